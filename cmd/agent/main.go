@@ -16,13 +16,24 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/theotruvelot/g0s/internal/agent/collector"
+	"github.com/theotruvelot/g0s/internal/agent/control"
 	"github.com/theotruvelot/g0s/internal/agent/converter"
+	"github.com/theotruvelot/g0s/internal/agent/enrollment"
+	"github.com/theotruvelot/g0s/internal/agent/handshake"
 	"github.com/theotruvelot/g0s/internal/agent/healthcheck"
+	"github.com/theotruvelot/g0s/internal/agent/spool"
+	"github.com/theotruvelot/g0s/internal/agent/systemd"
 	"github.com/theotruvelot/g0s/pkg/logger"
+	pbauth "github.com/theotruvelot/g0s/pkg/proto/auth"
+	pbcontrol "github.com/theotruvelot/g0s/pkg/proto/control"
+	pbhealth "github.com/theotruvelot/g0s/pkg/proto/health"
 	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"github.com/theotruvelot/g0s/pkg/utils"
+	"github.com/theotruvelot/g0s/pkg/version"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -34,6 +45,12 @@ const (
 	_defaultLogLevel           = "info"
 	_defaultLogFormat          = "json"
 	_defaultGRPCPort           = "9090"
+	_defaultCredentialFile     = "/var/lib/g0s/agent.credential"
+	_defaultSpoolDir           = "/var/lib/g0s/spool"
+	_defaultSpoolMaxSizeMB     = 100
+	_defaultTopProcesses       = 10
+	_defaultCmdlineMaxLength   = 256
+	_enrollTimeout             = 30 * time.Second
 
 	_minConnectTimeout = 10 * time.Second
 	_keepaliveTime     = 60 * time.Second
@@ -47,10 +64,26 @@ const (
 var (
 	grpcAddr            string
 	apiToken            string
+	joinToken           string
+	credentialFile      string
+	spoolDir            string
+	spoolMaxSizeMB      int64
+	topProcesses        int
+	cmdlineMaxLength    int
+	allowSignal         []string
+	collectSystemd      bool
+	allowUnit           []string
+	includeInterface    []string
+	excludeInterface    []string
+	tags                map[string]string
 	interval            int
 	logFormat           string
 	logLevel            string
 	healthCheckInterval int
+	tlsCAFile           string
+	tlsCertFile         string
+	tlsKeyFile          string
+	tlsServerName       string
 )
 
 func main() {
@@ -62,22 +95,38 @@ func main() {
 	}
 
 	rootCmd.Flags().StringVar(&grpcAddr, "grpc-addr", _defaultGRPCPort, "Server gRPC address (required, e.g. localhost:9090)")
-	rootCmd.Flags().StringVarP(&apiToken, "token", "t", "", "API token for authentication (required)")
+	rootCmd.Flags().StringVarP(&apiToken, "token", "t", "", "Unused, agents authenticate with the credential they enroll for")
+	rootCmd.Flags().StringVar(&joinToken, "join-token", "", "Single-use join token to enroll the host, only needed on first start")
+	rootCmd.Flags().StringVar(&credentialFile, "credential-file", _defaultCredentialFile, "File the credential of the host is stored in once enrolled")
+	rootCmd.Flags().StringVar(&spoolDir, "spool-dir", _defaultSpoolDir, "Directory metrics are kept in until the server acknowledges them, empty to drop them while it is unreachable")
+	rootCmd.Flags().Int64Var(&spoolMaxSizeMB, "spool-max-size", _defaultSpoolMaxSizeMB, "Size in MB of the spool, the oldest metrics are dropped beyond it")
+	rootCmd.Flags().IntVar(&topProcesses, "top-processes", _defaultTopProcesses, "Number of top processes by CPU, and by memory, reported every interval, 0 to disable the process collector")
+	rootCmd.Flags().IntVar(&cmdlineMaxLength, "cmdline-max-length", _defaultCmdlineMaxLength, "Length in bytes the command line of the reported processes is truncated to, 0 to keep it whole")
+	rootCmd.Flags().StringSliceVar(&allowSignal, "allow-signal", nil, "Name glob of the processes operators may signal from the server, repeatable, e.g. --allow-signal nginx --allow-signal 'php-fpm*'; every signal is refused by default")
+	rootCmd.Flags().BoolVar(&collectSystemd, "systemd", true, "Report the state of the systemd services, when systemd manages the host")
+	rootCmd.Flags().StringSliceVar(&allowUnit, "allow-unit", nil, "Name glob of the systemd units operators may start, stop or restart from the server, repeatable, e.g. --allow-unit nginx --allow-unit 'app-*.service'; a glob without unit type matches services, every operation is refused by default")
+	rootCmd.Flags().StringSliceVar(&includeInterface, "include-interface", nil, "Name glob of the network interfaces reported, repeatable, e.g. --include-interface 'eth*'; every interface is reported by default")
+	rootCmd.Flags().StringSliceVar(&excludeInterface, "exclude-interface", nil, "Name glob of the network interfaces not reported, repeatable, e.g. --exclude-interface 'veth*' --exclude-interface lo")
+	rootCmd.Flags().StringToStringVar(&tags, "tag", nil, "Tag of the host in the server inventory, repeatable, e.g. --tag env=prod")
 	rootCmd.Flags().IntVarP(&interval, "interval", "i", _defaultCollectionInterval, "Collection interval in seconds")
 	rootCmd.Flags().StringVar(&logFormat, "log-format", _defaultLogFormat, "Log format: json or console")
 	rootCmd.Flags().StringVar(&logLevel, "log-level", _defaultLogLevel, "Log level: debug, info, warn, error")
 	rootCmd.Flags().IntVar(&healthCheckInterval, "health-check-interval", _defaultHealthInterval, "Health check interval in seconds")
+	rootCmd.Flags().StringVar(&tlsCAFile, "tls-ca", "", "CA file (PEM) used to verify the server certificate, enables TLS")
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "Client certificate file (PEM) identifying this host, enables TLS")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "Client private key file (PEM)")
+	rootCmd.Flags().StringVar(&tlsServerName, "tls-server-name", "", "Override the server name used to verify the server certificate")
 
-	err := rootCmd.MarkFlagRequired("grpc-addr")
-	err = rootCmd.MarkFlagRequired("token")
+	_ = rootCmd.MarkFlagRequired("grpc-addr")
+	_ = rootCmd.Flags().MarkDeprecated("token", "enroll the agent with --join-token instead")
 
-	if err = rootCmd.Execute(); err != nil {
+	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func runAgent(_ *cobra.Command, _ []string) error {
+func runAgent(cmd *cobra.Command, _ []string) error {
 	logger.InitLogger(logger.Config{
 		Level:     logLevel,
 		Format:    logFormat,
@@ -95,8 +144,18 @@ func runAgent(_ *cobra.Command, _ []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// The systemd client is shared by the systemd collector and the unit operations
+	var systemdClient *systemd.Client
+	if systemd.Booted() {
+		systemdClient = systemd.NewSystemClient()
+		defer systemdClient.Close()
+	}
+
 	// Initialize collectors
-	collectors := initCollectors()
+	collectors, err := initCollectors(systemdClient)
+	if err != nil {
+		return err
+	}
 	defer cleanupCollectors(collectors)
 
 	sigChan := make(chan os.Signal, 1)
@@ -106,8 +165,15 @@ func runAgent(_ *cobra.Command, _ []string) error {
 		logger.Info("Received shutdown signal", zap.String("signal", sig.String()))
 		cancel()
 	}()
+	creds, err := transportCredentials()
+	if err != nil {
+		return fmt.Errorf("failed to load TLS configuration: %w", err)
+	}
+
+	credential := &enrollment.Credential{}
 	conn, err := grpc.NewClient(grpcAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(credential),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                _keepaliveTime,
 			Timeout:             _keepaliveTimeout,
@@ -136,13 +202,52 @@ func runAgent(_ *cobra.Command, _ []string) error {
 		logger.Error("Failed to get hostname set hostname to UUID", zap.Error(err), zap.String("hostname", hostname))
 	}
 
+	controlService, err := control.New(pbcontrol.NewControlServiceClient(conn), systemdClient, logger.GetLogger(), hostname, control.Config{
+		SignalProcesses: allowSignal,
+		Units:           allowUnit,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid --allow-signal or --allow-unit: %w", err)
+	}
+
+	if err = loadCredential(ctx, conn, credential, hostname); err != nil {
+		return err
+	}
+
 	healthService := healthcheck.New(conn, logger.GetLogger(), hostname)
 	if err = healthService.Start(ctx, time.Duration(healthCheckInterval)*time.Second); err != nil {
 		return fmt.Errorf("failed to start health check service: %w", err)
 	}
+	controlService.Start(ctx)
+
+	// The default spool directory is only writable by root, other users still get an agent
+	// skipping collection while the server is unreachable. A --spool-dir given explicitly must be usable.
+	if spoolDir != "" && !cmd.Flags().Changed("spool-dir") {
+		if err := utils.CheckWritableDir(spoolDir); err != nil {
+			logger.Warn("Spool disabled, set --spool-dir to a writable directory to keep metrics while the server is unreachable",
+				zap.String("spool_dir", spoolDir),
+				zap.Error(err))
+			spoolDir = ""
+		}
+	}
 
+	var metricsSpool *spool.Spool
+	if spoolDir != "" {
+		metricsSpool, err = spool.Open(spoolDir, spoolMaxSizeMB<<20, time.Duration(interval)*time.Second, logger.GetLogger())
+		if err != nil {
+			return err
+		}
+		defer metricsSpool.Close()
+		if pending := metricsSpool.PendingBytes(); pending > 0 {
+			logger.Info("Metrics left in the spool are sent once connected", zap.Int64("spool_bytes", pending))
+		}
+	}
+
+	hello := func(ctx context.Context) (*handshake.Result, error) {
+		return handshake.Hello(ctx, pbhealth.NewHealthServiceClient(conn), hostname, collectors.names())
+	}
 	metricClient := pb.NewMetricServiceClient(conn)
-	if err = runMetricsCollection(ctx, healthService, metricClient, collectors); err != nil {
+	if err = runMetricsCollection(ctx, healthService, hello, metricClient, collectors, metricsSpool); err != nil {
 		if errors.Is(err, context.Canceled) {
 			logger.Info("Metrics collection stopped due to shutdown")
 			return nil
@@ -154,26 +259,92 @@ func runAgent(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func runMetricsCollection(ctx context.Context, healthService *healthcheck.Service, client pb.MetricServiceClient, collectors *collectors) error {
+// transportCredentials returns TLS credentials when a CA or a client certificate is configured
+func transportCredentials() (credentials.TransportCredentials, error) {
+	if tlsCAFile == "" && tlsCertFile == "" && tlsKeyFile == "" {
+		logger.Warn("TLS is disabled, connecting to the server in plaintext")
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig, err := utils.LoadClientTLSConfig(tlsCAFile, tlsCertFile, tlsKeyFile, tlsServerName)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// loadCredential sets the credential of the host, enrolling it first on the first start.
+// Agents with a client certificate, or reporting to a server allowing unenrolled agents, don't need one.
+func loadCredential(ctx context.Context, conn *grpc.ClientConn, credential *enrollment.Credential, hostname string) error {
+	enrollCtx, cancel := context.WithTimeout(ctx, _enrollTimeout)
+	defer cancel()
+
+	token, enrolled, err := enrollment.EnsureCredential(enrollCtx, pbauth.NewAuthServiceClient(conn), credentialFile, joinToken, hostname)
+	if err != nil {
+		if errors.Is(err, enrollment.ErrNotEnrolled) {
+			if tlsCertFile != "" {
+				logger.Info("No agent credential, authenticating with the client certificate")
+			} else {
+				logger.Warn("Agent is not enrolled, the server will reject it unless it allows unenrolled agents")
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to load the agent credential: %w", err)
+	}
+
+	if enrolled {
+		logger.Info("Agent enrolled", zap.String("hostname", hostname), zap.String("credential_file", credentialFile))
+	} else if joinToken != "" {
+		logger.Warn("Agent already enrolled, ignoring the join token", zap.String("credential_file", credentialFile))
+	}
+	credential.Set(token)
+	return nil
+}
+
+// runMetricsCollection collects metrics every interval and sends them to the server. With a
+// spool, metrics are collected even while the server is unhealthy and sent once it is back.
+// The agent says hello to the server before opening every metrics stream, as the server may
+// have been upgraded or downgraded in between.
+func runMetricsCollection(ctx context.Context, healthService *healthcheck.Service, hello func(context.Context) (*handshake.Result, error), client pb.MetricServiceClient, collectors *collectors, metricsSpool *spool.Spool) error {
 	ticker := time.NewTicker(time.Duration(interval) * time.Second)
 	defer ticker.Stop()
 
 	logger.Info("Starting metrics collection",
+		zap.String("version", version.Version),
 		zap.String("grpc_addr", grpcAddr),
 		zap.Duration("collection_interval", time.Duration(interval)*time.Second),
-		zap.Duration("health_interval", time.Duration(healthCheckInterval)*time.Second))
+		zap.Duration("health_interval", time.Duration(healthCheckInterval)*time.Second),
+		zap.Bool("spool", metricsSpool != nil))
 
+	// Without a spool, payloads are sent on stream one at a time. With a spool, they are sent
+	// from the spool on spoolStream, in batches if the server supports them.
 	var stream pb.MetricService_StreamMetricsClient
+	var spoolStream spool.Stream
+	var negotiated *handshake.Result
 	var lastHealthy bool
 
+	closeStreams := func() error {
+		negotiated = nil
+		if spoolStream != nil {
+			// Closing rewinds the spool, the payloads not acknowledged are sent on the next stream
+			if err := spoolStream.Close(); err != nil {
+				logger.Debug("Failed to close metrics stream", zap.Error(err))
+			}
+			spoolStream = nil
+		}
+		if stream != nil {
+			err := stream.CloseSend()
+			stream = nil
+			return err
+		}
+		return nil
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			if stream != nil {
-				err := stream.CloseSend()
-				if err != nil {
-					return err
-				}
+			if err := closeStreams(); err != nil {
+				return err
 			}
 			return ctx.Err()
 
@@ -184,52 +355,121 @@ func runMetricsCollection(ctx context.Context, healthService *healthcheck.Servic
 				if isHealthy {
 					logger.Info("Server became healthy, resuming metrics collection")
 				} else {
-					logger.Info("Server became unhealthy, pausing metrics collection")
-					if stream != nil {
-						err := stream.CloseSend()
-						if err != nil {
-							return err
-						}
-						stream = nil
+					if metricsSpool != nil {
+						logger.Info("Server became unhealthy, spooling metrics until it is back")
+					} else {
+						logger.Info("Server became unhealthy, pausing metrics collection")
+					}
+					if err := closeStreams(); err != nil {
+						return err
 					}
 				}
 				lastHealthy = isHealthy
 			}
 
-			if !isHealthy {
+			if !isHealthy && metricsSpool == nil {
 				logger.Debug("Skipping metrics collection, server is unhealthy")
 				continue
 			}
 
-			if stream == nil {
-				newStream, err := connectWithRetry(ctx, client)
+			payload := collectMetrics(collectors)
+			if metricsSpool != nil {
+				if err := metricsSpool.Append(payload); err != nil {
+					logger.Error("Failed to spool metrics, dropping them", zap.Error(err))
+				}
+			}
+
+			if !isHealthy {
+				logger.Debug("Server is unhealthy, metrics spooled",
+					zap.Int64("spool_bytes", metricsSpool.PendingBytes()))
+				continue
+			}
+
+			if negotiated == nil {
+				result, err := hello(ctx)
 				if err != nil {
-					if errors.Is(err, context.Canceled) {
+					if errors.Is(err, handshake.ErrIncompatible) || errors.Is(err, context.Canceled) {
 						return err
 					}
-					logger.Error("Failed to create metrics stream", zap.Error(err))
+					logger.Error("Failed to say hello to the server", zap.Error(err))
 					continue
 				}
-				stream = newStream
-				logger.Info("Metrics stream established")
+				negotiated = result
+				logger.Info("Connected to the server",
+					zap.String("server_version", formatServerVersion(negotiated.ServerVersion)),
+					zap.Strings("features", negotiated.Features))
 			}
 
-			if err := collectAndSendMetrics(true, collectors, stream); err != nil {
+			var err error
+			if metricsSpool != nil {
+				if spoolStream == nil {
+					spoolStream, err = connectWithRetry(ctx, func(ctx context.Context) (spool.Stream, error) {
+						return metricsSpool.Connect(ctx, client, negotiated)
+					})
+				}
+				if err == nil {
+					err = flushSpool(spoolStream)
+				}
+			} else {
+				if stream == nil {
+					stream, err = connectWithRetry(ctx, func(ctx context.Context) (pb.MetricService_StreamMetricsClient, error) {
+						return client.StreamMetrics(ctx)
+					})
+				}
+				if err == nil {
+					err = sendMetrics(stream, payload)
+				}
+			}
+
+			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return err
 				}
 				logger.Error("Failed to send metrics, closing stream", zap.Error(err))
-				err := stream.CloseSend()
-				if err != nil {
+				if err := closeStreams(); err != nil {
 					return err
 				}
-				stream = nil
 			}
 		}
 	}
 }
 
-func connectWithRetry(ctx context.Context, client pb.MetricServiceClient) (pb.MetricService_StreamMetricsClient, error) {
+// formatServerVersion returns the version of the server, which servers predating the handshake don't tell
+func formatServerVersion(serverVersion string) string {
+	if serverVersion == "" {
+		return "unknown"
+	}
+	return serverVersion
+}
+
+// flushSpool sends the payloads of the spool not sent yet, the latest one included
+func flushSpool(stream spool.Stream) error {
+	sent, err := stream.Flush()
+	if sent > 1 {
+		logger.Info("Sent spooled metrics", zap.Int("payloads", sent))
+	}
+	return err
+}
+
+// sendMetrics sends a payload and waits for its acknowledgment
+func sendMetrics(stream pb.MetricService_StreamMetricsClient, payload *pb.MetricsPayload) error {
+	if err := stream.Send(payload); err != nil {
+		return fmt.Errorf("failed to send metrics: %w", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("failed to receive acknowledgment: %w", err)
+	}
+
+	logger.Debug("Metrics sent successfully",
+		zap.String("status", resp.Status),
+		zap.String("message", resp.Message))
+	return nil
+}
+
+// connectWithRetry opens a metrics stream with connect, retrying with backoff until it succeeds
+// or ctx is done
+func connectWithRetry[T any](ctx context.Context, connect func(context.Context) (T, error)) (T, error) {
 	var retryCount int
 	backoffConfig := backoff.Config{
 		BaseDelay:  1.0 * time.Second,
@@ -239,8 +479,9 @@ func connectWithRetry(ctx context.Context, client pb.MetricServiceClient) (pb.Me
 	}
 
 	for {
-		stream, err := client.StreamMetrics(ctx)
+		stream, err := connect(ctx)
 		if err == nil {
+			logger.Info("Metrics stream established")
 			return stream, nil
 		}
 
@@ -263,7 +504,8 @@ func connectWithRetry(ctx context.Context, client pb.MetricServiceClient) (pb.Me
 
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			var zero T
+			return zero, ctx.Err()
 		case <-time.After(jitter):
 			continue
 		}
@@ -276,24 +518,66 @@ type collectors struct {
 	disk    *collector.DiskCollector
 	network *collector.NetworkCollector
 	host    *collector.HostCollector
+	load    *collector.LoadCollector
 	docker  *collector.DockerCollector
+	// process is nil when the process collector is disabled
+	process *collector.ProcessCollector
+	// systemd is nil when disabled or when systemd does not manage the host
+	systemd *collector.SystemdCollector
 }
 
-func initCollectors() *collectors {
+// names returns the names of the collectors enabled, as announced to the server
+func (c *collectors) names() []string {
+	names := []string{"cpu", "ram", "disk", "network", "host", "load"}
+	if c.docker != nil {
+		names = append(names, "docker")
+	}
+	if c.process != nil {
+		names = append(names, "process")
+	}
+	if c.systemd != nil {
+		names = append(names, "systemd")
+	}
+	return names
+}
+
+func initCollectors(systemdClient *systemd.Client) (*collectors, error) {
 	log := logger.GetLogger()
+	networkCollector, err := collector.NewNetworkCollector(log, includeInterface, excludeInterface)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --include-interface or --exclude-interface: %w", err)
+	}
+
 	dockerCollector, err := collector.NewDockerCollector(log)
 	if err != nil {
 		log.Error("Failed to initialize Docker collector", zap.Error(err))
 	}
 
+	var processCollector *collector.ProcessCollector
+	if topProcesses > 0 {
+		processCollector = collector.NewProcessCollector(log, topProcesses, cmdlineMaxLength)
+	}
+
+	var systemdCollector *collector.SystemdCollector
+	if collectSystemd {
+		if systemdClient != nil {
+			systemdCollector = collector.NewSystemdCollector(log, systemdClient)
+		} else {
+			log.Info("systemd does not manage this host, the systemd collector is disabled")
+		}
+	}
+
 	return &collectors{
 		cpu:     collector.NewCPUCollector(log),
 		ram:     collector.NewRAMCollector(log),
 		disk:    collector.NewDiskCollector(log),
-		network: collector.NewNetworkCollector(log),
+		network: networkCollector,
 		host:    collector.NewHostCollector(log),
+		load:    collector.NewLoadCollector(log),
 		docker:  dockerCollector,
-	}
+		process: processCollector,
+		systemd: systemdCollector,
+	}, nil
 }
 
 type collectionResult struct {
@@ -302,16 +586,16 @@ type collectionResult struct {
 	diskMetrics    []model.DiskMetrics
 	networkMetrics []model.NetworkMetrics
 	hostMetrics    model.HostMetrics
+	loadMetrics    model.SystemLoadMetrics
 	dockerMetrics  []model.DockerMetrics
+	processMetrics []model.ProcessMetrics
+	systemdMetrics []model.SystemdUnitMetrics
 	errors         []error
 }
 
-func collectAndSendMetrics(isServerHealthy bool, c *collectors, stream pb.MetricService_StreamMetricsClient) error {
-	if !isServerHealthy {
-		logger.Warn("Skipping metrics transmission, server unhealthy")
-		return nil
-	}
-
+// collectMetrics collects the metrics of every collector, a failing collector leaving its
+// metrics empty
+func collectMetrics(c *collectors) *pb.MetricsPayload {
 	result := &collectionResult{
 		errors: make([]error, 0),
 	}
@@ -330,6 +614,12 @@ func collectAndSendMetrics(isServerHealthy bool, c *collectors, stream pb.Metric
 		result.errors = append(result.errors, fmt.Errorf("failed to collect host metrics: %w", err))
 	}
 
+	result.loadMetrics, err = c.load.Collect()
+	if err != nil {
+		logger.Error("Failed to collect load metrics", zap.Error(err))
+		result.errors = append(result.errors, fmt.Errorf("failed to collect load metrics: %w", err))
+	}
+
 	result.networkMetrics, err = c.network.Collect()
 	if err != nil {
 		logger.Error("Failed to collect network metrics", zap.Error(err))
@@ -388,6 +678,36 @@ func collectAndSendMetrics(isServerHealthy bool, c *collectors, stream pb.Metric
 		}()
 	}
 
+	if c.process != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			processMetrics, err := c.process.Collect()
+			if err != nil {
+				addError(fmt.Errorf("failed to collect process metrics: %w", err))
+				return
+			}
+			mu.Lock()
+			result.processMetrics = processMetrics
+			mu.Unlock()
+		}()
+	}
+
+	if c.systemd != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			systemdMetrics, err := c.systemd.Collect()
+			if err != nil {
+				addError(fmt.Errorf("failed to collect systemd metrics: %w", err))
+				return
+			}
+			mu.Lock()
+			result.systemdMetrics = systemdMetrics
+			mu.Unlock()
+		}()
+	}
+
 	wg.Wait()
 
 	if len(result.errors) > 0 {
@@ -395,33 +715,33 @@ func collectAndSendMetrics(isServerHealthy bool, c *collectors, stream pb.Metric
 		// Don't return error, continue with partial metrics
 	}
 
-	pbMetrics := &pb.MetricsPayload{
-		Host:      converter.ConvertHostMetrics(result.hostMetrics),
-		Cpu:       converter.ConvertCPUMetrics(result.cpuMetrics),
-		Ram:       converter.ConvertRAMMetrics(result.ramMetrics),
-		Disk:      converter.ConvertDiskMetrics(result.diskMetrics),
-		Network:   converter.ConvertNetworkMetrics(result.networkMetrics),
-		Docker:    converter.ConvertDockerMetrics(result.dockerMetrics),
-		Timestamp: timestamppb.Now(),
-	}
+	hostMetrics := converter.ConvertHostMetrics(result.hostMetrics)
+	hostMetrics.AgentVersion = version.Version
+	hostMetrics.Tags = tags
+	hostMetrics.CollectionIntervalSeconds = uint32(interval)
 
-	if err := stream.Send(pbMetrics); err != nil {
-		return fmt.Errorf("failed to send metrics: %w", err)
-	}
-	resp, err := stream.Recv()
-	if err != nil {
-		return fmt.Errorf("failed to receive acknowledgment: %w", err)
+	pbMetrics := &pb.MetricsPayload{
+		Host:         hostMetrics,
+		Cpu:          converter.ConvertCPUMetrics(result.cpuMetrics),
+		Ram:          converter.ConvertRAMMetrics(result.ramMetrics),
+		Disk:         converter.ConvertDiskMetrics(result.diskMetrics),
+		Network:      converter.ConvertNetworkMetrics(result.networkMetrics),
+		Docker:       converter.ConvertDockerMetrics(result.dockerMetrics),
+		Processes:    converter.ConvertProcessMetrics(result.processMetrics),
+		SystemdUnits: converter.ConvertSystemdUnitMetrics(result.systemdMetrics),
+		Load:         converter.ConvertSystemLoadMetrics(result.loadMetrics),
+		Timestamp:    timestamppb.Now(),
 	}
 
-	logger.Debug("Metrics sent successfully",
-		zap.String("status", resp.Status),
-		zap.String("message", resp.Message),
+	logger.Debug("Metrics collected",
 		zap.Int("cpu_metrics", len(result.cpuMetrics)),
 		zap.Int("disk_metrics", len(result.diskMetrics)),
 		zap.Int("network_metrics", len(result.networkMetrics)),
-		zap.Int("docker_metrics", len(result.dockerMetrics)))
+		zap.Int("docker_metrics", len(result.dockerMetrics)),
+		zap.Int("process_metrics", len(result.processMetrics)),
+		zap.Int("systemd_metrics", len(result.systemdMetrics)))
 
-	return nil
+	return pbMetrics
 }
 
 // cleanupCollectors properly closes and cleans up all collectors
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/config"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// _adminCallTimeout bounds every call of the management commands
+const _adminCallTimeout = 10 * time.Second
+
+// runAdmin calls fn with an admin service authenticated as the user logged in through the TUI
+func runAdmin(op string, fn func(ctx context.Context, adminService *services.AdminService) error) error {
+	return runCommand(op, func(ctx context.Context, grpcClients *clients.Clients) error {
+		return fn(ctx, services.NewAdminService(grpcClients))
+	})
+}
+
+// runCommand calls fn with gRPC clients authenticated as the user logged in through the TUI
+func runCommand(op string, fn func(ctx context.Context, grpcClients *clients.Clients) error) error {
+	return runWithClients(op, func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), _adminCallTimeout)
+	}, fn)
+}
+
+// runStreamCommand is runCommand for commands following a stream, they run until interrupted
+func runStreamCommand(op string, fn func(ctx context.Context, grpcClients *clients.Clients) error) error {
+	return runWithClients(op, func() (context.Context, context.CancelFunc) {
+		return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	}, fn)
+}
+
+func runWithClients(op string, newContext func() (context.Context, context.CancelFunc), fn func(ctx context.Context, grpcClients *clients.Clients) error) error {
+	logger.InitLogger(logger.Config{
+		Level:      logLevel,
+		Format:     "json",
+		OutputPath: "./logs.logs",
+		Component:  "cli",
+	})
+	defer logger.Sync()
+
+	if !config.ConfigExists() {
+		return &cliError{op: op, err: fmt.Errorf("not logged in, run g0s-cli to log in first")}
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return &cliError{op: "loading config", err: err}
+	}
+
+	grpcClients, err := clients.NewClients(cfg.ServerURL, cfg.TLS)
+	if err != nil {
+		return &cliError{op: "creating gRPC clients", err: err}
+	}
+	defer grpcClients.Close()
+	grpcClients.Authenticate(cfg)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	if err := fn(ctx, grpcClients); err != nil {
+		return &cliError{op: op, err: fmt.Errorf("%s", status.Convert(err).Message())}
+	}
+	return nil
+}
+
+func newTableWriter() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
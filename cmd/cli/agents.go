@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+)
+
+var joinTokenTTL time.Duration
+
+func newAgentsCmd() *cobra.Command {
+	agentsCmd := &cobra.Command{
+		Use:   "agents",
+		Short: "Manage agent enrollment (admin only)",
+	}
+
+	joinTokenCmd := &cobra.Command{
+		Use:   "join-token",
+		Short: "Create a single-use join token to enroll an agent, printed once",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAdmin("creating join token", func(ctx context.Context, adminService *services.AdminService) error {
+				res, err := adminService.CreateJoinToken(ctx, joinTokenTTL)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Join token %s created, it expires at %s and won't be shown again:\n\n%s\n",
+					res.GetId(), formatTimestamp(res.GetExpiresAt()), res.GetValue())
+				return nil
+			})
+		},
+	}
+	joinTokenCmd.Flags().DurationVar(&joinTokenTTL, "ttl", 0, "Lifetime of the token, at most 168h (default 1h)")
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <hostname>",
+		Short: "Revoke the credential of an agent, it has to enroll again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("revoking agent", func(ctx context.Context, adminService *services.AdminService) error {
+				agent, err := adminService.RevokeAgent(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Agent %s revoked\n", agent.GetHostname())
+				return nil
+			})
+		},
+	}
+
+	agentsCmd.AddCommand(joinTokenCmd, revokeCmd)
+	return agentsCmd
+}
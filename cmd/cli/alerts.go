@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+	"github.com/theotruvelot/g0s/pkg/proto/alert"
+)
+
+var (
+	alertState       string
+	alertHostFilter  string
+	alertLimit       int
+	alertSeverity    string
+	alertDescription string
+)
+
+func newAlertsCmd() *cobra.Command {
+	alertsCmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Browse the alerts of the hosts you can see and manage the alert rules",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the latest alerts, most recent first",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			state, err := parseAlertState(alertState)
+			if err != nil {
+				return err
+			}
+			return runCommand("listing alerts", func(ctx context.Context, grpcClients *clients.Clients) error {
+				alerts, err := services.NewAlertService(grpcClients).ListAlerts(ctx, state, alertHostFilter, alertLimit)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "STATE\tSEVERITY\tRULE\tHOSTNAME\tVALUE\tLABELS\tSTARTED\tRESOLVED")
+				for _, a := range alerts {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						formatAlertState(a.GetState()),
+						a.GetSeverity(),
+						a.GetRule(),
+						a.GetHostname(),
+						strconv.FormatFloat(a.GetValue(), 'g', 6, 64),
+						formatTags(a.GetLabels()),
+						formatTimestamp(a.GetStartedAt()),
+						formatTimestamp(a.GetResolvedAt()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+	listCmd.Flags().StringVar(&alertState, "state", "", "Only list the alerts in this state: pending, firing or resolved")
+	listCmd.Flags().StringVar(&alertHostFilter, "host", "", "Only list the alerts of the hosts matching this regex")
+	listCmd.Flags().IntVar(&alertLimit, "limit", 100, "Maximum number of alerts listed, at most 1000")
+
+	alertsCmd.AddCommand(listCmd, newAlertRulesCmd(), newSilencesCmd(), newMaintenanceCmd())
+	return alertsCmd
+}
+
+func newAlertRulesCmd() *cobra.Command {
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Manage the alert rules (admin only, listing is open to every user)",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the alert rules",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCommand("listing alert rules", func(ctx context.Context, grpcClients *clients.Clients) error {
+				rules, err := services.NewAlertService(grpcClients).ListAlertRules(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "NAME\tSEVERITY\tSOURCE\tEXPR")
+				for _, rule := range rules {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rule.GetName(), rule.GetSeverity(), rule.GetSource(), rule.GetExpr())
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	putCmd := &cobra.Command{
+		Use:   "put <name> <expr>",
+		Short: `Create or replace an alert rule, e.g. put high-cpu 'cpu_usage_percent_avg > 90 for 5m on host=~"web-.*"'`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("saving alert rule", func(ctx context.Context, grpcClients *clients.Clients) error {
+				rule, err := services.NewAlertService(grpcClients).PutAlertRule(ctx, &alert.AlertRule{
+					Name:        args[0],
+					Expr:        args[1],
+					Severity:    alertSeverity,
+					Description: alertDescription,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Alert rule %s saved\n", rule.GetName())
+				return nil
+			})
+		},
+	}
+	putCmd.Flags().StringVar(&alertSeverity, "severity", "", "Severity of the rule: info, warning or critical (default warning)")
+	putCmd.Flags().StringVar(&alertDescription, "description", "", "Description of the rule")
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete an alert rule and resolve its alerts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("deleting alert rule", func(ctx context.Context, grpcClients *clients.Clients) error {
+				if err := services.NewAlertService(grpcClients).DeleteAlertRule(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Alert rule %s deleted\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	rulesCmd.AddCommand(listCmd, putCmd, deleteCmd)
+	return rulesCmd
+}
+
+// parseAlertState returns the alert state named s, unspecified if s is empty
+func parseAlertState(s string) (alert.AlertState, error) {
+	if s == "" {
+		return alert.AlertState_ALERT_STATE_UNSPECIFIED, nil
+	}
+	state, ok := alert.AlertState_value["ALERT_STATE_"+strings.ToUpper(s)]
+	if !ok || state == int32(alert.AlertState_ALERT_STATE_UNSPECIFIED) {
+		return 0, fmt.Errorf("invalid alert state %q, expected pending, firing or resolved", s)
+	}
+	return alert.AlertState(state), nil
+}
+
+// formatAlertState returns the state without its enum prefix, e.g. "firing"
+func formatAlertState(state alert.AlertState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "ALERT_STATE_"))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+)
+
+var hostGroupTags map[string]string
+
+func newHostGroupsCmd() *cobra.Command {
+	hostGroupsCmd := &cobra.Command{
+		Use:   "hostgroups",
+		Short: "Manage the host groups users can be limited to (admin only)",
+	}
+
+	putCmd := &cobra.Command{
+		Use:   "put <name> [host-pattern]...",
+		Short: "Create a host group or replace its hostname patterns and tags, e.g. put web 'web-*' --tag role=web",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 1 && len(hostGroupTags) == 0 {
+				return errors.New("a host pattern or a --tag is required")
+			}
+			return runAdmin("saving host group", func(ctx context.Context, adminService *services.AdminService) error {
+				group, err := adminService.PutHostGroup(ctx, args[0], args[1:], hostGroupTags)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Host group %s saved\n", group.GetName())
+				return nil
+			})
+		},
+	}
+	putCmd.Flags().StringToStringVar(&hostGroupTags, "tag", nil, "Include the hosts having this tag, repeatable, every tag is required, e.g. --tag env=prod")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the host groups",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAdmin("listing host groups", func(ctx context.Context, adminService *services.AdminService) error {
+				groups, err := adminService.ListHostGroups(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "NAME\tHOST PATTERNS\tHOST TAGS")
+				for _, group := range groups {
+					fmt.Fprintf(w, "%s\t%s\t%s\n", group.GetName(), formatOptional(strings.Join(group.GetHostPatterns(), ",")), formatTags(group.GetHostTags()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a host group, users limited to it no longer see its hosts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("deleting host group", func(ctx context.Context, adminService *services.AdminService) error {
+				if err := adminService.DeleteHostGroup(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Host group %s deleted\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	hostGroupsCmd.AddCommand(putCmd, listCmd, deleteCmd)
+	return hostGroupsCmd
+}
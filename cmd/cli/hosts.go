@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+	"github.com/theotruvelot/g0s/pkg/proto/control"
+	"github.com/theotruvelot/g0s/pkg/proto/host"
+)
+
+var (
+	hostTags        map[string]string
+	hostEventFilter string
+	signalPID       int32
+	signalName      string
+	signalValue     string
+	signalComment   string
+	unitComment     string
+	actionsLimit    int32
+)
+
+func newHostsCmd() *cobra.Command {
+	hostsCmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Browse the inventory of the hosts you can see",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the hosts",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCommand("listing hosts", func(ctx context.Context, grpcClients *clients.Clients) error {
+				hosts, err := services.NewHostService(grpcClients).ListHosts(ctx, hostTags)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "HOSTNAME\tSTATE\tPLATFORM\tKERNEL\tAGENT\tIP\tTAGS\tLAST SEEN")
+				for _, h := range hosts {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						h.GetHostname(),
+						formatHostStatus(h),
+						formatPlatform(h),
+						h.GetKernelVersion(),
+						formatOptional(h.GetAgentVersion()),
+						formatOptional(h.GetIpAddress()),
+						formatTags(h.GetTags()),
+						formatTimestamp(h.GetLastSeenAt()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+	listCmd.Flags().StringToStringVar(&hostTags, "tag", nil, "Only list the hosts having this tag, repeatable, e.g. --tag env=prod")
+
+	showCmd := &cobra.Command{
+		Use:   "show <hostname>",
+		Short: "Show the inventory of a host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("getting host", func(ctx context.Context, grpcClients *clients.Clients) error {
+				h, err := services.NewHostService(grpcClients).GetHost(ctx, args[0])
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintf(w, "Hostname:\t%s\n", h.GetHostname())
+				fmt.Fprintf(w, "ID:\t%s\n", h.GetId())
+				fmt.Fprintf(w, "State:\t%s (since %s)\n", formatHostState(h.GetState()), formatTimestamp(h.GetStateChangedAt()))
+				fmt.Fprintf(w, "OS:\t%s\n", formatOptional(h.GetOs()))
+				fmt.Fprintf(w, "Platform:\t%s\n", formatPlatform(h))
+				fmt.Fprintf(w, "Kernel:\t%s\n", formatOptional(strings.TrimSpace(h.GetKernelVersion()+" "+h.GetKernelArch())))
+				fmt.Fprintf(w, "Virtualization:\t%s\n", formatOptional(strings.TrimSpace(h.GetVirtualizationSystem()+" "+h.GetVirtualizationRole())))
+				fmt.Fprintf(w, "Agent version:\t%s\n", formatOptional(h.GetAgentVersion()))
+				fmt.Fprintf(w, "Agent protocol:\t%s\n", formatProtocol(h))
+				fmt.Fprintf(w, "Collectors:\t%s\n", formatOptional(strings.Join(h.GetCollectors(), ", ")))
+				fmt.Fprintf(w, "IP address:\t%s\n", formatOptional(h.GetIpAddress()))
+				fmt.Fprintf(w, "Tags:\t%s\n", formatTags(h.GetTags()))
+				fmt.Fprintf(w, "First seen:\t%s\n", formatTimestamp(h.GetFirstSeenAt()))
+				fmt.Fprintf(w, "Last seen:\t%s\n", formatTimestamp(h.GetLastSeenAt()))
+				fmt.Fprintf(w, "Last metrics:\t%s\n", formatTimestamp(h.GetLastMetricsAt()))
+				fmt.Fprintf(w, "Enrolled:\t%s\n", formatTimestamp(h.GetEnrolledAt()))
+				fmt.Fprintf(w, "Revoked:\t%t\n", h.GetRevoked())
+				fmt.Fprintf(w, "In maintenance:\t%t\n", h.GetInMaintenance())
+				return w.Flush()
+			})
+		},
+	}
+
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Follow the state transitions of the hosts, until interrupted",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runStreamCommand("watching host events", func(ctx context.Context, grpcClients *clients.Clients) error {
+				return services.NewHostService(grpcClients).WatchHostEvents(ctx, hostEventFilter, func(event *host.HostEvent) {
+					fmt.Printf("%s %s %s -> %s: %s\n",
+						formatTimestamp(event.GetTimestamp()),
+						event.GetHostname(),
+						formatHostState(event.GetPreviousState()),
+						formatHostState(event.GetState()),
+						formatOptional(event.GetReason()))
+				})
+			})
+		},
+	}
+	eventsCmd.Flags().StringVar(&hostEventFilter, "host", "", "Only follow the hosts matching this regex")
+
+	decommissionCmd := &cobra.Command{
+		Use:   "decommission <hostname>",
+		Short: "Mark a host as decommissioned so it is no longer reported offline (admin only)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("decommissioning host", func(ctx context.Context, adminService *services.AdminService) error {
+				if err := adminService.DecommissionHost(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Host %s decommissioned\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	signalCmd := &cobra.Command{
+		Use:   "signal <hostname>",
+		Short: "Send a signal to processes of a host through its agent, e.g. signal web-1 --name nginx --signal HUP (operators and admins)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			signal, err := parseSignal(signalValue)
+			if err != nil {
+				return err
+			}
+			if (signalPID > 0) == (signalName != "") {
+				return errors.New("either --pid or --name is required")
+			}
+
+			return runCommand("signalling process", func(ctx context.Context, grpcClients *clients.Clients) error {
+				action, err := services.NewControlService(grpcClients).SignalProcess(ctx, &control.SignalProcessRequest{
+					Hostname: args[0],
+					Pid:      signalPID,
+					Name:     signalName,
+					Signal:   signal,
+					Comment:  signalComment,
+				})
+				if err != nil {
+					return err
+				}
+				if action.GetStatus() != control.ActionStatus_ACTION_STATUS_SUCCEEDED {
+					return fmt.Errorf("%s: %s", formatActionStatus(action.GetStatus()), action.GetMessage())
+				}
+				fmt.Printf("Sent %s to process %s on %s\n", formatSignal(signal), formatPIDs(action.GetPids()), action.GetHostname())
+				return nil
+			})
+		},
+	}
+	signalCmd.Flags().Int32Var(&signalPID, "pid", 0, "Signal the process with this pid")
+	signalCmd.Flags().StringVar(&signalName, "name", "", "Signal every process with this name")
+	signalCmd.Flags().StringVar(&signalValue, "signal", "TERM", "Signal to send: TERM, KILL or HUP")
+	signalCmd.Flags().StringVar(&signalComment, "comment", "", "Why, kept in the audit record")
+
+	unitCmd := &cobra.Command{
+		Use:   "unit <hostname> <unit> <start|stop|restart>",
+		Short: "Start, stop or restart a systemd unit of a host through its agent, e.g. unit web-1 nginx restart (operators and admins)",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(_ *cobra.Command, args []string) error {
+			operation, err := parseUnitOperation(args[2])
+			if err != nil {
+				return err
+			}
+
+			return runCommand("controlling unit", func(ctx context.Context, grpcClients *clients.Clients) error {
+				action, err := services.NewControlService(grpcClients).ControlUnit(ctx, &control.ControlUnitRequest{
+					Hostname:  args[0],
+					Unit:      args[1],
+					Operation: operation,
+					Comment:   unitComment,
+				})
+				if err != nil {
+					return err
+				}
+				if action.GetStatus() != control.ActionStatus_ACTION_STATUS_SUCCEEDED {
+					return fmt.Errorf("%s: %s", formatActionStatus(action.GetStatus()), action.GetMessage())
+				}
+				fmt.Printf("%s on %s\n", action.GetMessage(), action.GetHostname())
+				return nil
+			})
+		},
+	}
+	unitCmd.Flags().StringVar(&unitComment, "comment", "", "Why, kept in the audit record")
+
+	actionsCmd := &cobra.Command{
+		Use:   "actions [hostname]",
+		Short: "List the latest actions on the hosts and their results, from the audit records (operators and admins)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			hostname := ""
+			if len(args) == 1 {
+				hostname = args[0]
+			}
+			return runCommand("listing actions", func(ctx context.Context, grpcClients *clients.Clients) error {
+				actions, err := services.NewControlService(grpcClients).ListActions(ctx, hostname, actionsLimit)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "REQUESTED\tHOST\tACTION\tTARGET\tBY\tSTATUS\tPIDS\tMESSAGE\tCOMMENT")
+				for _, a := range actions {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						formatTimestamp(a.GetRequestedAt()),
+						a.GetHostname(),
+						a.GetAction(),
+						formatActionTarget(a),
+						a.GetRequestedBy(),
+						formatActionStatus(a.GetStatus()),
+						formatPIDs(a.GetPids()),
+						formatOptional(a.GetMessage()),
+						formatOptional(a.GetComment()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+	actionsCmd.Flags().Int32Var(&actionsLimit, "limit", 50, "Maximum number of actions to list")
+
+	hostsCmd.AddCommand(listCmd, showCmd, eventsCmd, decommissionCmd, signalCmd, unitCmd, actionsCmd)
+	return hostsCmd
+}
+
+// formatHostState returns the state without its enum prefix, e.g. "online"
+func formatHostState(state host.HostState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "HOST_STATE_"))
+}
+
+// formatHostStatus returns the state of the host, flagged when it is in maintenance
+func formatHostStatus(h *host.Host) string {
+	if h.GetInMaintenance() {
+		return formatHostState(h.GetState()) + " (maintenance)"
+	}
+	return formatHostState(h.GetState())
+}
+
+func formatPlatform(h *host.Host) string {
+	return formatOptional(strings.TrimSpace(h.GetPlatform() + " " + h.GetPlatformVersion()))
+}
+
+// formatProtocol returns the protocol version of the agent and the features negotiated, "-" for
+// agents predating the handshake
+func formatProtocol(h *host.Host) string {
+	if h.GetProtocolVersion() == 0 {
+		return "-"
+	}
+	if len(h.GetAgentFeatures()) == 0 {
+		return fmt.Sprintf("v%d", h.GetProtocolVersion())
+	}
+	return fmt.Sprintf("v%d (%s)", h.GetProtocolVersion(), strings.Join(h.GetAgentFeatures(), ", "))
+}
+
+// parseSignal returns the signal named s, with or without its SIG prefix, e.g. "HUP" or "sigterm"
+func parseSignal(s string) (control.Signal, error) {
+	name := strings.TrimPrefix(strings.ToUpper(s), "SIG")
+	value, ok := control.Signal_value["SIGNAL_"+name]
+	if !ok || value == int32(control.Signal_SIGNAL_UNSPECIFIED) {
+		return 0, fmt.Errorf("invalid signal %q, expected TERM, KILL or HUP", s)
+	}
+	return control.Signal(value), nil
+}
+
+// parseUnitOperation returns the operation named s, e.g. "restart"
+func parseUnitOperation(s string) (control.UnitOperation, error) {
+	value, ok := control.UnitOperation_value["UNIT_OPERATION_"+strings.ToUpper(s)]
+	if !ok || value == int32(control.UnitOperation_UNIT_OPERATION_UNSPECIFIED) {
+		return 0, fmt.Errorf("invalid operation %q, expected start, stop or restart", s)
+	}
+	return control.UnitOperation(value), nil
+}
+
+func formatSignal(signal control.Signal) string {
+	return strings.TrimPrefix(signal.String(), "SIGNAL_")
+}
+
+// formatActionStatus returns the status without its enum prefix, e.g. "refused"
+func formatActionStatus(status control.ActionStatus) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(status.String(), "ACTION_STATUS_"), "_", " "))
+}
+
+// formatActionTarget returns the pid, the process name or the unit the action was requested on
+func formatActionTarget(a *control.ActionResult) string {
+	if a.GetUnit() != "" {
+		return a.GetUnit()
+	}
+	if a.GetPid() > 0 {
+		return fmt.Sprintf("pid %d", a.GetPid())
+	}
+	return formatOptional(a.GetProcessName())
+}
+
+func formatPIDs(pids []int32) string {
+	if len(pids) == 0 {
+		return "-"
+	}
+	s := make([]string, len(pids))
+	for i, pid := range pids {
+		s[i] = fmt.Sprint(pid)
+	}
+	return strings.Join(s, ",")
+}
+
+// formatTags returns the tags as sorted key=value pairs
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func formatOptional(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -20,6 +20,7 @@ var (
 	serverURL string
 	apiToken  string
 	logLevel  string
+	tlsCfg    config.TLSConfig
 )
 
 func (e *cliError) Error() string {
@@ -39,7 +40,14 @@ func main() {
 
 	rootCmd.Flags().StringVarP(&serverURL, "server", "s", "", "Server URL to request metrics from (optional if config exists)")
 	rootCmd.Flags().StringVarP(&apiToken, "token", "t", "", "API token for authentication (optional if config exists)")
-	rootCmd.Flags().StringVarP(&logLevel, "log-level", "l", "info", "Log level: debug, info, warn, error")
+	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "info", "Log level: debug, info, warn, error")
+	rootCmd.Flags().BoolVar(&tlsCfg.Enabled, "tls", false, "Connect to the server over TLS")
+	rootCmd.Flags().StringVar(&tlsCfg.CAFile, "tls-ca", "", "CA certificate used to verify the server (implies --tls, defaults to the system roots)")
+	rootCmd.Flags().StringVar(&tlsCfg.CertFile, "tls-cert", "", "Client certificate for mutual TLS (implies --tls)")
+	rootCmd.Flags().StringVar(&tlsCfg.KeyFile, "tls-key", "", "Client private key for mutual TLS (implies --tls)")
+	rootCmd.Flags().StringVar(&tlsCfg.ServerName, "tls-server-name", "", "Override the server name used to verify the server certificate")
+
+	rootCmd.AddCommand(newUsersCmd(), newTokensCmd(), newHostGroupsCmd(), newAgentsCmd(), newHostsCmd(), newAlertsCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -70,7 +78,7 @@ func runCLI(_ *cobra.Command, _ []string) error {
 	})
 	defer logger.Sync()
 
-	if err := cli.RunWithConfig(serverURL, apiToken); err != nil {
+	if err := cli.RunWithConfig(serverURL, apiToken, tlsCfg); err != nil {
 		return &cliError{op: "running TUI", err: err}
 	}
 
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+	"github.com/theotruvelot/g0s/pkg/proto/alert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	silenceHostFilter     string
+	silenceTags           map[string]string
+	silenceRule           string
+	silenceStart          string
+	silenceDuration       time.Duration
+	silenceComment        string
+	silenceIncludeExpired bool
+	windowTimezone        string
+	windowDuration        time.Duration
+	windowHostFilter      string
+	windowTags            map[string]string
+	windowComment         string
+)
+
+func newSilencesCmd() *cobra.Command {
+	silencesCmd := &cobra.Command{
+		Use:   "silences",
+		Short: "Suppress the notifications of alerts for a while (operators and admins, listing is open to every user)",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: `Silence the alerts matching every given selector, e.g. create --host 'web-.*' --duration 2h --comment "kernel upgrade"`,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			startsAt := time.Now()
+			if silenceStart != "" {
+				var err error
+				if startsAt, err = time.Parse(time.RFC3339, silenceStart); err != nil {
+					return fmt.Errorf("invalid start %q, expected RFC 3339, e.g. 2024-06-02T22:00:00+02:00", silenceStart)
+				}
+			}
+			if silenceDuration <= 0 {
+				return errors.New("--duration must be positive")
+			}
+
+			return runCommand("creating silence", func(ctx context.Context, grpcClients *clients.Clients) error {
+				silence, err := services.NewAlertService(grpcClients).CreateSilence(ctx, &alert.Silence{
+					HostFilter: silenceHostFilter,
+					Tags:       silenceTags,
+					Rule:       silenceRule,
+					StartsAt:   timestamppb.New(startsAt),
+					EndsAt:     timestamppb.New(startsAt.Add(silenceDuration)),
+					Comment:    silenceComment,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Silence %s created, until %s\n", silence.GetId(), formatTimestamp(silence.GetEndsAt()))
+				return nil
+			})
+		},
+	}
+	createCmd.Flags().StringVar(&silenceHostFilter, "host", "", "Silence the alerts of the hosts matching this regex")
+	createCmd.Flags().StringToStringVar(&silenceTags, "tag", nil, "Silence the alerts of the hosts having this tag, repeatable, e.g. --tag env=prod")
+	createCmd.Flags().StringVar(&silenceRule, "rule", "", "Silence the alerts of this rule")
+	createCmd.Flags().StringVar(&silenceStart, "start", "", "Start of the silence, RFC 3339 (default now)")
+	createCmd.Flags().DurationVar(&silenceDuration, "duration", time.Hour, "Duration of the silence")
+	createCmd.Flags().StringVar(&silenceComment, "comment", "", "Why the alerts are silenced")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the silences not ended yet, the latest ending first",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCommand("listing silences", func(ctx context.Context, grpcClients *clients.Clients) error {
+				silences, err := services.NewAlertService(grpcClients).ListSilences(ctx, silenceIncludeExpired)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "ID\tHOSTS\tTAGS\tRULE\tSTARTS\tENDS\tCREATED BY\tCOMMENT")
+				for _, s := range silences {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						s.GetId(),
+						formatOptional(s.GetHostFilter()),
+						formatTags(s.GetTags()),
+						formatOptional(s.GetRule()),
+						formatTimestamp(s.GetStartsAt()),
+						formatTimestamp(s.GetEndsAt()),
+						s.GetCreatedBy(),
+						formatOptional(s.GetComment()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+	listCmd.Flags().BoolVar(&silenceIncludeExpired, "all", false, "Also list the silences that ended")
+
+	expireCmd := &cobra.Command{
+		Use:   "expire <id>",
+		Short: "End a silence now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("expiring silence", func(ctx context.Context, grpcClients *clients.Clients) error {
+				if _, err := services.NewAlertService(grpcClients).ExpireSilence(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Silence %s expired\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	silencesCmd.AddCommand(createCmd, listCmd, expireCmd)
+	return silencesCmd
+}
+
+func newMaintenanceCmd() *cobra.Command {
+	maintenanceCmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Manage the recurring maintenance windows of the hosts (admin only, listing is open to every user)",
+	}
+
+	putCmd := &cobra.Command{
+		Use:   "put <name> <schedule>",
+		Short: `Create or replace a maintenance window opening on a cron schedule, e.g. put sunday-patching '0 22 * * 0' --duration 4h --tag env=prod`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("saving maintenance window", func(ctx context.Context, grpcClients *clients.Clients) error {
+				window, err := services.NewAlertService(grpcClients).PutMaintenanceWindow(ctx, &alert.MaintenanceWindow{
+					Name:       args[0],
+					Schedule:   args[1],
+					Timezone:   windowTimezone,
+					Duration:   durationpb.New(windowDuration),
+					HostFilter: windowHostFilter,
+					Tags:       windowTags,
+					Comment:    windowComment,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Maintenance window %s saved\n", window.GetName())
+				return nil
+			})
+		},
+	}
+	putCmd.Flags().StringVar(&windowTimezone, "timezone", "", "IANA time zone of the schedule, e.g. Europe/Paris (default UTC)")
+	putCmd.Flags().DurationVar(&windowDuration, "duration", time.Hour, "How long the window stays open, at most 168h")
+	putCmd.Flags().StringVar(&windowHostFilter, "host", "", "Hosts matching this regex are in maintenance")
+	putCmd.Flags().StringToStringVar(&windowTags, "tag", nil, "Hosts having this tag are in maintenance, repeatable, e.g. --tag env=prod")
+	putCmd.Flags().StringVar(&windowComment, "comment", "", "Why the hosts are in maintenance")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the maintenance windows",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCommand("listing maintenance windows", func(ctx context.Context, grpcClients *clients.Clients) error {
+				windows, err := services.NewAlertService(grpcClients).ListMaintenanceWindows(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "NAME\tSCHEDULE\tTIMEZONE\tDURATION\tHOSTS\tTAGS\tOPEN\tCOMMENT")
+				for _, mw := range windows {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+						mw.GetName(),
+						mw.GetSchedule(),
+						formatOptional(mw.GetTimezone()),
+						mw.GetDuration().AsDuration(),
+						formatOptional(mw.GetHostFilter()),
+						formatTags(mw.GetTags()),
+						mw.GetActive(),
+						formatOptional(mw.GetComment()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a maintenance window, ending the maintenance of its hosts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCommand("deleting maintenance window", func(ctx context.Context, grpcClients *clients.Clients) error {
+				if err := services.NewAlertService(grpcClients).DeleteMaintenanceWindow(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Maintenance window %s deleted\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	maintenanceCmd.AddCommand(putCmd, listCmd, deleteCmd)
+	return maintenanceCmd
+}
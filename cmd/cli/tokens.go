@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+	"github.com/theotruvelot/g0s/pkg/proto/admin"
+)
+
+var (
+	tokenUsername string
+	tokenName     string
+	tokenScopes   []string
+	tokenTTL      time.Duration
+)
+
+func newTokensCmd() *cobra.Command {
+	tokensCmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "Manage the API tokens of users (admin only)",
+	}
+	tokensCmd.PersistentFlags().StringVar(&tokenUsername, "user", "", "Username owning the tokens")
+	_ = tokensCmd.MarkPersistentFlagRequired("user")
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token, printed once",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAdmin("issuing token", func(ctx context.Context, adminService *services.AdminService) error {
+				res, err := adminService.IssueToken(ctx, tokenUsername, tokenName, tokenScopes, tokenTTL)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Token %s issued for %s, it won't be shown again:\n\n%s\n",
+					res.GetToken().GetId(), tokenUsername, res.GetValue())
+				return nil
+			})
+		},
+	}
+	createCmd.Flags().StringVar(&tokenName, "name", "", "Name of the token, e.g. the machine it is used on")
+	createCmd.Flags().StringSliceVar(&tokenScopes, "scope", nil, "Scope granted to the token, repeatable (default every scope)")
+	createCmd.Flags().DurationVar(&tokenTTL, "ttl", 0, "Lifetime of the token, e.g. 720h (default never expires)")
+	_ = createCmd.MarkFlagRequired("name")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the API tokens of a user",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAdmin("listing tokens", func(ctx context.Context, adminService *services.AdminService) error {
+				tokens, err := adminService.ListTokens(ctx, tokenUsername)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "ID\tNAME\tSCOPES\tSTATUS\tEXPIRES\tLAST USED\tCREATED")
+				for _, token := range tokens {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						token.GetId(),
+						token.GetName(),
+						strings.Join(token.GetScopes(), ","),
+						tokenStatus(token),
+						formatTimestamp(token.GetExpiresAt()),
+						formatTimestamp(token.GetLastUsedAt()),
+						formatTimestamp(token.GetCreatedAt()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <token-id>",
+		Short: "Revoke an API token and end the sessions opened with it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("revoking token", func(ctx context.Context, adminService *services.AdminService) error {
+				if _, err := adminService.RevokeToken(ctx, tokenUsername, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Token %s revoked\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	tokensCmd.AddCommand(createCmd, listCmd, revokeCmd)
+	return tokensCmd
+}
+
+func tokenStatus(token *admin.ApiToken) string {
+	switch {
+	case token.GetRevoked():
+		return "revoked"
+	case token.GetExpiresAt() != nil && !token.GetExpiresAt().AsTime().After(time.Now()):
+		return "expired"
+	default:
+		return "active"
+	}
+}
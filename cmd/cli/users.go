@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/cli/services"
+)
+
+var (
+	userRole       string
+	userHostGroups []string
+)
+
+func newUsersCmd() *cobra.Command {
+	usersCmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage users (admin only)",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create <username>",
+		Short: "Create a user, see tokens create to give it a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("creating user", func(ctx context.Context, adminService *services.AdminService) error {
+				user, err := adminService.CreateUser(ctx, args[0], userRole, userHostGroups)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("User %s created with id %s\n", user.GetUsername(), user.GetId())
+				return nil
+			})
+		},
+	}
+	createCmd.Flags().StringVar(&userRole, "role", "viewer", "Role of the user: viewer, operator or admin")
+	createCmd.Flags().StringSliceVar(&userHostGroups, "host-group", nil, "Host group the user is limited to, repeatable (default every host)")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runAdmin("listing users", func(ctx context.Context, adminService *services.AdminService) error {
+				users, err := adminService.ListUsers(ctx)
+				if err != nil {
+					return err
+				}
+
+				w := newTableWriter()
+				fmt.Fprintln(w, "ID\tUSERNAME\tROLE\tHOST GROUPS\tDISABLED\tCREATED")
+				for _, user := range users {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\n",
+						user.GetId(),
+						user.GetUsername(),
+						user.GetRole(),
+						formatHostGroups(user.GetHostGroups()),
+						user.GetDisabled(),
+						formatTimestamp(user.GetCreatedAt()))
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <username>",
+		Short: "Prevent a user from logging in and end its sessions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("disabling user", func(ctx context.Context, adminService *services.AdminService) error {
+				if _, err := adminService.DisableUser(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("User %s disabled\n", args[0])
+				return nil
+			})
+		},
+	}
+
+	setRoleCmd := &cobra.Command{
+		Use:   "set-role <username> <role>",
+		Short: "Change the role of a user: viewer, operator or admin",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("changing user role", func(ctx context.Context, adminService *services.AdminService) error {
+				user, err := adminService.SetUserRole(ctx, args[0], args[1])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("User %s is now %s\n", user.GetUsername(), user.GetRole())
+				return nil
+			})
+		},
+	}
+
+	setHostGroupsCmd := &cobra.Command{
+		Use:   "set-host-groups <username> [host-group...]",
+		Short: "Limit a user to host groups, or give it access to every host without groups",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runAdmin("changing user host groups", func(ctx context.Context, adminService *services.AdminService) error {
+				user, err := adminService.SetUserHostGroups(ctx, args[0], args[1:])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("User %s now sees the hosts of %s\n", user.GetUsername(), formatHostGroups(user.GetHostGroups()))
+				return nil
+			})
+		},
+	}
+
+	usersCmd.AddCommand(createCmd, listCmd, disableCmd, setRoleCmd, setHostGroupsCmd)
+	return usersCmd
+}
+
+// formatHostGroups lists the host groups of a user, "*" when the user sees every host
+func formatHostGroups(hostGroups []string) string {
+	if len(hostGroups) == 0 {
+		return "*"
+	}
+	return strings.Join(hostGroups, ",")
+}
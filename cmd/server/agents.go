@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+)
+
+var joinTokenTTL time.Duration
+
+// newAgentsCmd manages agent enrollment directly in the database, admins can also use g0s-cli agents
+func newAgentsCmd() *cobra.Command {
+	agentsCmd := &cobra.Command{
+		Use:   "agents",
+		Short: "Manage agent enrollment",
+	}
+
+	joinTokenCmd := &cobra.Command{
+		Use:   "join-token",
+		Short: "Create a single-use join token to enroll an agent, printed once",
+		Args:  cobra.NoArgs,
+		RunE:  runAgentsJoinToken,
+	}
+	joinTokenCmd.Flags().DurationVar(&joinTokenTTL, "ttl", service.DefaultJoinTokenTTL, "Lifetime of the token, at most 168h")
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <hostname>",
+		Short: "Revoke the credential of an agent, it has to enroll again",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runAgentsRevoke,
+	}
+
+	agentsCmd.AddCommand(joinTokenCmd, revokeCmd)
+	return agentsCmd
+}
+
+// newEnrollmentService connects to the database and returns the enrollment service working on it.
+// It has no JWT service: agents enroll through the running server, not these commands.
+func newEnrollmentService() (*service.EnrollmentService, error) {
+	db, err := initCommandDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewEnrollmentService(
+		database.NewJoinTokenRepository(db),
+		database.NewHostRepository(db),
+		nil,
+	), nil
+}
+
+func runAgentsJoinToken(_ *cobra.Command, _ []string) error {
+	enrollmentService, err := newEnrollmentService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	plaintext, token, err := enrollmentService.CreateJoinToken("g0s-server", joinTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Join token %s created, it expires at %s and won't be shown again:\n\n%s\n",
+		token.ID, token.ExpiresAt.Format(time.RFC3339), plaintext)
+	return nil
+}
+
+func runAgentsRevoke(_ *cobra.Command, args []string) error {
+	enrollmentService, err := newEnrollmentService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if _, err := enrollmentService.RevokeHost(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Agent %s revoked\n", args[0])
+	return nil
+}
@@ -12,16 +12,22 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/theotruvelot/g0s/internal/server"
+	"github.com/theotruvelot/g0s/internal/server/service"
 	"github.com/theotruvelot/g0s/pkg/logger"
+	"github.com/theotruvelot/g0s/pkg/utils"
 	"go.uber.org/zap"
 )
 
 const (
 	_defaultHTTPAddr         = ":8080"
 	_defaultGRPCAddr         = ":9090"
+	_defaultMetricsAddr      = ":9464"
 	_defaultLogLevel         = "info"
 	_defaultLogFormat        = "json"
 	_defaultVMEndpoint       = "http://localhost:8428"
+	_defaultWALDir           = "/var/lib/g0s/wal"
+	_defaultWALMaxSizeMB     = 1024
+	_defaultWALMaxAge        = 24 * time.Hour
 	_defaultDSN              = "postgresql://root@127.0.0.1:26257/defaultdb?sslmode=disable"
 	_defaultJWTSecret        = "mongigasecret"
 	_defaultJWTRefreshSecret = "mongigasecretrefresh"
@@ -31,12 +37,23 @@ const (
 var (
 	httpAddr         string
 	grpcAddr         string
+	metricsAddr      string
 	logLevel         string
 	logFormat        string
 	vmEndpoint       string
+	walDir           string
+	walMaxSizeMB     int64
+	walMaxAge        time.Duration
 	dsn              string
 	jwtSecret        string
 	jwtRefreshSecret string
+	tlsCertFile      string
+	tlsKeyFile       string
+	tlsClientCAFile  string
+	allowUnenrolled  bool
+	hostOfflineAfter int
+	alertRulesFile   string
+	notifications    string
 )
 
 type serverError struct {
@@ -59,14 +76,27 @@ func main() {
 		RunE:  runServer,
 	}
 
-	rootCmd.Flags().StringVar(&httpAddr, "http-addr", _defaultHTTPAddr, "HTTP server address")
+	rootCmd.Flags().StringVar(&httpAddr, "http-addr", _defaultHTTPAddr, "Address of the JSON gateway to the gRPC API, empty to disable it")
 	rootCmd.Flags().StringVar(&grpcAddr, "grpc-addr", _defaultGRPCAddr, "gRPC server address")
-	rootCmd.Flags().StringVar(&logLevel, "log-level", _defaultLogLevel, "Log level: debug, info, warn, error")
-	rootCmd.Flags().StringVar(&logFormat, "log-format", _defaultLogFormat, "Log format: json or console")
+	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", _defaultMetricsAddr, "Address of the Prometheus /metrics endpoint of the server, empty to disable it")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", _defaultLogLevel, "Log level: debug, info, warn, error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", _defaultLogFormat, "Log format: json or console")
 	rootCmd.Flags().StringVar(&vmEndpoint, "vm-endpoint", _defaultVMEndpoint, "VictoriaMetrics endpoint")
-	rootCmd.Flags().StringVar(&dsn, "dsn", _defaultDSN, "Database DSN")
+	rootCmd.Flags().StringVar(&walDir, "wal-dir", _defaultWALDir, "Directory metrics are buffered in until VictoriaMetrics stores them, empty to write them right away")
+	rootCmd.Flags().Int64Var(&walMaxSizeMB, "wal-max-size", _defaultWALMaxSizeMB, "Size in MB of the metrics buffer, the oldest metrics are dropped beyond it")
+	rootCmd.Flags().DurationVar(&walMaxAge, "wal-max-age", _defaultWALMaxAge, "Age of the oldest metrics kept in the buffer")
+	rootCmd.PersistentFlags().StringVar(&dsn, "dsn", _defaultDSN, "Database DSN")
 	rootCmd.Flags().StringVar(&jwtSecret, "jwt-secret", _defaultJWTSecret, "JWT secret for signing tokens")
 	rootCmd.Flags().StringVar(&jwtRefreshSecret, "jwt-refresh-secret", _defaultJWTRefreshSecret, "JWT secret for signing refresh tokens")
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls-cert", "", "TLS certificate file (PEM) of the gRPC server")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls-key", "", "TLS private key file (PEM) of the gRPC server")
+	rootCmd.Flags().StringVar(&tlsClientCAFile, "tls-client-ca", "", "CA file (PEM) used to verify agent client certificates")
+	rootCmd.Flags().BoolVar(&allowUnenrolled, "allow-unenrolled-agents", false, "Accept agents without credential or client certificate (development only)")
+	rootCmd.Flags().StringVar(&alertRulesFile, "alert-rules", "", "YAML file of alert rules, evaluated along the rules managed with g0s-cli")
+	rootCmd.Flags().StringVar(&notifications, "notifications", "", "YAML file of the webhook, Slack and SMTP channels alerts are sent to")
+	rootCmd.Flags().IntVar(&hostOfflineAfter, "host-offline-after", service.DefaultOfflineAfter, "Collection intervals without health watch nor metrics after which a host is offline (at least 2)")
+
+	rootCmd.AddCommand(newUsersCmd(), newTokensCmd(), newAgentsCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -74,7 +104,7 @@ func main() {
 	}
 }
 
-func runServer(_ *cobra.Command, _ []string) error {
+func runServer(cmd *cobra.Command, _ []string) error {
 	logger.InitLogger(logger.Config{
 		Level:     logLevel,
 		Format:    logFormat,
@@ -82,16 +112,44 @@ func runServer(_ *cobra.Command, _ []string) error {
 	})
 	defer logger.Sync()
 
+	if hostOfflineAfter < 2 {
+		return &serverError{op: "configure", err: fmt.Errorf("--host-offline-after must be at least 2, got %d", hostOfflineAfter)}
+	}
+
+	// The default buffer directory is only writable by root, other users still get a server
+	// writing the metrics right away. A --wal-dir given explicitly must be usable.
+	if !cmd.Flags().Changed("wal-dir") {
+		if err := utils.CheckWritableDir(walDir); err != nil {
+			logger.Warn("Metrics buffer disabled, set --wal-dir to a writable directory to keep metrics while VictoriaMetrics is down",
+				zap.String("wal_dir", walDir),
+				zap.Error(err))
+			walDir = ""
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	cfg := server.Config{
 		GRPCAddr:         grpcAddr,
+		HTTPAddr:         httpAddr,
+		MetricsAddr:      metricsAddr,
 		LogLevel:         logLevel,
 		LogFormat:        logFormat,
 		VMEndpoint:       vmEndpoint,
+		WALDir:           walDir,
+		WALMaxBytes:      walMaxSizeMB << 20,
+		WALMaxAge:        walMaxAge,
 		JWTSecret:        jwtSecret,
 		JWTRefreshSecret: jwtRefreshSecret,
+		TLSCertFile:      tlsCertFile,
+		TLSKeyFile:       tlsKeyFile,
+		TLSClientCAFile:  tlsClientCAFile,
+
+		AllowUnenrolledAgents: allowUnenrolled,
+		HostOfflineAfter:      hostOfflineAfter,
+		AlertRulesFile:        alertRulesFile,
+		NotificationsFile:     notifications,
 	}
 
 	// Initialize database connection
@@ -119,6 +177,8 @@ func runServerWithConfig(ctx context.Context, cfg server.Config) error {
 
 	logger.Info("Starting g0s-server",
 		zap.String("grpc_addr", cfg.GRPCAddr),
+		zap.String("http_addr", cfg.HTTPAddr),
+		zap.String("metrics_addr", cfg.MetricsAddr),
 		zap.String("log_level", cfg.LogLevel),
 		zap.String("log_format", cfg.LogFormat))
 
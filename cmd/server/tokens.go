@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var (
+	tokenUsername string
+	tokenName     string
+	tokenScopes   []string
+	tokenTTL      time.Duration
+)
+
+func newTokensCmd() *cobra.Command {
+	tokensCmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "Manage the API tokens users log in with",
+	}
+	tokensCmd.PersistentFlags().StringVar(&tokenUsername, "user", "", "Username owning the tokens")
+	_ = tokensCmd.MarkPersistentFlagRequired("user")
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token, printed once",
+		Args:  cobra.NoArgs,
+		RunE:  runTokensCreate,
+	}
+	createCmd.Flags().StringVar(&tokenName, "name", "", "Name of the token, e.g. the machine it is used on")
+	createCmd.Flags().StringSliceVar(&tokenScopes, "scope", nil, "Scope granted to the token, repeatable (default every scope)")
+	createCmd.Flags().DurationVar(&tokenTTL, "ttl", 0, "Lifetime of the token, e.g. 720h (default never expires)")
+	_ = createCmd.MarkFlagRequired("name")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the API tokens of a user",
+		Args:  cobra.NoArgs,
+		RunE:  runTokensList,
+	}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <token-id>",
+		Short: "Revoke an API token and end the sessions opened with it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTokensRevoke,
+	}
+
+	tokensCmd.AddCommand(createCmd, listCmd, revokeCmd)
+	return tokensCmd
+}
+
+// newTokenService connects to the database and returns the token service working on it
+func newTokenService() (*service.TokenService, error) {
+	db, err := initCommandDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewTokenService(
+		database.NewUserRepository(db),
+		database.NewAPITokenRepository(db),
+		database.NewRefreshTokenRepository(db),
+	), nil
+}
+
+// initCommandDatabase connects to the database for the management commands.
+// Logs go to stderr so stdout only carries the result of the command.
+func initCommandDatabase() (*gorm.DB, error) {
+	logger.InitLogger(logger.Config{
+		Level:      logLevel,
+		Format:     logFormat,
+		OutputPath: "stderr",
+		Component:  "server",
+	})
+
+	db, err := database.Init(dsn)
+	if err != nil {
+		return nil, &serverError{op: "init database", err: err}
+	}
+	return db, nil
+}
+
+func runTokensCreate(_ *cobra.Command, _ []string) error {
+	tokenService, err := newTokenService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	plaintext, token, err := tokenService.CreateToken(tokenUsername, tokenName, tokenScopes, tokenTTL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Token %s created for %s, it won't be shown again:\n\n%s\n", token.ID, tokenUsername, plaintext)
+	return nil
+}
+
+func runTokensList(_ *cobra.Command, _ []string) error {
+	tokenService, err := newTokenService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	tokens, err := tokenService.ListTokens(tokenUsername)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSCOPES\tSTATUS\tEXPIRES\tLAST USED\tCREATED")
+	now := time.Now()
+	for _, token := range tokens {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			token.ID,
+			token.Name,
+			strings.Join(token.Scopes, ","),
+			tokenStatus(&token, now),
+			formatOptionalTime(token.ExpiresAt),
+			formatOptionalTime(token.LastUsedAt),
+			token.CreatedAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+func runTokensRevoke(_ *cobra.Command, args []string) error {
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid token id %q: %w", args[0], err)
+	}
+
+	tokenService, err := newTokenService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if _, err := tokenService.RevokeToken(tokenUsername, id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Token %s revoked\n", id)
+	return nil
+}
+
+func tokenStatus(token *models.APIToken, now time.Time) string {
+	switch {
+	case token.Revoked:
+		return "revoked"
+	case token.Expired(now):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
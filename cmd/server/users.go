@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+)
+
+var (
+	userRole       string
+	userHostGroups []string
+)
+
+// newUsersCmd manages users directly in the database. It is how the first admin is created,
+// admins then manage users through the AdminService, e.g. with g0s-cli users.
+func newUsersCmd() *cobra.Command {
+	usersCmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage the users allowed to log in",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create <username>",
+		Short: "Create a user, see tokens create to give it a token",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUsersCreate,
+	}
+	createCmd.Flags().StringVar(&userRole, "role", string(auth.RoleViewer), "Role of the user: viewer, operator or admin")
+	createCmd.Flags().StringSliceVar(&userHostGroups, "host-group", nil, "Host group the user is limited to, repeatable (default every host)")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users",
+		Args:  cobra.NoArgs,
+		RunE:  runUsersList,
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <username>",
+		Short: "Prevent a user from logging in and end its sessions",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUsersDisable,
+	}
+
+	usersCmd.AddCommand(createCmd, listCmd, disableCmd)
+	return usersCmd
+}
+
+// newUserService connects to the database and returns the user service working on it
+func newUserService() (*service.UserService, error) {
+	db, err := initCommandDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewUserService(
+		database.NewUserRepository(db),
+		database.NewHostGroupRepository(db),
+		database.NewRefreshTokenRepository(db),
+	), nil
+}
+
+func runUsersCreate(_ *cobra.Command, args []string) error {
+	userService, err := newUserService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	user, err := userService.CreateUser(args[0], auth.Role(userRole), userHostGroups)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s created with id %s\n", user.Username, user.ID)
+	return nil
+}
+
+func runUsersList(_ *cobra.Command, _ []string) error {
+	userService, err := newUserService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	users, err := userService.ListUsers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSERNAME\tROLE\tHOST GROUPS\tDISABLED\tCREATED")
+	for _, user := range users {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\n",
+			user.ID,
+			user.Username,
+			user.Role,
+			formatHostGroups(user.HostGroups),
+			user.Disabled,
+			user.CreatedAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+func runUsersDisable(_ *cobra.Command, args []string) error {
+	userService, err := newUserService()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if _, err := userService.DisableUser(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s disabled\n", args[0])
+	return nil
+}
+
+func formatHostGroups(hostGroups []string) string {
+	if len(hostGroups) == 0 {
+		return "*"
+	}
+	return strings.Join(hostGroups, ",")
+}
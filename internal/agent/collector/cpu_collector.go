@@ -158,22 +158,24 @@ func (c *CPUCollector) buildCPUMetrics(
 ) []model.CPUMetrics {
 	metrics := make([]model.CPUMetrics, 0, len(cpuInfo)+1)
 
-	var defaultFrequencyMHz float64
-	if len(cpuInfo) > 0 {
-		defaultFrequencyMHz = float64(cpuInfo[0].Mhz)
-	}
-
 	totalMetric := model.CPUMetrics{
-		Model:        cpuInfo[0].ModelName,
 		Cores:        physicalCount,
 		Threads:      logicalCount,
-		FrequencyMHz: defaultFrequencyMHz,
 		UsagePercent: totalUsagePercent,
-		UserTime:     cpuTimes[0].User,
-		SystemTime:   cpuTimes[0].System,
-		IdleTime:     cpuTimes[0].Idle,
 		IsTotal:      true,
 	}
+	var defaultFrequencyMHz float64
+	if len(cpuInfo) > 0 {
+		defaultFrequencyMHz = float64(cpuInfo[0].Mhz)
+		totalMetric.Model = cpuInfo[0].ModelName
+		totalMetric.FrequencyMHz = defaultFrequencyMHz
+	}
+	// The per-core times are missing when they could not be read
+	if len(cpuTimes) > 0 {
+		totalMetric.UserTime = cpuTimes[0].User
+		totalMetric.SystemTime = cpuTimes[0].System
+		totalMetric.IdleTime = cpuTimes[0].Idle
+	}
 	metrics = append(metrics, totalMetric)
 
 	for i := 0; i < physicalCount && i < len(cpuTimes); i++ {
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/shirou/gopsutil/v4/cpu"
@@ -48,6 +49,7 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 		name          string
 		cpuInfo       []cpu.InfoStat
 		percentages   []float64
+		totalPercent  float64
 		cpuTimes      []cpu.TimesStat
 		physicalCount int
 		logicalCount  int
@@ -61,7 +63,8 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 					Mhz:       3000,
 				},
 			},
-			percentages: []float64{75.5},
+			percentages:  []float64{75.5},
+			totalPercent: 75.5,
 			cpuTimes: []cpu.TimesStat{
 				{
 					User:   100.0,
@@ -71,7 +74,7 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 			},
 			physicalCount: 4,
 			logicalCount:  8,
-			expectedLen:   1,
+			expectedLen:   2,
 		},
 		{
 			name: "Multiple CPUs",
@@ -85,7 +88,8 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 					Mhz:       2800,
 				},
 			},
-			percentages: []float64{60.0, 70.0},
+			percentages:  []float64{60.0, 70.0},
+			totalPercent: 65.0,
 			cpuTimes: []cpu.TimesStat{
 				{
 					User:   150.0,
@@ -100,7 +104,7 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 			},
 			physicalCount: 8,
 			logicalCount:  16,
-			expectedLen:   2,
+			expectedLen:   3,
 		},
 		{
 			name: "Missing Percentages and Times",
@@ -120,25 +124,33 @@ func TestCPUCollector_buildCPUMetrics(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			metrics := collector.buildCPUMetrics(tc.cpuInfo, tc.percentages, tc.cpuTimes, tc.physicalCount, tc.logicalCount)
-
-			assert.Equal(t, tc.expectedLen, len(metrics))
-
-			for i, metric := range metrics {
-				assert.Equal(t, tc.cpuInfo[i].ModelName, metric.Model)
-				assert.Equal(t, tc.physicalCount, metric.Cores)
-				assert.Equal(t, tc.logicalCount, metric.Threads)
-				assert.Equal(t, float64(tc.cpuInfo[i].Mhz), metric.FrequencyMHz)
-
-				if i < len(tc.percentages) {
-					assert.Equal(t, tc.percentages[i], metric.UsagePercent)
-				}
+			metrics := collector.buildCPUMetrics(tc.cpuInfo, tc.percentages, tc.totalPercent, tc.cpuTimes, tc.physicalCount, tc.logicalCount)
+
+			require.Equal(t, tc.expectedLen, len(metrics))
+
+			// The first metric is the total of the CPU
+			total := metrics[0]
+			assert.True(t, total.IsTotal)
+			assert.Equal(t, tc.cpuInfo[0].ModelName, total.Model)
+			assert.Equal(t, tc.physicalCount, total.Cores)
+			assert.Equal(t, tc.logicalCount, total.Threads)
+			assert.Equal(t, float64(tc.cpuInfo[0].Mhz), total.FrequencyMHz)
+			assert.Equal(t, tc.totalPercent, total.UsagePercent)
+			if len(tc.cpuTimes) > 0 {
+				assert.Equal(t, tc.cpuTimes[0].User, total.UserTime)
+			}
 
-				if i < len(tc.cpuTimes) {
-					assert.Equal(t, tc.cpuTimes[i].User, metric.UserTime)
-					assert.Equal(t, tc.cpuTimes[i].System, metric.SystemTime)
-					assert.Equal(t, tc.cpuTimes[i].Idle, metric.IdleTime)
-				}
+			for i, metric := range metrics[1:] {
+				assert.False(t, metric.IsTotal)
+				assert.Equal(t, fmt.Sprintf("CPU %d", i+1), metric.Model)
+				assert.Equal(t, i+1, metric.CoreID)
+				assert.Equal(t, 1, metric.Cores)
+				assert.Equal(t, 1, metric.Threads)
+				assert.Equal(t, float64(tc.cpuInfo[0].Mhz), metric.FrequencyMHz)
+				assert.Equal(t, tc.percentages[i], metric.UsagePercent)
+				assert.Equal(t, tc.cpuTimes[i].User, metric.UserTime)
+				assert.Equal(t, tc.cpuTimes[i].System, metric.SystemTime)
+				assert.Equal(t, tc.cpuTimes[i].Idle, metric.IdleTime)
 			}
 		})
 	}
@@ -148,34 +160,38 @@ func TestCPUCollector_buildCPUMetrics_SafeIndexing(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	collector := NewCPUCollector(logger)
 
-	// Test case where we have more CPUs than percentages/times
+	// Test case where we have more cores than percentages, and fewer times than cores
 	cpuInfo := []cpu.InfoStat{
 		{ModelName: "CPU1", Mhz: 2000.0},
-		{ModelName: "CPU2", Mhz: 2500.0},
-		{ModelName: "CPU3", Mhz: 3000.0},
 	}
 	percentages := []float64{15.0} // Only one percentage
 	cpuTimes := []cpu.TimesStat{
 		{User: 80.0, System: 40.0, Idle: 280.0},
-	} // Only one time stat
+		{User: 90.0, System: 45.0, Idle: 270.0},
+		{User: 10.0, System: 5.0, Idle: 390.0},
+	}
 
-	metrics := collector.buildCPUMetrics(cpuInfo, percentages, cpuTimes, 4, 8)
+	metrics := collector.buildCPUMetrics(cpuInfo, percentages, 20.0, cpuTimes, 4, 8)
 
-	require.Len(t, metrics, 3)
+	require.Len(t, metrics, 4, "one metric per core with times, and the total")
 
-	// First CPU should have all data
+	assert.True(t, metrics[0].IsTotal)
 	assert.Equal(t, "CPU1", metrics[0].Model)
-	assert.Equal(t, 15.0, metrics[0].UsagePercent)
-	assert.Equal(t, 80.0, metrics[0].UserTime)
+	assert.Equal(t, 20.0, metrics[0].UsagePercent)
 
-	// Second and third CPUs should have zero values for missing data
-	assert.Equal(t, "CPU2", metrics[1].Model)
-	assert.Equal(t, float64(0), metrics[1].UsagePercent)
-	assert.Equal(t, float64(0), metrics[1].UserTime)
+	// First core should have all data
+	assert.Equal(t, "CPU 1", metrics[1].Model)
+	assert.Equal(t, 15.0, metrics[1].UsagePercent)
+	assert.Equal(t, 80.0, metrics[1].UserTime)
 
-	assert.Equal(t, "CPU3", metrics[2].Model)
+	// Second and third cores should have zero usage for the missing percentages
+	assert.Equal(t, "CPU 2", metrics[2].Model)
 	assert.Equal(t, float64(0), metrics[2].UsagePercent)
-	assert.Equal(t, float64(0), metrics[2].UserTime)
+	assert.Equal(t, 90.0, metrics[2].UserTime)
+
+	assert.Equal(t, "CPU 3", metrics[3].Model)
+	assert.Equal(t, float64(0), metrics[3].UsagePercent)
+	assert.Equal(t, 10.0, metrics[3].UserTime)
 }
 
 // Integration test - will only work on systems where gopsutil can collect data
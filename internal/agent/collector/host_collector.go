@@ -29,6 +29,7 @@ type staticHostInfo struct {
 	virtualizationSystem string
 	virtualizationRole   string
 	kernelVersion        string
+	kernelArch           string
 }
 
 // NewHostCollector creates a new HostCollector instance.
@@ -74,6 +75,7 @@ func (c *HostCollector) getCachedStaticData() (*staticHostInfo, error) {
 		virtualizationSystem: hostInfo.VirtualizationSystem,
 		virtualizationRole:   hostInfo.VirtualizationRole,
 		kernelVersion:        hostInfo.KernelVersion,
+		kernelArch:           hostInfo.KernelArch,
 	}
 	c.cacheExpiry = time.Now().Add(c.cacheDuration)
 
@@ -111,5 +113,6 @@ func (c *HostCollector) buildHostMetrics(staticInfo *staticHostInfo, hostInfo *h
 		VirtualizationSystem: staticInfo.virtualizationSystem,
 		VirtualizationRole:   staticInfo.virtualizationRole,
 		KernelVersion:        staticInfo.kernelVersion,
+		KernelArch:           staticInfo.kernelArch,
 	}
 }
@@ -35,7 +35,18 @@ func TestHostCollector_buildHostMetrics(t *testing.T) {
 		KernelVersion:        "5.4.0",
 	}
 
-	result := collector.buildHostMetrics(hostInfo)
+	staticInfo := &staticHostInfo{
+		hostname:             "test-host",
+		os:                   "linux",
+		platform:             "ubuntu",
+		platformFamily:       "debian",
+		platformVersion:      "20.04",
+		virtualizationSystem: "docker",
+		virtualizationRole:   "guest",
+		kernelVersion:        "5.4.0",
+	}
+
+	result := collector.buildHostMetrics(staticInfo, hostInfo)
 
 	assert.Equal(t, "test-host", result.Hostname)
 	assert.Equal(t, uint64(3600), result.Uptime)
@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/theotruvelot/g0s/internal/agent/model"
+	"go.uber.org/zap"
+)
+
+const _procRoot = "/proc"
+
+// _pressureResources are the resources of /proc/pressure, in the order they are reported
+var _pressureResources = []string{"cpu", "memory", "io"}
+
+// LoadCollector reports the load average and, on Linux 4.20+ kernels, the pressure stall
+// information (PSI) of the CPU, memory and IO
+type LoadCollector struct {
+	log *zap.Logger
+	// procRoot is where procfs is mounted, replaced in tests
+	procRoot string
+	// pressureMissing is set once PSI was found unavailable, to log it only once
+	pressureMissing bool
+}
+
+func NewLoadCollector(log *zap.Logger) *LoadCollector {
+	return &LoadCollector{
+		log:      log,
+		procRoot: _procRoot,
+	}
+}
+
+func (c *LoadCollector) Collect() (model.SystemLoadMetrics, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		c.log.Error("Failed to collect load average", zap.Error(err))
+		return model.SystemLoadMetrics{}, err
+	}
+	metrics := model.SystemLoadMetrics{
+		Load1:  avg.Load1,
+		Load5:  avg.Load5,
+		Load15: avg.Load15,
+	}
+
+	for _, resource := range _pressureResources {
+		pressure, err := readPressure(filepath.Join(c.procRoot, "pressure", resource), resource)
+		if err != nil {
+			// Kernels without PSI, or booted with psi=0, have no such files or refuse to read them
+			if !c.pressureMissing {
+				c.log.Info("Pressure stall information is not available", zap.String("resource", resource), zap.Error(err))
+				c.pressureMissing = true
+			}
+			continue
+		}
+		metrics.Pressure = append(metrics.Pressure, pressure...)
+	}
+	return metrics, nil
+}
+
+// readPressure parses a /proc/pressure file, e.g.
+//
+//	some avg10=1.38 avg60=2.71 avg300=1.91 total=134957371
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func readPressure(path, resource string) ([]model.PressureMetrics, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var metrics []model.PressureMetrics
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		m := model.PressureMetrics{Resource: resource, Kind: fields[0]}
+		if m.Kind != "some" && m.Kind != "full" {
+			return nil, fmt.Errorf("unexpected line in %s: %q", path, scanner.Text())
+		}
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "avg10":
+				m.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				m.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				m.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				m.TotalMicroseconds, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in %s: %w", key, path, err)
+			}
+		}
+		metrics = append(metrics, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(metrics) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	return metrics, nil
+}
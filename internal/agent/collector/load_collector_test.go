@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/agent/model"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLoadCollector_Collect(t *testing.T) {
+	collector := NewLoadCollector(zaptest.NewLogger(t))
+	collector.procRoot = "testdata/proc"
+
+	metrics, err := collector.Collect()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, metrics.Load1, 0.0)
+	assert.Equal(t, []model.PressureMetrics{
+		{Resource: "cpu", Kind: "some", Avg10: 1.38, Avg60: 2.71, Avg300: 1.91, TotalMicroseconds: 134957371},
+		{Resource: "cpu", Kind: "full", TotalMicroseconds: 0},
+		{Resource: "memory", Kind: "some", Avg10: 12.5, Avg60: 8.02, Avg300: 3.1, TotalMicroseconds: 51234567},
+		{Resource: "memory", Kind: "full", Avg10: 4.25, Avg60: 2, Avg300: 0.75, TotalMicroseconds: 20456789},
+		{Resource: "io", Kind: "some", Avg10: 0.5, Avg60: 0.3, Avg300: 0.12, TotalMicroseconds: 9876543},
+		{Resource: "io", Kind: "full", Avg10: 0.1, Avg60: 0.05, Avg300: 0.02, TotalMicroseconds: 4567890},
+	}, metrics.Pressure)
+}
+
+func TestLoadCollector_PressureUnavailable(t *testing.T) {
+	collector := NewLoadCollector(zaptest.NewLogger(t))
+	collector.procRoot = "testdata/proc-legacy-cpu"
+
+	metrics, err := collector.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, []model.PressureMetrics{
+		{Resource: "cpu", Kind: "some", Avg10: 0.2, Avg60: 0.1, Avg300: 0.05, TotalMicroseconds: 1200},
+		{Resource: "memory", Kind: "some"},
+		{Resource: "memory", Kind: "full"},
+	}, metrics.Pressure, "older kernels have no full line for the CPU, the missing io file is skipped")
+	assert.True(t, collector.pressureMissing)
+
+	collector.procRoot = t.TempDir()
+	metrics, err = collector.Collect()
+	require.NoError(t, err, "the load average is reported without PSI")
+	assert.Empty(t, metrics.Pressure)
+}
+
+func TestReadPressure_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty":     "",
+		"bad-kind":  "partial avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+		"bad-avg":   "some avg10=high avg60=0.00 avg300=0.00 total=0\n",
+		"bad-total": "some avg10=0.00 avg60=0.00 avg300=0.00 total=-1\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := readPressure(path, "cpu")
+		assert.Error(t, err, name)
+	}
+}
@@ -1,19 +1,58 @@
 package collector
 
 import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/theotruvelot/g0s/internal/agent/model"
 	"go.uber.org/zap"
 )
 
+const _sysRoot = "/sys"
+
+// interfaceSample is the counters of an interface at a collection, to compute its rates at the next one
+type interfaceSample struct {
+	counters net.IOCountersStat
+	at       time.Time
+}
+
+// NetworkCollector reports the counters of the network interfaces, their rates since the
+// previous collection, and their addresses, MTU, state and link speed
 type NetworkCollector struct {
 	log *zap.Logger
+	// include and exclude are name globs of the interfaces reported, every one when include is empty
+	include     []string
+	exclude     []string
+	lastSamples map[string]interfaceSample
+	now         func() time.Time
+	// interfaces lists the interfaces of the host and sysRoot is where sysfs is mounted, replaced in tests
+	interfaces func() (net.InterfaceStatList, error)
+	sysRoot    string
 }
 
-func NewNetworkCollector(log *zap.Logger) *NetworkCollector {
-	return &NetworkCollector{
-		log: log,
+// NewNetworkCollector returns a collector reporting the interfaces matching a glob of include,
+// or every interface when it is empty, and none of exclude, e.g. "veth*"
+func NewNetworkCollector(log *zap.Logger, include, exclude []string) (*NetworkCollector, error) {
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return nil, fmt.Errorf("invalid interface name pattern %q", pattern)
+		}
 	}
+	return &NetworkCollector{
+		log:         log,
+		include:     include,
+		exclude:     exclude,
+		lastSamples: make(map[string]interfaceSample),
+		now:         time.Now,
+		interfaces:  net.Interfaces,
+		sysRoot:     _sysRoot,
+	}, nil
 }
 
 func (c *NetworkCollector) Collect() ([]model.NetworkMetrics, error) {
@@ -23,14 +62,35 @@ func (c *NetworkCollector) Collect() ([]model.NetworkMetrics, error) {
 		return nil, err
 	}
 
-	return c.buildNetworkMetrics(netStats), nil
+	metrics := c.buildNetworkMetrics(netStats)
+
+	// The counters are still reported when the interfaces can't be listed
+	interfaces, err := c.interfaces()
+	if err != nil {
+		c.log.Warn("Failed to list network interfaces", zap.Error(err))
+		return metrics, nil
+	}
+	for i := range metrics {
+		for _, iface := range interfaces {
+			if iface.Name == metrics[i].InterfaceName {
+				c.addInterfaceDetails(&metrics[i], iface)
+				break
+			}
+		}
+	}
+	return metrics, nil
 }
 
 func (c *NetworkCollector) buildNetworkMetrics(netStats []net.IOCountersStat) []model.NetworkMetrics {
+	now := c.now()
+	samples := make(map[string]interfaceSample, len(netStats))
 	metrics := make([]model.NetworkMetrics, 0, len(netStats))
 
 	for _, iface := range netStats {
-		metrics = append(metrics, model.NetworkMetrics{
+		if !c.selected(iface.Name) {
+			continue
+		}
+		m := model.NetworkMetrics{
 			InterfaceName: iface.Name,
 			BytesSent:     iface.BytesSent,
 			BytesRecv:     iface.BytesRecv,
@@ -38,8 +98,83 @@ func (c *NetworkCollector) buildNetworkMetrics(netStats []net.IOCountersStat) []
 			PacketsRecv:   iface.PacketsRecv,
 			ErrIn:         iface.Errin,
 			ErrOut:        iface.Errout,
-		})
+			DropIn:        iface.Dropin,
+			DropOut:       iface.Dropout,
+		}
+		sample := interfaceSample{counters: iface, at: now}
+		c.setRates(&m, sample)
+		samples[iface.Name] = sample
+		metrics = append(metrics, m)
 	}
+	c.lastSamples = samples
 
 	return metrics
 }
+
+// selected reports whether the interface matches the include globs and none of the exclude ones
+func (c *NetworkCollector) selected(name string) bool {
+	match := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return (len(c.include) == 0 || match(c.include)) && !match(c.exclude)
+}
+
+// setRates sets the rates of the interface since the previous collection. A counter lower than
+// at the previous collection means the counters were reset, e.g. the interface was recreated:
+// the rates are left at 0 and the new counters are the baseline of the next collection.
+func (c *NetworkCollector) setRates(m *model.NetworkMetrics, sample interfaceSample) {
+	last, ok := c.lastSamples[m.InterfaceName]
+	if !ok {
+		return
+	}
+	elapsed := sample.at.Sub(last.at).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	cur, prev := sample.counters, last.counters
+	if cur.BytesSent < prev.BytesSent || cur.BytesRecv < prev.BytesRecv ||
+		cur.PacketsSent < prev.PacketsSent || cur.PacketsRecv < prev.PacketsRecv {
+		c.log.Debug("Network interface counters were reset", zap.String("interface", m.InterfaceName))
+		return
+	}
+	m.BytesSentPerSec = float64(cur.BytesSent-prev.BytesSent) / elapsed
+	m.BytesRecvPerSec = float64(cur.BytesRecv-prev.BytesRecv) / elapsed
+	m.PacketsSentPerSec = float64(cur.PacketsSent-prev.PacketsSent) / elapsed
+	m.PacketsRecvPerSec = float64(cur.PacketsRecv-prev.PacketsRecv) / elapsed
+}
+
+// addInterfaceDetails sets the addresses, MTU, state and link speed of the interface. The state
+// and the speed are read from sysfs on Linux, elsewhere the interface is up when enabled and its
+// speed is unknown.
+func (c *NetworkCollector) addInterfaceDetails(m *model.NetworkMetrics, iface net.InterfaceStat) {
+	for _, addr := range iface.Addrs {
+		m.Addresses = append(m.Addresses, addr.Addr)
+	}
+	m.MTU = iface.MTU
+
+	dir := filepath.Join(c.sysRoot, "class", "net", iface.Name)
+	enabled := slices.Contains(iface.Flags, "up")
+	m.Up = enabled
+	// The flag only tells the interface is enabled, operstate whether its link is up
+	if state, err := os.ReadFile(filepath.Join(dir, "operstate")); err == nil {
+		switch strings.TrimSpace(string(state)) {
+		case "up":
+			m.Up = true
+		case "unknown":
+			// Loopback and some virtual interfaces don't report their state
+			m.Up = enabled
+		default:
+			m.Up = false
+		}
+	}
+	// speed is -1, or can't be read, when the link is down or has no speed
+	if speed, err := readUint(filepath.Join(dir, "speed")); err == nil {
+		m.SpeedMbps = speed
+	}
+}
@@ -1,17 +1,22 @@
 package collector
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/agent/model"
 	"go.uber.org/zap/zaptest"
 )
 
 func TestNewNetworkCollector(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	collector := NewNetworkCollector(logger)
+	collector, err := NewNetworkCollector(logger, nil, nil)
+	require.NoError(t, err)
 
 	assert.NotNil(t, collector)
 	assert.Equal(t, logger, collector.log)
@@ -19,7 +24,8 @@ func TestNewNetworkCollector(t *testing.T) {
 
 func TestNetworkCollector_Collect(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	collector := NewNetworkCollector(logger)
+	collector, err := NewNetworkCollector(logger, nil, nil)
+	require.NoError(t, err)
 
 	metrics, err := collector.Collect()
 	require.NoError(t, err)
@@ -40,7 +46,8 @@ func TestNetworkCollector_Collect(t *testing.T) {
 
 func TestNetworkCollector_buildNetworkMetrics(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	collector := NewNetworkCollector(logger)
+	collector, err := NewNetworkCollector(logger, nil, nil)
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name     string
@@ -117,7 +124,8 @@ func TestNetworkCollector_Collect_Integration(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	collector := NewNetworkCollector(logger)
+	collector, err := NewNetworkCollector(logger, nil, nil)
+	require.NoError(t, err)
 
 	metrics, err := collector.Collect()
 
@@ -135,3 +143,124 @@ func TestNetworkCollector_Collect_Integration(t *testing.T) {
 		assert.GreaterOrEqual(t, m.PacketsRecv, uint64(0))
 	}
 }
+
+func TestNetworkCollector_rates(t *testing.T) {
+	collector, err := NewNetworkCollector(zaptest.NewLogger(t), nil, nil)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	collector.now = func() time.Time { return now }
+
+	counters := func(bytesSent, bytesRecv, packetsSent, packetsRecv uint64) []net.IOCountersStat {
+		return []net.IOCountersStat{{
+			Name: "eth0", BytesSent: bytesSent, BytesRecv: bytesRecv, PacketsSent: packetsSent, PacketsRecv: packetsRecv,
+		}}
+	}
+
+	metrics := collector.buildNetworkMetrics(counters(1000, 2000, 10, 20))
+	require.Len(t, metrics, 1)
+	assert.Zero(t, metrics[0].BytesSentPerSec, "there is no rate at the first collection")
+
+	now = now.Add(10 * time.Second)
+	metrics = collector.buildNetworkMetrics(counters(11000, 7000, 110, 70))
+	assert.Equal(t, 1000.0, metrics[0].BytesSentPerSec)
+	assert.Equal(t, 500.0, metrics[0].BytesRecvPerSec)
+	assert.Equal(t, 10.0, metrics[0].PacketsSentPerSec)
+	assert.Equal(t, 5.0, metrics[0].PacketsRecvPerSec)
+
+	// The interface was recreated, its counters start over
+	now = now.Add(10 * time.Second)
+	metrics = collector.buildNetworkMetrics(counters(500, 9000, 5, 90))
+	assert.Zero(t, metrics[0].BytesSentPerSec)
+	assert.Zero(t, metrics[0].BytesRecvPerSec, "every rate is dropped on a reset")
+	assert.Equal(t, uint64(500), metrics[0].BytesSent)
+
+	now = now.Add(5 * time.Second)
+	metrics = collector.buildNetworkMetrics(counters(1500, 9000, 15, 90))
+	assert.Equal(t, 200.0, metrics[0].BytesSentPerSec, "the rates resume from the reset counters")
+	assert.Zero(t, metrics[0].BytesRecvPerSec)
+}
+
+func TestNetworkCollector_filter(t *testing.T) {
+	netStats := []net.IOCountersStat{{Name: "lo"}, {Name: "eth0"}, {Name: "eth1"}, {Name: "veth3a1b"}, {Name: "docker0"}}
+	names := func(collector *NetworkCollector) []string {
+		var names []string
+		for _, m := range collector.buildNetworkMetrics(netStats) {
+			names = append(names, m.InterfaceName)
+		}
+		return names
+	}
+
+	collector, err := NewNetworkCollector(zaptest.NewLogger(t), nil, []string{"veth*", "lo"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eth0", "eth1", "docker0"}, names(collector))
+
+	collector, err = NewNetworkCollector(zaptest.NewLogger(t), []string{"eth*"}, []string{"eth1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eth0"}, names(collector))
+
+	_, err = NewNetworkCollector(zaptest.NewLogger(t), []string{"eth["}, nil)
+	assert.Error(t, err)
+	_, err = NewNetworkCollector(zaptest.NewLogger(t), nil, []string{""})
+	assert.Error(t, err)
+}
+
+func writeSysfsFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	path = filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestNetworkCollector_addInterfaceDetails(t *testing.T) {
+	collector, err := NewNetworkCollector(zaptest.NewLogger(t), nil, nil)
+	require.NoError(t, err)
+	collector.sysRoot = t.TempDir()
+	writeSysfsFile(t, collector.sysRoot, "class/net/eth0/operstate", "up\n")
+	writeSysfsFile(t, collector.sysRoot, "class/net/eth0/speed", "1000\n")
+	writeSysfsFile(t, collector.sysRoot, "class/net/eth1/operstate", "down\n")
+	writeSysfsFile(t, collector.sysRoot, "class/net/eth1/speed", "-1\n")
+	writeSysfsFile(t, collector.sysRoot, "class/net/lo/operstate", "unknown\n")
+
+	var eth0, eth1, lo, tun0 model.NetworkMetrics
+	collector.addInterfaceDetails(&eth0, net.InterfaceStat{
+		Name: "eth0", MTU: 1500, Flags: []string{"up", "broadcast", "multicast"},
+		Addrs: net.InterfaceAddrList{{Addr: "192.168.1.10/24"}, {Addr: "fe80::1/64"}},
+	})
+	assert.Equal(t, model.NetworkMetrics{
+		Addresses: []string{"192.168.1.10/24", "fe80::1/64"}, MTU: 1500, Up: true, SpeedMbps: 1000,
+	}, eth0)
+
+	collector.addInterfaceDetails(&eth1, net.InterfaceStat{Name: "eth1", MTU: 9000, Flags: []string{"up"}})
+	assert.Equal(t, model.NetworkMetrics{MTU: 9000}, eth1, "an enabled interface without link is down")
+
+	collector.addInterfaceDetails(&lo, net.InterfaceStat{Name: "lo", MTU: 65536, Flags: []string{"up", "loopback"}})
+	assert.True(t, lo.Up, "the loopback reports an unknown state")
+
+	// Without sysfs, the flag is used
+	collector.addInterfaceDetails(&tun0, net.InterfaceStat{Name: "tun0", MTU: 1400, Flags: []string{"up", "pointtopoint"}})
+	assert.True(t, tun0.Up)
+	assert.Zero(t, tun0.SpeedMbps)
+}
+
+func TestNetworkCollector_CollectDetails(t *testing.T) {
+	collector, err := NewNetworkCollector(zaptest.NewLogger(t), nil, nil)
+	require.NoError(t, err)
+	netStats, err := net.IOCounters(true)
+	require.NoError(t, err)
+	require.NotEmpty(t, netStats)
+	collector.sysRoot = t.TempDir()
+	collector.interfaces = func() (net.InterfaceStatList, error) {
+		return net.InterfaceStatList{{Name: netStats[0].Name, MTU: 1500, Flags: []string{"up"}}}, nil
+	}
+
+	metrics, err := collector.Collect()
+	require.NoError(t, err)
+	for _, m := range metrics {
+		if m.InterfaceName == netStats[0].Name {
+			assert.Equal(t, 1500, m.MTU)
+			assert.True(t, m.Up)
+		} else {
+			assert.Zero(t, m.MTU, "interfaces not listed have no details")
+		}
+	}
+}
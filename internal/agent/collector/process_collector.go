@@ -0,0 +1,159 @@
+package collector
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/theotruvelot/g0s/internal/agent/model"
+	"go.uber.org/zap"
+)
+
+// processSample is the CPU time of a process at a collection, to compute its usage at the next one
+type processSample struct {
+	createTime int64
+	cpuTime    float64
+	at         time.Time
+}
+
+// processUsage is the usage of a process, read for every process to rank them
+type processUsage struct {
+	proc       *process.Process
+	cpuPercent float64
+	rss        uint64
+}
+
+// ProcessCollector reports the top processes by CPU and by resident memory. The CPU usage of a
+// process is computed between two collections, or since it started at the first one.
+type ProcessCollector struct {
+	log         *zap.Logger
+	topN        int
+	maxCmdline  int
+	lastSamples map[int32]processSample
+	now         func() time.Time
+}
+
+// NewProcessCollector returns a collector reporting the topN processes by CPU and the topN by
+// resident memory, their command line truncated to maxCmdline bytes
+func NewProcessCollector(log *zap.Logger, topN, maxCmdline int) *ProcessCollector {
+	return &ProcessCollector{
+		log:         log,
+		topN:        topN,
+		maxCmdline:  maxCmdline,
+		lastSamples: make(map[int32]processSample),
+		now:         time.Now,
+	}
+}
+
+func (c *ProcessCollector) Collect() ([]model.ProcessMetrics, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		c.log.Error("Failed to list processes", zap.Error(err))
+		return nil, err
+	}
+
+	now := c.now()
+	samples := make(map[int32]processSample, len(procs))
+	usages := make([]processUsage, 0, len(procs))
+	for _, p := range procs {
+		// Processes exiting in between or not readable by the agent user are skipped
+		times, err := p.Times()
+		if err != nil {
+			continue
+		}
+		createTime, _ := p.CreateTime()
+		sample := processSample{createTime: createTime, cpuTime: times.User + times.System, at: now}
+		samples[p.Pid] = sample
+
+		usage := processUsage{proc: p, cpuPercent: c.cpuPercent(p.Pid, sample)}
+		if mem, err := p.MemoryInfo(); err == nil {
+			usage.rss = mem.RSS
+		}
+		usages = append(usages, usage)
+	}
+	c.lastSamples = samples
+
+	top := c.selectTop(usages)
+	metrics := make([]model.ProcessMetrics, 0, len(top))
+	for _, usage := range top {
+		metrics = append(metrics, c.buildProcessMetrics(usage))
+	}
+	return metrics, nil
+}
+
+// cpuPercent returns the CPU usage of the process since the previous collection, 100 being a
+// full core. A new process, or a PID reused by another one, is measured since it started.
+func (c *ProcessCollector) cpuPercent(pid int32, sample processSample) float64 {
+	last, ok := c.lastSamples[pid]
+	if !ok || last.createTime != sample.createTime {
+		if sample.createTime <= 0 {
+			return 0
+		}
+		last = processSample{createTime: sample.createTime, at: time.UnixMilli(sample.createTime)}
+	}
+
+	elapsed := sample.at.Sub(last.at).Seconds()
+	if elapsed <= 0 || sample.cpuTime < last.cpuTime {
+		return 0
+	}
+	return (sample.cpuTime - last.cpuTime) / elapsed * 100
+}
+
+// selectTop returns the topN processes by CPU and the topN by resident memory, without
+// duplicates, sorted by CPU usage
+func (c *ProcessCollector) selectTop(usages []processUsage) []processUsage {
+	if c.topN <= 0 {
+		return nil
+	}
+
+	selected := make(map[int32]bool)
+	var top []processUsage
+	pick := func(less func(a, b processUsage) bool) {
+		sort.SliceStable(usages, func(i, j int) bool { return less(usages[i], usages[j]) })
+		for i := 0; i < len(usages) && i < c.topN; i++ {
+			if !selected[usages[i].proc.Pid] {
+				selected[usages[i].proc.Pid] = true
+				top = append(top, usages[i])
+			}
+		}
+	}
+	pick(func(a, b processUsage) bool { return a.rss > b.rss })
+	pick(func(a, b processUsage) bool { return a.cpuPercent > b.cpuPercent })
+
+	sort.SliceStable(top, func(i, j int) bool { return top[i].cpuPercent > top[j].cpuPercent })
+	return top
+}
+
+// buildProcessMetrics reads the details of a top process, left empty when they can't be read
+func (c *ProcessCollector) buildProcessMetrics(usage processUsage) model.ProcessMetrics {
+	p := usage.proc
+	m := model.ProcessMetrics{
+		PID:        p.Pid,
+		CPUPercent: usage.cpuPercent,
+		RSSBytes:   usage.rss,
+	}
+	m.Name, _ = p.Name()
+	if cmdline, err := p.Cmdline(); err == nil {
+		m.Cmdline = truncate(cmdline, c.maxCmdline)
+	}
+	m.Username, _ = p.Username()
+	if status, err := p.Status(); err == nil {
+		m.State = strings.Join(status, ",")
+	}
+	m.Threads, _ = p.NumThreads()
+	m.OpenFiles, _ = p.NumFDs()
+	return m
+}
+
+// truncate cuts s to at most max bytes, without splitting a UTF-8 character. A max of 0 keeps s whole.
+func truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
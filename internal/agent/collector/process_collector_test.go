@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestProcessCollector_Collect(t *testing.T) {
+	collector := NewProcessCollector(zaptest.NewLogger(t), 1000, 64)
+
+	metrics, err := collector.Collect()
+	require.NoError(t, err)
+	require.NotEmpty(t, metrics)
+
+	var self bool
+	for _, m := range metrics {
+		assert.LessOrEqual(t, len(m.Cmdline), 64)
+		if m.PID == int32(os.Getpid()) {
+			self = true
+			assert.NotEmpty(t, m.Name)
+			assert.Positive(t, m.Threads)
+			assert.Positive(t, m.RSSBytes)
+		}
+	}
+	assert.True(t, self, "the test process is listed")
+}
+
+func TestProcessCollector_cpuPercent(t *testing.T) {
+	collector := NewProcessCollector(zaptest.NewLogger(t), 10, 0)
+	start := time.Unix(1700000000, 0)
+	collector.lastSamples[42] = processSample{createTime: start.UnixMilli(), cpuTime: 10, at: start.Add(time.Minute)}
+
+	// 30s of CPU in 60s
+	sample := processSample{createTime: start.UnixMilli(), cpuTime: 40, at: start.Add(2 * time.Minute)}
+	assert.InDelta(t, 50, collector.cpuPercent(42, sample), 0.001)
+
+	// The PID was reused by a process started 10s ago, measured since it started
+	sample = processSample{createTime: start.Add(110 * time.Second).UnixMilli(), cpuTime: 20, at: start.Add(2 * time.Minute)}
+	assert.InDelta(t, 200, collector.cpuPercent(42, sample), 0.001)
+
+	// A new process
+	assert.InDelta(t, 10, collector.cpuPercent(7, processSample{createTime: start.UnixMilli(), cpuTime: 12, at: start.Add(2 * time.Minute)}), 0.001)
+}
+
+func TestProcessCollector_selectTop(t *testing.T) {
+	collector := NewProcessCollector(zaptest.NewLogger(t), 2, 0)
+	usage := func(pid int32, cpuPercent float64, rss uint64) processUsage {
+		return processUsage{proc: &process.Process{Pid: pid}, cpuPercent: cpuPercent, rss: rss}
+	}
+
+	top := collector.selectTop([]processUsage{
+		usage(1, 0.1, 10),
+		usage(2, 95, 20),
+		usage(3, 0, 4000),
+		usage(4, 30, 3000),
+		usage(5, 1, 30),
+	})
+
+	var pids []int32
+	for _, u := range top {
+		pids = append(pids, u.proc.Pid)
+	}
+	assert.Equal(t, []int32{2, 4, 3}, pids, "top 2 by CPU and by memory, sorted by CPU")
+
+	collector.topN = 0
+	assert.Empty(t, collector.selectTop([]processUsage{usage(1, 1, 1)}))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "/usr/bin/python3 app.py", truncate("/usr/bin/python3 app.py", 0))
+	assert.Equal(t, "/usr/bin", truncate("/usr/bin/python3 app.py", 8))
+	assert.Equal(t, "caf", truncate("café", 4), "UTF-8 characters are not split")
+}
@@ -0,0 +1,135 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/agent/model"
+	"github.com/theotruvelot/g0s/internal/agent/systemd"
+	"go.uber.org/zap"
+)
+
+const _cgroupRoot = "/sys/fs/cgroup"
+
+// SystemdCollector reports the services of systemd: their state, restarts and last exit code,
+// and the memory and CPU usage of their cgroup
+type SystemdCollector struct {
+	log    *zap.Logger
+	client *systemd.Client
+	// cgroupRoot is where the cgroup hierarchy is mounted, replaced in tests
+	cgroupRoot string
+}
+
+func NewSystemdCollector(log *zap.Logger, client *systemd.Client) *SystemdCollector {
+	return &SystemdCollector{
+		log:        log,
+		client:     client,
+		cgroupRoot: _cgroupRoot,
+	}
+}
+
+func (c *SystemdCollector) Collect() ([]model.SystemdUnitMetrics, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	units, err := c.client.ListUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]model.SystemdUnitMetrics, 0, len(units))
+	for _, unit := range units {
+		if unit.LoadState != "loaded" || !strings.HasSuffix(unit.Name, ".service") {
+			continue
+		}
+		if ctx.Err() != nil {
+			c.log.Warn("Timed out reading the systemd services, the others are skipped", zap.Int("services", len(metrics)))
+			break
+		}
+
+		m := model.SystemdUnitMetrics{
+			Name:        unit.Name,
+			Description: unit.Description,
+			LoadState:   unit.LoadState,
+			ActiveState: unit.ActiveState,
+			SubState:    unit.SubState,
+		}
+		// The state of the unit is reported even when the service can't be read
+		service, err := c.client.Service(ctx, unit)
+		if err != nil {
+			c.log.Debug("Failed to read systemd service", zap.String("unit", unit.Name), zap.Error(err))
+		} else {
+			m.Result = service.Result
+			m.Restarts = service.Restarts
+			m.LastExitCode = service.ExecMainStatus
+			m.MemoryBytes, m.CPUSeconds = c.accounting(service)
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+// accounting returns the memory in bytes and the CPU time in seconds of the cgroup of the
+// service, from cgroup v2 then v1, falling back to the accounting of systemd. Both are 0 for
+// a service not running.
+func (c *SystemdCollector) accounting(service systemd.Service) (uint64, float64) {
+	cgroup := service.ControlGroup
+	if cgroup == "" {
+		return 0, 0
+	}
+
+	var memory uint64
+	if v, err := readUint(filepath.Join(c.cgroupRoot, cgroup, "memory.current")); err == nil {
+		memory = v
+	} else if v, err := readUint(filepath.Join(c.cgroupRoot, "memory", cgroup, "memory.usage_in_bytes")); err == nil {
+		memory = v
+	} else if service.MemoryCurrent != math.MaxUint64 {
+		memory = service.MemoryCurrent
+	}
+
+	var cpu float64
+	if usec, err := readCPUStatUsage(filepath.Join(c.cgroupRoot, cgroup, "cpu.stat")); err == nil {
+		cpu = float64(usec) / 1e6
+	} else if ns, err := readUint(filepath.Join(c.cgroupRoot, "cpuacct", cgroup, "cpuacct.usage")); err == nil {
+		cpu = float64(ns) / 1e9
+	} else if service.CPUUsageNSec != math.MaxUint64 {
+		cpu = float64(service.CPUUsageNSec) / 1e9
+	}
+	return memory, cpu
+}
+
+// readUint reads a file holding a single number, such as memory.current
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readCPUStatUsage reads usage_usec from the cpu.stat file of a cgroup v2
+func readCPUStatUsage(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "usage_usec "); ok {
+			return strconv.ParseUint(value, 10, 64)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no usage_usec in %s", path)
+}
@@ -0,0 +1,117 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/agent/model"
+	"github.com/theotruvelot/g0s/internal/agent/systemd"
+	"go.uber.org/zap/zaptest"
+)
+
+// fakeSystemdBus answers ListUnits with units, and GetAll with the service properties of the unit
+type fakeSystemdBus struct {
+	units    [][]any
+	services map[dbus.ObjectPath]map[string]any
+}
+
+func (f *fakeSystemdBus) Call(_ context.Context, _ string, path dbus.ObjectPath, _, method string, _ ...any) ([]any, error) {
+	if method == "ListUnits" {
+		return []any{f.units}, nil
+	}
+	props, ok := f.services[path]
+	if !ok {
+		return nil, dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}
+	}
+	variants := make(map[string]dbus.Variant, len(props))
+	for name, value := range props {
+		variants[name] = dbus.MakeVariant(value)
+	}
+	return []any{variants}, nil
+}
+
+func (f *fakeSystemdBus) Close() error {
+	return nil
+}
+
+func unitRow(name, load, active, sub string) []any {
+	path := dbus.ObjectPath("/org/freedesktop/systemd1/unit/" + name)
+	return []any{name, name + " daemon", load, active, sub, "", path, uint32(0), "", dbus.ObjectPath("/")}
+}
+
+func writeCgroupFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	path = filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestSystemdCollector_Collect(t *testing.T) {
+	bus := &fakeSystemdBus{
+		units: [][]any{
+			unitRow("nginx.service", "loaded", "active", "running"),
+			unitRow("backup.service", "loaded", "failed", "failed"),
+			unitRow("worker.service", "loaded", "active", "running"),
+			unitRow("gone.service", "not-found", "inactive", "dead"),
+			unitRow("sshd.socket", "loaded", "active", "listening"),
+		},
+		services: map[dbus.ObjectPath]map[string]any{
+			"/org/freedesktop/systemd1/unit/nginx.service": {
+				"Result": "success", "NRestarts": uint32(2), "ExecMainStatus": int32(0),
+				"ControlGroup": "/system.slice/nginx.service",
+			},
+			"/org/freedesktop/systemd1/unit/backup.service": {
+				"Result": "exit-code", "NRestarts": uint32(0), "ExecMainStatus": int32(3),
+				"ControlGroup": "", "MemoryCurrent": uint64(math.MaxUint64),
+			},
+		},
+	}
+	collector := NewSystemdCollector(zaptest.NewLogger(t), systemd.NewClient(func() (systemd.Bus, error) { return bus, nil }))
+	collector.cgroupRoot = t.TempDir()
+	writeCgroupFile(t, collector.cgroupRoot, "system.slice/nginx.service/memory.current", "73400320\n")
+	writeCgroupFile(t, collector.cgroupRoot, "system.slice/nginx.service/cpu.stat", "usage_usec 12500000\nuser_usec 10000000\nsystem_usec 2500000\n")
+
+	metrics, err := collector.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, []model.SystemdUnitMetrics{
+		{
+			Name: "nginx.service", Description: "nginx.service daemon",
+			LoadState: "loaded", ActiveState: "active", SubState: "running",
+			Result: "success", Restarts: 2, MemoryBytes: 73400320, CPUSeconds: 12.5,
+		},
+		{
+			Name: "backup.service", Description: "backup.service daemon",
+			LoadState: "loaded", ActiveState: "failed", SubState: "failed",
+			Result: "exit-code", LastExitCode: 3,
+		},
+		{
+			Name: "worker.service", Description: "worker.service daemon",
+			LoadState: "loaded", ActiveState: "active", SubState: "running",
+		},
+	}, metrics, "only the loaded services are reported, with their state even when the service can't be read")
+}
+
+func TestSystemdCollector_accounting(t *testing.T) {
+	collector := NewSystemdCollector(zaptest.NewLogger(t), nil)
+	collector.cgroupRoot = t.TempDir()
+
+	// cgroup v1
+	writeCgroupFile(t, collector.cgroupRoot, "memory/system.slice/app.service/memory.usage_in_bytes", "4096\n")
+	writeCgroupFile(t, collector.cgroupRoot, "cpuacct/system.slice/app.service/cpuacct.usage", "1500000000\n")
+	memory, cpu := collector.accounting(systemd.Service{ControlGroup: "/system.slice/app.service"})
+	assert.Equal(t, uint64(4096), memory)
+	assert.InDelta(t, 1.5, cpu, 0.0001)
+
+	// The cgroup can't be read, the accounting of systemd is used when enabled
+	memory, cpu = collector.accounting(systemd.Service{
+		ControlGroup: "/system.slice/other.service", MemoryCurrent: 8192, CPUUsageNSec: math.MaxUint64,
+	})
+	assert.Equal(t, uint64(8192), memory)
+	assert.Zero(t, cpu)
+}
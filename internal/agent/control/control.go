@@ -0,0 +1,357 @@
+// Package control carries out on the host the actions requested by the operators through the
+// server, such as signalling a process or restarting a systemd unit. Every action is refused
+// unless the configuration of the agent allows it.
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/theotruvelot/g0s/internal/agent/systemd"
+	pb "github.com/theotruvelot/g0s/pkg/proto/control"
+	"github.com/theotruvelot/g0s/pkg/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_initialBackoff = 2 * time.Second
+	_maxBackoff     = 30 * time.Second
+
+	// _unitWait is how long systemd is given to carry out an operation on a unit before its
+	// state is reported, and _unitTimeout how long the whole operation may last. The server
+	// waits 5s for the result.
+	_unitWait    = 3 * time.Second
+	_unitTimeout = 4 * time.Second
+)
+
+var _signals = map[pb.Signal]syscall.Signal{
+	pb.Signal_SIGNAL_TERM: syscall.SIGTERM,
+	pb.Signal_SIGNAL_KILL: syscall.SIGKILL,
+	pb.Signal_SIGNAL_HUP:  syscall.SIGHUP,
+}
+
+var _unitOperations = map[pb.UnitOperation]systemd.Operation{
+	pb.UnitOperation_UNIT_OPERATION_START:   systemd.Start,
+	pb.UnitOperation_UNIT_OPERATION_STOP:    systemd.Stop,
+	pb.UnitOperation_UNIT_OPERATION_RESTART: systemd.Restart,
+}
+
+// Config is what the operators may do on the host
+type Config struct {
+	// SignalProcesses are the name globs of the processes that may be signalled, e.g. "nginx"
+	// or "php-fpm*". Every signal is refused when empty.
+	SignalProcesses []string
+	// Units are the name globs of the systemd units that may be started, stopped or restarted,
+	// e.g. "nginx" or "app-*.service", a glob without unit type matching services. Every
+	// operation is refused when empty.
+	Units []string
+}
+
+// unitController carries out the operations on the systemd units, see systemd.Client
+type unitController interface {
+	Control(ctx context.Context, name string, op systemd.Operation, wait time.Duration) (systemd.Unit, error)
+}
+
+// processInfo is a process of the host, as the agent sees it
+type processInfo struct {
+	pid  int32
+	name string
+}
+
+// Service keeps a control stream open with the server and answers the commands it receives
+type Service struct {
+	config   Config
+	hostname string
+	client   pb.ControlServiceClient
+	logger   *zap.Logger
+	// self is the pid of the agent, which is never signalled
+	self int32
+	// processes lists the processes of the host and signal sends them a signal, replaced in tests
+	processes func() ([]processInfo, error)
+	signal    func(pid int32, sig syscall.Signal) error
+	// units is nil when systemd does not manage the host
+	units unitController
+}
+
+// New returns a service carrying out the actions config allows, units being nil when systemd
+// does not manage the host
+func New(client pb.ControlServiceClient, units *systemd.Client, logger *zap.Logger, hostname string, config Config) (*Service, error) {
+	for _, pattern := range config.SignalProcesses {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return nil, fmt.Errorf("invalid process name pattern %q", pattern)
+		}
+	}
+	unitPatterns := make([]string, len(config.Units))
+	for i, pattern := range config.Units {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return nil, fmt.Errorf("invalid unit name pattern %q", pattern)
+		}
+		if !utils.HasUnitType(pattern) {
+			pattern += ".service"
+		}
+		unitPatterns[i] = pattern
+	}
+	config.Units = unitPatterns
+
+	s := &Service{
+		config:    config,
+		hostname:  hostname,
+		client:    client,
+		logger:    logger,
+		self:      int32(os.Getpid()),
+		processes: listProcesses,
+		signal:    signalProcess,
+	}
+	if units != nil {
+		s.units = units
+	}
+	return s, nil
+}
+
+func (s *Service) Start(ctx context.Context) {
+	if len(s.config.SignalProcesses) == 0 && len(s.config.Units) == 0 {
+		s.logger.Info("Starting control service, every action is refused")
+	} else {
+		s.logger.Info("Starting control service",
+			zap.Strings("signal_processes", s.config.SignalProcesses),
+			zap.Strings("units", s.config.Units))
+	}
+	go s.controlLoop(ctx)
+}
+
+// controlLoop keeps the control stream open until ctx is done, backing off while the server
+// is unreachable or does not support it
+func (s *Service) controlLoop(ctx context.Context) {
+	backoff := _initialBackoff
+	for {
+		started := time.Now()
+		err := s.serve(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// Streams only carry the rare commands, one that lasted was working
+		if time.Since(started) > _maxBackoff {
+			backoff = _initialBackoff
+		}
+		if status.Code(err) == codes.Unimplemented {
+			s.logger.Debug("The server does not support control streams")
+			backoff = _maxBackoff
+		} else {
+			s.logger.Debug("Control stream closed, reconnecting", zap.Error(err), zap.Duration("backoff", backoff))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, _maxBackoff)
+	}
+}
+
+// serve opens a control stream and answers its commands until it breaks
+func (s *Service) serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.AgentControl(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pb.AgentMessage{Message: &pb.AgentMessage_Hello{Hello: &pb.AgentHello{Hostname: s.hostname}}}); err != nil {
+		return err
+	}
+
+	for {
+		cmd, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		result := s.Execute(cmd)
+		if err := stream.Send(&pb.AgentMessage{Message: &pb.AgentMessage_Result{Result: result}}); err != nil {
+			return err
+		}
+	}
+}
+
+// Execute carries out a command and returns its result
+func (s *Service) Execute(cmd *pb.Command) *pb.CommandResult {
+	var result *pb.CommandResult
+	switch action := cmd.Action.(type) {
+	case *pb.Command_SignalProcess:
+		result = s.signalProcesses(action.SignalProcess)
+		s.logger.Info("Signal requested by the server",
+			zap.String("command_id", cmd.Id),
+			zap.Int32("pid", action.SignalProcess.Pid),
+			zap.String("process_name", action.SignalProcess.Name),
+			zap.String("signal", action.SignalProcess.Signal.String()),
+			zap.String("status", result.Status.String()),
+			zap.String("message", result.Message),
+			zap.Int32s("pids", result.Pids))
+	case *pb.Command_Unit:
+		result = s.controlUnit(action.Unit)
+		s.logger.Info("Unit operation requested by the server",
+			zap.String("command_id", cmd.Id),
+			zap.String("unit", action.Unit.Unit),
+			zap.String("operation", action.Unit.Operation.String()),
+			zap.String("status", result.Status.String()),
+			zap.String("message", result.Message))
+	default:
+		result = &pb.CommandResult{
+			Status:  pb.ActionStatus_ACTION_STATUS_FAILED,
+			Message: "unsupported command, upgrade the agent",
+		}
+		s.logger.Warn("Unsupported command received", zap.String("command_id", cmd.Id))
+	}
+	result.Id = cmd.Id
+	return result
+}
+
+// signalProcesses sends the signal to the process with the pid, or to every process with the
+// name, if the configuration allows signalling them
+func (s *Service) signalProcesses(cmd *pb.SignalProcessCommand) *pb.CommandResult {
+	sig, ok := _signals[cmd.Signal]
+	if !ok {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "unsupported signal %s", cmd.Signal)
+	}
+	if len(s.config.SignalProcesses) == 0 {
+		return failed(pb.ActionStatus_ACTION_STATUS_REFUSED, "signals are disabled on this agent, allow them with --allow-signal")
+	}
+
+	procs, err := s.processes()
+	if err != nil {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "failed to list processes: %v", err)
+	}
+	var targets []processInfo
+	for _, p := range procs {
+		if (cmd.Pid > 0 && p.pid == cmd.Pid) || (cmd.Pid <= 0 && p.name == cmd.Name) {
+			targets = append(targets, p)
+		}
+	}
+	if len(targets) == 0 {
+		if cmd.Pid > 0 {
+			return failed(pb.ActionStatus_ACTION_STATUS_NOT_FOUND, "no process with pid %d", cmd.Pid)
+		}
+		return failed(pb.ActionStatus_ACTION_STATUS_NOT_FOUND, "no process named %q", cmd.Name)
+	}
+
+	// Nothing is signalled unless every target is allowed
+	for _, p := range targets {
+		if p.pid == 1 || p.pid == s.self {
+			return failed(pb.ActionStatus_ACTION_STATUS_REFUSED, "process %d (%s) is never signalled", p.pid, p.name)
+		}
+		if !s.allowed(p.name) {
+			return failed(pb.ActionStatus_ACTION_STATUS_REFUSED, "process %q is not allowed to be signalled on this agent", p.name)
+		}
+	}
+
+	result := &pb.CommandResult{Status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED}
+	var errs []string
+	for _, p := range targets {
+		if err := s.signal(p.pid, sig); err != nil {
+			errs = append(errs, fmt.Sprintf("process %d: %v", p.pid, err))
+			continue
+		}
+		result.Pids = append(result.Pids, p.pid)
+	}
+	if len(errs) > 0 {
+		result.Status = pb.ActionStatus_ACTION_STATUS_FAILED
+		result.Message = "failed to signal " + strings.Join(errs, ", ")
+	}
+	return result
+}
+
+// controlUnit starts, stops or restarts the unit if the configuration allows it, and reports
+// the state of the unit once systemd carried the operation out
+func (s *Service) controlUnit(cmd *pb.UnitCommand) *pb.CommandResult {
+	op, ok := _unitOperations[cmd.Operation]
+	if !ok {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "unsupported unit operation %s", cmd.Operation)
+	}
+	name, err := utils.NormalizeUnitName(cmd.Unit)
+	if err != nil {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "%v", err)
+	}
+	if len(s.config.Units) == 0 {
+		return failed(pb.ActionStatus_ACTION_STATUS_REFUSED, "unit operations are disabled on this agent, allow them with --allow-unit")
+	}
+	if !matchAny(s.config.Units, name) {
+		return failed(pb.ActionStatus_ACTION_STATUS_REFUSED, "unit %q is not allowed to be controlled on this agent", name)
+	}
+	if s.units == nil {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "systemd does not manage this host")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _unitTimeout)
+	defer cancel()
+	unit, err := s.units.Control(ctx, name, op, _unitWait)
+	if errors.Is(err, systemd.ErrNoSuchUnit) {
+		return failed(pb.ActionStatus_ACTION_STATUS_NOT_FOUND, "no unit named %q", name)
+	}
+	if err != nil {
+		return failed(pb.ActionStatus_ACTION_STATUS_FAILED, "failed to %s %s: %v", op, name, err)
+	}
+
+	result := &pb.CommandResult{
+		Status:  pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+		Message: fmt.Sprintf("%s is %s (%s)", name, unit.ActiveState, unit.SubState),
+	}
+	if unit.ActiveState == "failed" && op != systemd.Stop {
+		result.Status = pb.ActionStatus_ACTION_STATUS_FAILED
+	}
+	return result
+}
+
+func (s *Service) allowed(name string) bool {
+	return matchAny(s.config.SignalProcesses, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func failed(actionStatus pb.ActionStatus, format string, args ...any) *pb.CommandResult {
+	return &pb.CommandResult{Status: actionStatus, Message: fmt.Sprintf(format, args...)}
+}
+
+func listProcesses() ([]processInfo, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]processInfo, 0, len(procs))
+	for _, p := range procs {
+		// Processes exiting in between are skipped
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, processInfo{pid: p.Pid, name: name})
+	}
+	return infos, nil
+}
+
+func signalProcess(pid int32, sig syscall.Signal) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		if errors.Is(err, process.ErrorProcessNotRunning) {
+			return errors.New("process exited")
+		}
+		return err
+	}
+	return p.SendSignal(sig)
+}
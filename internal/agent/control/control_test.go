@@ -0,0 +1,305 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/agent/systemd"
+	pb "github.com/theotruvelot/g0s/pkg/proto/control"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// signalled is a signal sent to a process
+type signalled struct {
+	pid int32
+	sig syscall.Signal
+}
+
+func newTestService(t *testing.T, allowed ...string) (*Service, *[]signalled) {
+	t.Helper()
+	s, err := New(nil, nil, zap.NewNop(), "web-1", Config{SignalProcesses: allowed})
+	require.NoError(t, err)
+	s.self = 99
+	s.processes = func() ([]processInfo, error) {
+		return []processInfo{
+			{pid: 1, name: "systemd"},
+			{pid: 10, name: "nginx"},
+			{pid: 11, name: "nginx"},
+			{pid: 20, name: "php-fpm8.2"},
+			{pid: 30, name: "postgres"},
+			{pid: 40, name: "gone"},
+			{pid: 99, name: "g0s-agent"},
+		}, nil
+	}
+	var sent []signalled
+	s.signal = func(pid int32, sig syscall.Signal) error {
+		if pid == 40 {
+			return errors.New("process exited")
+		}
+		sent = append(sent, signalled{pid: pid, sig: sig})
+		return nil
+	}
+	return s, &sent
+}
+
+func signalCommand(pid int32, name string, signal pb.Signal) *pb.Command {
+	return &pb.Command{Id: "cmd-1", Action: &pb.Command_SignalProcess{SignalProcess: &pb.SignalProcessCommand{
+		Pid: pid, Name: name, Signal: signal,
+	}}}
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	_, err := New(nil, nil, zap.NewNop(), "web-1", Config{SignalProcesses: []string{"nginx", "php-fpm["}})
+	assert.Error(t, err)
+}
+
+func TestService_RefusesByDefault(t *testing.T) {
+	s, sent := newTestService(t)
+
+	result := s.Execute(signalCommand(10, "", pb.Signal_SIGNAL_TERM))
+	assert.Equal(t, "cmd-1", result.Id)
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_REFUSED, result.Status)
+	assert.Contains(t, result.Message, "--allow-signal")
+	assert.Empty(t, *sent)
+}
+
+func TestService_SignalProcess(t *testing.T) {
+	tests := []struct {
+		name   string
+		cmd    *pb.Command
+		status pb.ActionStatus
+		pids   []int32
+		sent   []signalled
+	}{
+		{
+			name:   "by pid",
+			cmd:    signalCommand(20, "", pb.Signal_SIGNAL_HUP),
+			status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+			pids:   []int32{20},
+			sent:   []signalled{{pid: 20, sig: syscall.SIGHUP}},
+		},
+		{
+			name:   "by name",
+			cmd:    signalCommand(0, "nginx", pb.Signal_SIGNAL_TERM),
+			status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+			pids:   []int32{10, 11},
+			sent:   []signalled{{pid: 10, sig: syscall.SIGTERM}, {pid: 11, sig: syscall.SIGTERM}},
+		},
+		{
+			name:   "not allowed",
+			cmd:    signalCommand(30, "", pb.Signal_SIGNAL_KILL),
+			status: pb.ActionStatus_ACTION_STATUS_REFUSED,
+		},
+		{
+			name:   "init",
+			cmd:    signalCommand(0, "systemd", pb.Signal_SIGNAL_HUP),
+			status: pb.ActionStatus_ACTION_STATUS_REFUSED,
+		},
+		{
+			name:   "agent itself",
+			cmd:    signalCommand(99, "", pb.Signal_SIGNAL_KILL),
+			status: pb.ActionStatus_ACTION_STATUS_REFUSED,
+		},
+		{
+			name:   "no such pid",
+			cmd:    signalCommand(12, "", pb.Signal_SIGNAL_TERM),
+			status: pb.ActionStatus_ACTION_STATUS_NOT_FOUND,
+		},
+		{
+			name:   "no such name",
+			cmd:    signalCommand(0, "redis", pb.Signal_SIGNAL_TERM),
+			status: pb.ActionStatus_ACTION_STATUS_NOT_FOUND,
+		},
+		{
+			name:   "unspecified signal",
+			cmd:    signalCommand(10, "", pb.Signal_SIGNAL_UNSPECIFIED),
+			status: pb.ActionStatus_ACTION_STATUS_FAILED,
+		},
+		{
+			name:   "process exited",
+			cmd:    signalCommand(40, "", pb.Signal_SIGNAL_TERM),
+			status: pb.ActionStatus_ACTION_STATUS_FAILED,
+		},
+		{
+			name:   "unsupported command",
+			cmd:    &pb.Command{Id: "cmd-1"},
+			status: pb.ActionStatus_ACTION_STATUS_FAILED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, sent := newTestService(t, "nginx", "php-fpm*", "systemd", "g0s-agent", "gone")
+
+			result := s.Execute(tt.cmd)
+			assert.Equal(t, "cmd-1", result.Id)
+			assert.Equal(t, tt.status, result.Status, result.Message)
+			assert.Equal(t, tt.pids, result.Pids)
+			assert.Equal(t, tt.sent, *sent)
+		})
+	}
+}
+
+// fakeUnits are the units of a host, by name, recording the operations carried out
+type fakeUnits struct {
+	units map[string]systemd.Unit
+	done  []string
+}
+
+func (f *fakeUnits) Control(_ context.Context, name string, op systemd.Operation, _ time.Duration) (systemd.Unit, error) {
+	unit, ok := f.units[name]
+	if !ok {
+		return systemd.Unit{}, systemd.ErrNoSuchUnit
+	}
+	f.done = append(f.done, string(op)+" "+name)
+	return unit, nil
+}
+
+func unitCommand(unit string, operation pb.UnitOperation) *pb.Command {
+	return &pb.Command{Id: "cmd-1", Action: &pb.Command_Unit{Unit: &pb.UnitCommand{Unit: unit, Operation: operation}}}
+}
+
+func TestNew_InvalidUnitPattern(t *testing.T) {
+	_, err := New(nil, nil, zap.NewNop(), "web-1", Config{Units: []string{"nginx", "app-["}})
+	assert.Error(t, err)
+}
+
+func TestService_ControlUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *pb.Command
+		status  pb.ActionStatus
+		message string
+		done    []string
+	}{
+		{
+			name:    "restart",
+			cmd:     unitCommand("nginx", pb.UnitOperation_UNIT_OPERATION_RESTART),
+			status:  pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+			message: "nginx.service is active (running)",
+			done:    []string{"restart nginx.service"},
+		},
+		{
+			name:   "glob",
+			cmd:    unitCommand("app-worker@2.service", pb.UnitOperation_UNIT_OPERATION_STOP),
+			status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+			done:   []string{"stop app-worker@2.service"},
+		},
+		{
+			name:    "start of a unit that fails",
+			cmd:     unitCommand("backup.service", pb.UnitOperation_UNIT_OPERATION_START),
+			status:  pb.ActionStatus_ACTION_STATUS_FAILED,
+			message: "backup.service is failed (failed)",
+			done:    []string{"start backup.service"},
+		},
+		{
+			name:   "not allowed",
+			cmd:    unitCommand("sshd", pb.UnitOperation_UNIT_OPERATION_STOP),
+			status: pb.ActionStatus_ACTION_STATUS_REFUSED,
+		},
+		{
+			name:   "glob without type matches services only",
+			cmd:    unitCommand("app-cleanup.timer", pb.UnitOperation_UNIT_OPERATION_START),
+			status: pb.ActionStatus_ACTION_STATUS_REFUSED,
+		},
+		{
+			name:   "no such unit",
+			cmd:    unitCommand("app-web.service", pb.UnitOperation_UNIT_OPERATION_START),
+			status: pb.ActionStatus_ACTION_STATUS_NOT_FOUND,
+		},
+		{
+			name:   "invalid unit",
+			cmd:    unitCommand("../nginx", pb.UnitOperation_UNIT_OPERATION_START),
+			status: pb.ActionStatus_ACTION_STATUS_FAILED,
+		},
+		{
+			name:   "unspecified operation",
+			cmd:    unitCommand("nginx", pb.UnitOperation_UNIT_OPERATION_UNSPECIFIED),
+			status: pb.ActionStatus_ACTION_STATUS_FAILED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(nil, nil, zap.NewNop(), "web-1", Config{Units: []string{"nginx.service", "app-*", "backup"}})
+			require.NoError(t, err)
+			units := &fakeUnits{units: map[string]systemd.Unit{
+				"nginx.service":        {Name: "nginx.service", ActiveState: "active", SubState: "running"},
+				"app-worker@2.service": {Name: "app-worker@2.service", ActiveState: "inactive", SubState: "dead"},
+				"backup.service":       {Name: "backup.service", ActiveState: "failed", SubState: "failed"},
+			}}
+			s.units = units
+
+			result := s.Execute(tt.cmd)
+			assert.Equal(t, "cmd-1", result.Id)
+			assert.Equal(t, tt.status, result.Status, result.Message)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.Message)
+			}
+			assert.Equal(t, tt.done, units.done)
+		})
+	}
+}
+
+func TestService_ControlUnitRefusedByDefault(t *testing.T) {
+	s, _ := newTestService(t)
+	result := s.Execute(unitCommand("nginx", pb.UnitOperation_UNIT_OPERATION_RESTART))
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_REFUSED, result.Status)
+	assert.Contains(t, result.Message, "--allow-unit")
+
+	// Allowed, but systemd does not manage the host
+	s, err := New(nil, nil, zap.NewNop(), "web-1", Config{Units: []string{"nginx"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nginx.service"}, s.config.Units)
+	result = s.Execute(unitCommand("nginx", pb.UnitOperation_UNIT_OPERATION_RESTART))
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_FAILED, result.Status)
+}
+
+// fakeControlStream is a control stream receiving cmds, then failing
+type fakeControlStream struct {
+	grpc.ClientStream
+	cmds []*pb.Command
+	sent []*pb.AgentMessage
+}
+
+func (f *fakeControlStream) Send(msg *pb.AgentMessage) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeControlStream) Recv() (*pb.Command, error) {
+	if len(f.cmds) == 0 {
+		return nil, io.EOF
+	}
+	cmd := f.cmds[0]
+	f.cmds = f.cmds[1:]
+	return cmd, nil
+}
+
+type fakeControlClient struct {
+	pb.ControlServiceClient
+	stream *fakeControlStream
+}
+
+func (f *fakeControlClient) AgentControl(context.Context, ...grpc.CallOption) (pb.ControlService_AgentControlClient, error) {
+	return f.stream, nil
+}
+
+func TestService_Serve(t *testing.T) {
+	s, sent := newTestService(t, "nginx")
+	stream := &fakeControlStream{cmds: []*pb.Command{signalCommand(0, "nginx", pb.Signal_SIGNAL_HUP)}}
+	s.client = &fakeControlClient{stream: stream}
+
+	assert.ErrorIs(t, s.serve(context.Background()), io.EOF)
+	require.Len(t, stream.sent, 2)
+	assert.Equal(t, "web-1", stream.sent[0].GetHello().GetHostname(), "the agent introduces itself first")
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_SUCCEEDED, stream.sent[1].GetResult().GetStatus())
+	assert.Len(t, *sent, 2)
+}
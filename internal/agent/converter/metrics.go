@@ -17,6 +17,7 @@ func ConvertHostMetrics(m model.HostMetrics) *pb.HostMetrics {
 		VirtualizationSystem: m.VirtualizationSystem,
 		VirtualizationRole:   m.VirtualizationRole,
 		KernelVersion:        m.KernelVersion,
+		KernelArch:           m.KernelArch,
 	}
 }
 
@@ -76,13 +77,23 @@ func ConvertNetworkMetrics(metrics []model.NetworkMetrics) []*pb.NetworkMetrics
 	result := make([]*pb.NetworkMetrics, len(metrics))
 	for i, m := range metrics {
 		result[i] = &pb.NetworkMetrics{
-			InterfaceName: m.InterfaceName,
-			BytesSent:     m.BytesSent,
-			BytesRecv:     m.BytesRecv,
-			PacketsSent:   m.PacketsSent,
-			PacketsRecv:   m.PacketsRecv,
-			ErrIn:         m.ErrIn,
-			ErrOut:        m.ErrOut,
+			InterfaceName:     m.InterfaceName,
+			BytesSent:         m.BytesSent,
+			BytesRecv:         m.BytesRecv,
+			PacketsSent:       m.PacketsSent,
+			PacketsRecv:       m.PacketsRecv,
+			ErrIn:             m.ErrIn,
+			ErrOut:            m.ErrOut,
+			DropIn:            m.DropIn,
+			DropOut:           m.DropOut,
+			BytesSentPerSec:   m.BytesSentPerSec,
+			BytesRecvPerSec:   m.BytesRecvPerSec,
+			PacketsSentPerSec: m.PacketsSentPerSec,
+			PacketsRecvPerSec: m.PacketsRecvPerSec,
+			Addresses:         m.Addresses,
+			Mtu:               uint32(m.MTU),
+			Up:                m.Up,
+			SpeedMbps:         m.SpeedMbps,
 		}
 	}
 	return result
@@ -108,3 +119,60 @@ func ConvertDockerMetrics(metrics []model.DockerMetrics) []*pb.DockerMetrics {
 	}
 	return result
 }
+
+func ConvertProcessMetrics(metrics []model.ProcessMetrics) []*pb.ProcessMetrics {
+	result := make([]*pb.ProcessMetrics, len(metrics))
+	for i, m := range metrics {
+		result[i] = &pb.ProcessMetrics{
+			Pid:        m.PID,
+			Name:       m.Name,
+			Cmdline:    m.Cmdline,
+			Username:   m.Username,
+			State:      m.State,
+			Threads:    m.Threads,
+			OpenFiles:  m.OpenFiles,
+			CpuPercent: m.CPUPercent,
+			RssBytes:   m.RSSBytes,
+		}
+	}
+	return result
+}
+
+func ConvertSystemdUnitMetrics(metrics []model.SystemdUnitMetrics) []*pb.SystemdUnitMetrics {
+	result := make([]*pb.SystemdUnitMetrics, len(metrics))
+	for i, m := range metrics {
+		result[i] = &pb.SystemdUnitMetrics{
+			Name:         m.Name,
+			Description:  m.Description,
+			LoadState:    m.LoadState,
+			ActiveState:  m.ActiveState,
+			SubState:     m.SubState,
+			Result:       m.Result,
+			Restarts:     m.Restarts,
+			LastExitCode: m.LastExitCode,
+			MemoryBytes:  m.MemoryBytes,
+			CpuSeconds:   m.CPUSeconds,
+		}
+	}
+	return result
+}
+
+func ConvertSystemLoadMetrics(m model.SystemLoadMetrics) *pb.SystemLoadMetrics {
+	pressure := make([]*pb.PressureMetrics, len(m.Pressure))
+	for i, p := range m.Pressure {
+		pressure[i] = &pb.PressureMetrics{
+			Resource:          p.Resource,
+			Kind:              p.Kind,
+			Avg10:             p.Avg10,
+			Avg60:             p.Avg60,
+			Avg300:            p.Avg300,
+			TotalMicroseconds: p.TotalMicroseconds,
+		}
+	}
+	return &pb.SystemLoadMetrics{
+		Load1:    m.Load1,
+		Load5:    m.Load5,
+		Load15:   m.Load15,
+		Pressure: pressure,
+	}
+}
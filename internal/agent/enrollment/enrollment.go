@@ -0,0 +1,125 @@
+package enrollment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/auth"
+	"github.com/theotruvelot/g0s/pkg/utils"
+)
+
+// ErrNotEnrolled is returned when the agent has neither a stored credential nor a join token
+var ErrNotEnrolled = errors.New("agent is not enrolled, start it once with --join-token")
+
+// Credential is the per-host credential the agent sends as bearer token on every call.
+// It implements credentials.PerRPCCredentials and sends nothing until it is set.
+type Credential struct {
+	mu    sync.RWMutex
+	token string
+}
+
+// Set replaces the credential sent with the next calls
+func (c *Credential) Set(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *Credential) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity is false so agents can reach a development server without TLS,
+// the server warns when it runs that way
+func (c *Credential) RequireTransportSecurity() bool {
+	return false
+}
+
+// Load returns the credential stored at path, or an empty string if there is none yet
+func Load(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read credential: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save stores the credential at path, readable by the agent user only. The file is replaced
+// atomically so a crash never leaves a truncated credential behind.
+func Save(path, credential string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create credential directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credential-*")
+	if err != nil {
+		return fmt.Errorf("create credential file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(credential + "\n"); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write credential: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write credential: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("protect credential: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Enroll exchanges the single-use join token for the credential of the host
+func Enroll(ctx context.Context, client pb.AuthServiceClient, joinToken, hostname string) (string, error) {
+	res, err := client.EnrollAgent(ctx, &pb.EnrollAgentRequest{JoinToken: joinToken, Hostname: hostname})
+	if err != nil {
+		return "", fmt.Errorf("enroll agent: %w", err)
+	}
+	if res.GetCredential() == "" {
+		return "", errors.New("enroll agent: server returned an empty credential")
+	}
+	return res.GetCredential(), nil
+}
+
+// EnsureCredential returns the credential stored at path. Without one it enrolls with the
+// join token and stores the new credential, so the join token is only needed on first start.
+// A join token given while a credential is stored is ignored. The join token is single-use, so
+// it is only sent once the credential is known to be storable at path.
+func EnsureCredential(ctx context.Context, client pb.AuthServiceClient, path, joinToken, hostname string) (credential string, enrolled bool, err error) {
+	credential, err = Load(path)
+	if err != nil {
+		return "", false, err
+	}
+	if credential != "" {
+		return credential, false, nil
+	}
+	if joinToken == "" {
+		return "", false, ErrNotEnrolled
+	}
+	if err := utils.CheckWritableDir(filepath.Dir(path)); err != nil {
+		return "", false, fmt.Errorf("store credential in %s: %w", path, err)
+	}
+
+	credential, err = Enroll(ctx, client, joinToken, hostname)
+	if err != nil {
+		return "", false, err
+	}
+	if err := Save(path, credential); err != nil {
+		return "", false, err
+	}
+	return credential, true, nil
+}
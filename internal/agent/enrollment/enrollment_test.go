@@ -0,0 +1,89 @@
+package enrollment
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockAuthClient struct {
+	pb.AuthServiceClient
+	calls    int
+	hostname string
+}
+
+func (m *mockAuthClient) EnrollAgent(_ context.Context, req *pb.EnrollAgentRequest, _ ...grpc.CallOption) (*pb.EnrollAgentResponse, error) {
+	m.calls++
+	m.hostname = req.Hostname
+	if req.JoinToken != "g0sj_valid" {
+		return nil, status.Error(codes.Unauthenticated, "invalid join token")
+	}
+	return &pb.EnrollAgentResponse{HostId: "host-id", Credential: "agent-credential"}, nil
+}
+
+func TestEnsureCredential_Enrolls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "agent.credential")
+	client := &mockAuthClient{}
+
+	credential, enrolled, err := EnsureCredential(context.Background(), client, path, "g0sj_valid", "web-1")
+	require.NoError(t, err)
+	assert.True(t, enrolled)
+	assert.Equal(t, "agent-credential", credential)
+	assert.Equal(t, "web-1", client.hostname)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The stored credential is used from then on, the join token is not sent again
+	credential, enrolled, err = EnsureCredential(context.Background(), client, path, "g0sj_valid", "web-1")
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+	assert.Equal(t, "agent-credential", credential)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestEnsureCredential_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.credential")
+
+	_, _, err := EnsureCredential(context.Background(), &mockAuthClient{}, path, "", "web-1")
+	assert.ErrorIs(t, err, ErrNotEnrolled)
+
+	_, _, err = EnsureCredential(context.Background(), &mockAuthClient{}, path, "g0sj_used", "web-1")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Nothing is stored after a failed enrollment
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEnsureCredential_NotStorable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	client := &mockAuthClient{}
+
+	_, _, err := EnsureCredential(context.Background(), client, filepath.Join(file, "agent.credential"), "g0sj_valid", "web-1")
+	assert.Error(t, err)
+	assert.Zero(t, client.calls, "the join token is kept for a retry with a writable credential file")
+}
+
+func TestCredential_GetRequestMetadata(t *testing.T) {
+	credential := &Credential{}
+
+	md, err := credential.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, md)
+
+	credential.Set("agent-credential")
+	md, err = credential.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer agent-credential", md["authorization"])
+}
@@ -0,0 +1,64 @@
+// Package handshake negotiates the protocol version and the features the agent uses with the
+// server, so agents and servers of different versions work together during rollouts
+package handshake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/health"
+	"github.com/theotruvelot/g0s/pkg/version"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrIncompatible is returned when the agent and the server have no protocol version in common
+var ErrIncompatible = errors.New("agent and server are incompatible")
+
+// Features are the features the agent supports
+var Features = []string{version.FeatureMetricsBatches, version.FeatureGzip}
+
+// Result is what the agent and the server agreed on
+type Result struct {
+	// ServerVersion is empty for servers predating the handshake
+	ServerVersion string
+	// Features are the features both sides support. For servers predating the handshake,
+	// they are every feature of the agent, each one falling back when the server lacks it.
+	Features []string
+}
+
+// Has reports whether both sides support the feature
+func (r *Result) Has(feature string) bool {
+	return slices.Contains(r.Features, feature)
+}
+
+// Hello announces the agent of the host and its enabled collectors to the server. It returns
+// ErrIncompatible if either side can't work with the other.
+func Hello(ctx context.Context, client pb.HealthServiceClient, hostname string, collectors []string) (*Result, error) {
+	resp, err := client.Hello(ctx, &pb.HelloRequest{
+		Hostname:           hostname,
+		AgentVersion:       version.Version,
+		ProtocolVersion:    version.Protocol,
+		MinProtocolVersion: version.MinProtocol,
+		Features:           Features,
+		Collectors:         collectors,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			return &Result{Features: Features}, nil
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("%w: %s", ErrIncompatible, status.Convert(err).Message())
+		default:
+			return nil, fmt.Errorf("handshake: %w", err)
+		}
+	}
+
+	if resp.ProtocolVersion < version.MinProtocol {
+		return nil, fmt.Errorf("%w: server %s speaks protocol version %d but agent %s requires %d to %d, upgrade the server",
+			ErrIncompatible, resp.ServerVersion, resp.ProtocolVersion, version.Version, version.MinProtocol, version.Protocol)
+	}
+	return &Result{ServerVersion: resp.ServerVersion, Features: resp.Features}, nil
+}
@@ -0,0 +1,71 @@
+package handshake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/health"
+	"github.com/theotruvelot/g0s/pkg/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClient answers Hello with resp or err
+type fakeClient struct {
+	pb.HealthServiceClient
+	req  *pb.HelloRequest
+	resp *pb.HelloResponse
+	err  error
+}
+
+func (c *fakeClient) Hello(_ context.Context, req *pb.HelloRequest, _ ...grpc.CallOption) (*pb.HelloResponse, error) {
+	c.req = req
+	return c.resp, c.err
+}
+
+func TestHello_NegotiatesFeatures(t *testing.T) {
+	client := &fakeClient{resp: &pb.HelloResponse{
+		ServerVersion:   "v1.4.0",
+		ProtocolVersion: version.Protocol,
+		Features:        []string{version.FeatureGzip},
+	}}
+
+	result, err := Hello(context.Background(), client, "web-1", []string{"cpu", "ram"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", result.ServerVersion)
+	assert.True(t, result.Has(version.FeatureGzip))
+	assert.False(t, result.Has(version.FeatureMetricsBatches))
+
+	assert.Equal(t, "web-1", client.req.Hostname)
+	assert.Equal(t, version.Protocol, client.req.ProtocolVersion)
+	assert.Equal(t, Features, client.req.Features)
+	assert.Equal(t, []string{"cpu", "ram"}, client.req.Collectors)
+}
+
+func TestHello_ServerPredatingHandshake(t *testing.T) {
+	client := &fakeClient{err: status.Error(codes.Unimplemented, "unknown method Hello")}
+
+	result, err := Hello(context.Background(), client, "web-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.ServerVersion)
+	assert.True(t, result.Has(version.FeatureMetricsBatches), "features fall back on their own")
+}
+
+func TestHello_Incompatible(t *testing.T) {
+	client := &fakeClient{err: status.Error(codes.FailedPrecondition, "upgrade the agent")}
+	_, err := Hello(context.Background(), client, "web-1", nil)
+	assert.ErrorIs(t, err, ErrIncompatible)
+	assert.ErrorContains(t, err, "upgrade the agent")
+
+	client = &fakeClient{resp: &pb.HelloResponse{ServerVersion: "v0.1.0", ProtocolVersion: version.MinProtocol - 1}}
+	_, err = Hello(context.Background(), client, "web-1", nil)
+	assert.ErrorIs(t, err, ErrIncompatible)
+
+	client = &fakeClient{err: status.Error(codes.Unavailable, "connection refused")}
+	_, err = Hello(context.Background(), client, "web-1", nil)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrIncompatible)
+}
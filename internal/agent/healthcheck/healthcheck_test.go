@@ -3,6 +3,7 @@ package healthcheck
 import (
 	"context"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,11 +20,11 @@ const bufSize = 1024 * 1024
 
 type mockHealthServer struct {
 	health.UnimplementedHealthServiceServer
-	serving bool
+	serving atomic.Bool
 }
 
 func (m *mockHealthServer) Check(ctx context.Context, req *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
-	if m.serving {
+	if m.serving.Load() {
 		return &health.HealthCheckResponse{
 			Status: health.HealthCheckResponse_SERVING,
 		}, nil
@@ -39,6 +40,10 @@ func (m *mockHealthServer) Watch(req *health.HealthCheckRequest, stream health.H
 		case <-stream.Context().Done():
 			return status.Error(codes.Canceled, "Stream canceled")
 		default:
+			// The server ends the stream when it stops serving
+			if !m.serving.Load() {
+				return status.Error(codes.Unavailable, "not serving")
+			}
 			if err := stream.Send(&health.HealthCheckResponse{
 				Status: health.HealthCheckResponse_SERVING,
 			}); err != nil {
@@ -52,7 +57,8 @@ func (m *mockHealthServer) Watch(req *health.HealthCheckRequest, stream health.H
 func setupTest(t *testing.T) (*grpc.ClientConn, *mockHealthServer) {
 	lis := bufconn.Listen(bufSize)
 	s := grpc.NewServer()
-	mockServer := &mockHealthServer{serving: true}
+	mockServer := &mockHealthServer{}
+	mockServer.serving.Store(true)
 	health.RegisterHealthServiceServer(s, mockServer)
 
 	go func() {
@@ -86,7 +92,7 @@ func TestHealthCheck(t *testing.T) {
 		Component: "test",
 	})
 
-	service := New(conn, logger.GetLogger())
+	service := New(conn, logger.GetLogger(), "test-host")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -105,7 +111,7 @@ func TestHealthCheck(t *testing.T) {
 	}
 
 	// Test unhealthy state
-	mockServer.serving = false
+	mockServer.serving.Store(false)
 	time.Sleep(200 * time.Millisecond)
 
 	if service.IsHealthy() {
@@ -123,7 +129,7 @@ func TestHealthCheckCancellation(t *testing.T) {
 		Component: "test",
 	})
 
-	service := New(conn, logger.GetLogger())
+	service := New(conn, logger.GetLogger(), "test-host")
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -11,4 +11,5 @@ type HostMetrics struct {
 	VirtualizationSystem string `json:"virtualization_system"`
 	VirtualizationRole   string `json:"virtualization_role"`
 	KernelVersion        string `json:"kernel_version"`
+	KernelArch           string `json:"kernel_arch"`
 }
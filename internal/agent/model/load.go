@@ -0,0 +1,17 @@
+package model
+
+type SystemLoadMetrics struct {
+	Load1    float64           `json:"load1"`
+	Load5    float64           `json:"load5"`
+	Load15   float64           `json:"load15"`
+	Pressure []PressureMetrics `json:"pressure"`
+}
+
+type PressureMetrics struct {
+	Resource          string  `json:"resource"`
+	Kind              string  `json:"kind"`
+	Avg10             float64 `json:"avg10"`
+	Avg60             float64 `json:"avg60"`
+	Avg300            float64 `json:"avg300"`
+	TotalMicroseconds uint64  `json:"total_microseconds"`
+}
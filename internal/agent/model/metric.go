@@ -3,11 +3,14 @@ package model
 import "time"
 
 type MetricsPayload struct {
-	Host      HostMetrics      `json:"host"`
-	CPU       []CPUMetrics     `json:"cpu"`
-	RAM       RamMetrics       `json:"ram"`
-	Disk      []DiskMetrics    `json:"disk"`
-	Network   []NetworkMetrics `json:"network"`
-	Docker    []DockerMetrics  `json:"docker"`
-	Timestamp time.Time        `json:"timestamp"`
+	Host         HostMetrics          `json:"host"`
+	CPU          []CPUMetrics         `json:"cpu"`
+	RAM          RamMetrics           `json:"ram"`
+	Disk         []DiskMetrics        `json:"disk"`
+	Network      []NetworkMetrics     `json:"network"`
+	Docker       []DockerMetrics      `json:"docker"`
+	Processes    []ProcessMetrics     `json:"processes"`
+	SystemdUnits []SystemdUnitMetrics `json:"systemd_units"`
+	Load         SystemLoadMetrics    `json:"load"`
+	Timestamp    time.Time            `json:"timestamp"`
 }
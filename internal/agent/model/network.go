@@ -8,4 +8,17 @@ type NetworkMetrics struct {
 	PacketsRecv   uint64 `json:"packets_recv"`
 	ErrIn         uint64 `json:"err_in"`
 	ErrOut        uint64 `json:"err_out"`
+	DropIn        uint64 `json:"drop_in"`
+	DropOut       uint64 `json:"drop_out"`
+	// Rates since the previous collection, 0 at the first one and after a counter reset
+	BytesSentPerSec   float64 `json:"bytes_sent_per_sec"`
+	BytesRecvPerSec   float64 `json:"bytes_recv_per_sec"`
+	PacketsSentPerSec float64 `json:"packets_sent_per_sec"`
+	PacketsRecvPerSec float64 `json:"packets_recv_per_sec"`
+	// Addresses in CIDR notation, e.g. 192.168.1.10/24
+	Addresses []string `json:"addresses"`
+	MTU       int      `json:"mtu"`
+	Up        bool     `json:"up"`
+	// SpeedMbps is 0 when the link speed is unknown, e.g. for virtual interfaces
+	SpeedMbps uint64 `json:"speed_mbps"`
 }
@@ -0,0 +1,13 @@
+package model
+
+type ProcessMetrics struct {
+	PID        int32   `json:"pid"`
+	Name       string  `json:"name"`
+	Cmdline    string  `json:"cmdline"`
+	Username   string  `json:"username"`
+	State      string  `json:"state"`
+	Threads    int32   `json:"threads"`
+	OpenFiles  int32   `json:"open_files"`
+	CPUPercent float64 `json:"cpu_percent"`
+	RSSBytes   uint64  `json:"rss_bytes"`
+}
@@ -0,0 +1,14 @@
+package model
+
+type SystemdUnitMetrics struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	LoadState    string  `json:"load_state"`
+	ActiveState  string  `json:"active_state"`
+	SubState     string  `json:"sub_state"`
+	Result       string  `json:"result"`
+	Restarts     uint32  `json:"restarts"`
+	LastExitCode int32   `json:"last_exit_code"`
+	MemoryBytes  uint64  `json:"memory_bytes"`
+	CPUSeconds   float64 `json:"cpu_seconds"`
+}
@@ -0,0 +1,148 @@
+// Package spool keeps the metrics payloads of the agent on disk until the server acknowledges
+// them, so the metrics collected while the server is unreachable are sent once it is back
+package spool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/agent/handshake"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"github.com/theotruvelot/g0s/pkg/version"
+	"github.com/theotruvelot/g0s/pkg/wal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// _batchesHeader is the header the server sends when a StreamMetricsBatches stream opens
+const _batchesHeader = "g0s-metrics-batches"
+
+// ErrNotAcknowledged is returned when the server answers a payload without acknowledging it
+var ErrNotAcknowledged = errors.New("payload not acknowledged")
+
+// Stream sends the spooled payloads to the server
+type Stream interface {
+	// Flush sends the spooled payloads not sent yet, in order, with their original timestamp.
+	// Payloads are deleted from the spool once the server acknowledges them.
+	Flush() (int, error)
+	// Close closes the stream, the payloads not acknowledged are sent again on the next one
+	Close() error
+}
+
+type Spool struct {
+	queue *wal.Queue
+	// replayAfter is the age after which a payload is flagged as replayed
+	replayAfter time.Duration
+	logger      *zap.Logger
+	now         func() time.Time
+	sequence    uint64
+}
+
+// Open opens the spool stored in dir, keeping at most maxBytes of payloads, the oldest being
+// dropped beyond. Payloads older than half the collection interval when sent are replayed ones.
+func Open(dir string, maxBytes int64, interval time.Duration, logger *zap.Logger) (*Spool, error) {
+	queue, err := wal.Open(dir, wal.Options{MaxBytes: maxBytes})
+	if err != nil {
+		return nil, fmt.Errorf("open spool: %w", err)
+	}
+	return &Spool{
+		queue:       queue,
+		replayAfter: interval / 2,
+		logger:      logger,
+		now:         time.Now,
+		// Sequences only have to increase, starting from the clock keeps them increasing across restarts
+		sequence: uint64(time.Now().UnixNano()),
+	}, nil
+}
+
+// Append gives the payload the next sequence and stores it
+func (s *Spool) Append(payload *pb.MetricsPayload) error {
+	s.sequence++
+	payload.Sequence = s.sequence
+
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+	if err := s.queue.Append(data); err != nil {
+		return fmt.Errorf("spool payload: %w", err)
+	}
+	return nil
+}
+
+// Connect opens a stream sending the spooled payloads to the server, in batches if both sides
+// support them and the server does not answer Unimplemented, one payload at a time otherwise
+func (s *Spool) Connect(ctx context.Context, client pb.MetricServiceClient, negotiated *handshake.Result) (Stream, error) {
+	s.queue.Rewind()
+	if !negotiated.Has(version.FeatureMetricsBatches) {
+		return s.connectLegacy(ctx, client)
+	}
+
+	var opts []grpc.CallOption
+	if negotiated.Has(version.FeatureGzip) {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+	batchesCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.StreamMetricsBatches(batchesCtx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The server sends a header when the stream opens, older servers end it right away
+	header, err := stream.Header()
+	if err == nil && len(header.Get(_batchesHeader)) == 0 {
+		_, err = stream.Recv()
+	}
+	if err == nil || status.Code(err) != codes.Unimplemented {
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		return newBatchStream(s, stream, cancel), nil
+	}
+	cancel()
+
+	s.logger.Info("Server does not support metrics batches, sending payloads one at a time")
+	return s.connectLegacy(ctx, client)
+}
+
+// connectLegacy opens a stream sending the spooled payloads one at a time
+func (s *Spool) connectLegacy(ctx context.Context, client pb.MetricServiceClient) (Stream, error) {
+	legacy, err := client.StreamMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &legacyStream{spool: s, stream: legacy}, nil
+}
+
+// replayed reports whether the payload was collected long enough ago to be a replayed one
+func (s *Spool) replayed(payload *pb.MetricsPayload) bool {
+	return s.now().Sub(payload.GetTimestamp().AsTime()) > s.replayAfter
+}
+
+// decode decodes a spooled payload, returning nil if it is unreadable
+func (s *Spool) decode(data []byte) *pb.MetricsPayload {
+	payload := &pb.MetricsPayload{}
+	if err := proto.Unmarshal(data, payload); err != nil {
+		s.logger.Error("Dropping unreadable spooled payload", zap.Error(err))
+		return nil
+	}
+	payload.Replayed = s.replayed(payload)
+	return payload
+}
+
+// PendingBytes returns the size of the payloads not acknowledged yet
+func (s *Spool) PendingBytes() int64 {
+	return s.queue.Stats().PendingBytes
+}
+
+// Close closes the spool, the payloads left are sent after the next start
+func (s *Spool) Close() error {
+	return s.queue.Close()
+}
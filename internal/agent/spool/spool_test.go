@@ -0,0 +1,275 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/agent/handshake"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeClient opens fake streams, batches ones unless legacy is set
+type fakeClient struct {
+	pb.MetricServiceClient
+	legacy  *fakeStream
+	batches *fakeBatchStream
+}
+
+func (c *fakeClient) StreamMetrics(ctx context.Context, _ ...grpc.CallOption) (pb.MetricService_StreamMetricsClient, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.legacy, nil
+}
+
+func (c *fakeClient) StreamMetricsBatches(context.Context, ...grpc.CallOption) (pb.MetricService_StreamMetricsBatchesClient, error) {
+	if c.batches == nil {
+		return &fakeBatchStream{unimplemented: true}, nil
+	}
+	return c.batches, nil
+}
+
+// fakeStream is a metrics stream acknowledging payloads until it fails
+type fakeStream struct {
+	grpc.ClientStream
+	sent    []*pb.MetricsPayload
+	failAt  int
+	pending *pb.MetricsResponse
+	// status is the status answered, "ok" if empty
+	status string
+	// noSequence answers without a sequence, as servers predating acknowledgments
+	noSequence bool
+}
+
+func (f *fakeStream) Send(payload *pb.MetricsPayload) error {
+	if f.failAt > 0 && len(f.sent) == f.failAt {
+		return errors.New("connection reset")
+	}
+	f.sent = append(f.sent, payload)
+	f.pending = &pb.MetricsResponse{Status: "ok", AckedSequence: payload.Sequence}
+	if f.status != "" {
+		f.pending = &pb.MetricsResponse{Status: f.status}
+	}
+	if f.noSequence {
+		f.pending.AckedSequence = 0
+	}
+	return nil
+}
+
+func (f *fakeStream) Recv() (*pb.MetricsResponse, error) {
+	return f.pending, nil
+}
+
+func (f *fakeStream) CloseSend() error { return nil }
+
+// fakeBatchStream is a batches stream whose acknowledgments are sent by the test
+type fakeBatchStream struct {
+	grpc.ClientStream
+	// unimplemented answers as servers predating batches
+	unimplemented bool
+	acks          chan *pb.MetricsAck
+
+	mu   sync.Mutex
+	sent []*pb.MetricsBatch
+}
+
+func newFakeBatchStream() *fakeBatchStream {
+	return &fakeBatchStream{acks: make(chan *pb.MetricsAck, 16)}
+}
+
+func (f *fakeBatchStream) Header() (metadata.MD, error) {
+	if f.unimplemented {
+		return metadata.MD{}, nil
+	}
+	return metadata.Pairs(_batchesHeader, "1"), nil
+}
+
+func (f *fakeBatchStream) Send(batch *pb.MetricsBatch) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, batch)
+	return nil
+}
+
+func (f *fakeBatchStream) Recv() (*pb.MetricsAck, error) {
+	if f.unimplemented {
+		return nil, status.Error(codes.Unimplemented, "unknown method StreamMetricsBatches")
+	}
+	ack, ok := <-f.acks
+	if !ok {
+		return nil, status.Error(codes.Canceled, "context canceled")
+	}
+	return ack, nil
+}
+
+func (f *fakeBatchStream) CloseSend() error {
+	close(f.acks)
+	return nil
+}
+
+func (f *fakeBatchStream) batches() []*pb.MetricsBatch {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*pb.MetricsBatch(nil), f.sent...)
+}
+
+func openSpool(t *testing.T, dir string) *Spool {
+	t.Helper()
+	s, err := Open(dir, 1<<20, time.Minute, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	return s
+}
+
+func connect(t *testing.T, s *Spool, client *fakeClient) Stream {
+	t.Helper()
+	stream, err := s.Connect(context.Background(), client, &handshake.Result{Features: handshake.Features})
+	require.NoError(t, err)
+	return stream
+}
+
+func payload(hostname string, at time.Time) *pb.MetricsPayload {
+	return &pb.MetricsPayload{Host: &pb.HostMetrics{Hostname: hostname}, Timestamp: timestamppb.New(at)}
+}
+
+func hostnames(payloads []*pb.MetricsPayload) []string {
+	var names []string
+	for _, p := range payloads {
+		names = append(names, p.Host.GetHostname())
+	}
+	return names
+}
+
+func TestSpool_ReplaysInOrderAfterOutage(t *testing.T) {
+	dir := t.TempDir()
+	s := openSpool(t, dir)
+
+	now := time.Now()
+	require.NoError(t, s.Append(payload("t1", now.Add(-3*time.Minute))))
+	require.NoError(t, s.Append(payload("t2", now.Add(-2*time.Minute))))
+
+	// The stream breaks after the first payload, the second stays spooled
+	legacy := &fakeStream{failAt: 1}
+	sent, err := connect(t, s, &fakeClient{legacy: legacy}).Flush()
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"t1"}, hostnames(legacy.sent))
+	require.NoError(t, s.Close())
+
+	// After a restart, the rest is replayed in order then the fresh payload is sent
+	s = openSpool(t, dir)
+	defer s.Close()
+	require.NoError(t, s.Append(payload("t3", now)))
+
+	legacy = &fakeStream{}
+	sent, err = connect(t, s, &fakeClient{legacy: legacy}).Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"t2", "t3"}, hostnames(legacy.sent))
+	assert.True(t, legacy.sent[0].Replayed)
+	assert.Equal(t, now.Add(-2*time.Minute).UnixNano(), legacy.sent[0].Timestamp.AsTime().UnixNano(), "original timestamp")
+	assert.False(t, legacy.sent[1].Replayed)
+	assert.Greater(t, legacy.sent[1].Sequence, legacy.sent[0].Sequence)
+	assert.Equal(t, int64(0), s.PendingBytes())
+}
+
+func TestSpool_KeepsUnacknowledgedPayloads(t *testing.T) {
+	s := openSpool(t, t.TempDir())
+	defer s.Close()
+	require.NoError(t, s.Append(payload("web-1", time.Now())))
+
+	sent, err := connect(t, s, &fakeClient{legacy: &fakeStream{status: "shutdown"}}).Flush()
+	assert.ErrorIs(t, err, ErrNotAcknowledged)
+	assert.Equal(t, 0, sent)
+	assert.Greater(t, s.PendingBytes(), int64(0))
+
+	sent, err = connect(t, s, &fakeClient{legacy: &fakeStream{noSequence: true}}).Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent, "servers without acknowledgments answer ok")
+	assert.Equal(t, int64(0), s.PendingBytes())
+}
+
+func TestSpool_SendsBatchesWithoutWaitingForAcknowledgments(t *testing.T) {
+	s := openSpool(t, t.TempDir())
+	defer s.Close()
+	for _, hostname := range []string{"t1", "t2", "t3"} {
+		require.NoError(t, s.Append(payload(hostname, time.Now())))
+	}
+
+	batches := newFakeBatchStream()
+	stream := connect(t, s, &fakeClient{batches: batches})
+	defer stream.Close()
+
+	sent, err := stream.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	require.Len(t, batches.batches(), 1)
+	first := batches.batches()[0].Payloads
+	assert.Equal(t, []string{"t1", "t2", "t3"}, hostnames(first))
+	assert.Greater(t, s.PendingBytes(), int64(0), "payloads are kept until acknowledged")
+
+	// Payloads sent but not acknowledged yet are not sent again
+	require.NoError(t, s.Append(payload("t4", time.Now())))
+	sent, err = stream.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, batches.batches(), 2)
+
+	batches.acks <- &pb.MetricsAck{Status: "ok", FirstSequence: first[0].Sequence, LastSequence: first[2].Sequence}
+	batches.acks <- &pb.MetricsAck{Status: "ok", LastSequence: batches.batches()[1].Payloads[0].Sequence}
+	assert.Eventually(t, func() bool { return s.PendingBytes() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestSpool_ResendsUnacknowledgedBatches(t *testing.T) {
+	s := openSpool(t, t.TempDir())
+	defer s.Close()
+	require.NoError(t, s.Append(payload("t1", time.Now())))
+	require.NoError(t, s.Append(payload("t2", time.Now())))
+
+	batches := newFakeBatchStream()
+	stream := connect(t, s, &fakeClient{batches: batches})
+	_, err := stream.Flush()
+	require.NoError(t, err)
+	batches.acks <- &pb.MetricsAck{Status: "shutdown", Message: "server is shutting down"}
+	assert.Eventually(t, func() bool {
+		_, err := stream.Flush()
+		return errors.Is(err, ErrNotAcknowledged)
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, stream.Close())
+
+	batches = newFakeBatchStream()
+	stream = connect(t, s, &fakeClient{batches: batches})
+	defer stream.Close()
+	sent, err := stream.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"t1", "t2"}, hostnames(batches.batches()[0].Payloads))
+}
+
+func TestSpool_SendsOneAtATimeWithoutNegotiatedBatches(t *testing.T) {
+	s := openSpool(t, t.TempDir())
+	defer s.Close()
+	require.NoError(t, s.Append(payload("t1", time.Now())))
+
+	legacy := &fakeStream{}
+	batches := newFakeBatchStream()
+	stream, err := s.Connect(context.Background(), &fakeClient{legacy: legacy, batches: batches}, &handshake.Result{})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	sent, err := stream.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"t1"}, hostnames(legacy.sent))
+	assert.Empty(t, batches.batches())
+}
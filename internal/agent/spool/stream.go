@@ -0,0 +1,237 @@
+package spool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// _maxBatchPayloads and _maxBatchBytes bound a batch, well under the 4MB message limit of gRPC
+	_maxBatchPayloads = 64
+	_maxBatchBytes    = 1 << 20
+	// _maxBatchesInFlight is the number of batches sent without waiting for their acknowledgment
+	_maxBatchesInFlight = 8
+)
+
+// legacyStream sends one payload at a time, waiting for its acknowledgment before the next
+type legacyStream struct {
+	spool  *Spool
+	stream pb.MetricService_StreamMetricsClient
+}
+
+func (l *legacyStream) Flush() (int, error) {
+	for sent := 0; ; sent++ {
+		data, ok, err := l.spool.queue.Peek()
+		if err != nil {
+			return sent, fmt.Errorf("read spool: %w", err)
+		}
+		if !ok {
+			return sent, nil
+		}
+
+		payload := l.spool.decode(data)
+		if payload == nil {
+			if err := l.spool.queue.Ack(); err != nil {
+				return sent, fmt.Errorf("delete spooled payload: %w", err)
+			}
+			continue
+		}
+
+		if err := l.stream.Send(payload); err != nil {
+			return sent, fmt.Errorf("failed to send metrics: %w", err)
+		}
+		resp, err := l.stream.Recv()
+		if err != nil {
+			return sent, fmt.Errorf("failed to receive acknowledgment: %w", err)
+		}
+		// Servers predating acknowledgments answer "ok" without a sequence
+		acked := resp.GetAckedSequence() == payload.Sequence || (resp.GetAckedSequence() == 0 && resp.GetStatus() == "ok")
+		if !acked {
+			return sent, fmt.Errorf("%w: %s: %s", ErrNotAcknowledged, resp.GetStatus(), resp.GetMessage())
+		}
+		if err := l.spool.queue.Ack(); err != nil {
+			return sent, fmt.Errorf("delete spooled payload: %w", err)
+		}
+	}
+}
+
+func (l *legacyStream) Close() error {
+	return l.stream.CloseSend()
+}
+
+// batch is a batch in flight, or unreadable payloads to delete along the batches before them
+type batch struct {
+	lastSequence uint64
+	// records is the number of spooled records of the batch, unreadable ones included
+	records int
+}
+
+// batchStream sends batches of payloads without waiting for their acknowledgment, up to
+// _maxBatchesInFlight, the acknowledgments being received in the background
+type batchStream struct {
+	spool  *Spool
+	stream pb.MetricService_StreamMetricsBatchesClient
+	cancel context.CancelFunc
+	// slots holds a value per batch in flight
+	slots chan struct{}
+	done  chan struct{}
+
+	mu       sync.Mutex
+	inflight []batch
+	err      error
+}
+
+func newBatchStream(s *Spool, stream pb.MetricService_StreamMetricsBatchesClient, cancel context.CancelFunc) *batchStream {
+	b := &batchStream{
+		spool:  s,
+		stream: stream,
+		cancel: cancel,
+		slots:  make(chan struct{}, _maxBatchesInFlight),
+		done:   make(chan struct{}),
+	}
+	go b.receive()
+	return b
+}
+
+func (b *batchStream) Flush() (int, error) {
+	sent := 0
+	for {
+		if err := b.failure(); err != nil {
+			return sent, err
+		}
+
+		payloads, records, err := b.next()
+		if err != nil {
+			return sent, err
+		}
+		if records == 0 {
+			return sent, nil
+		}
+		if len(payloads) == 0 {
+			// Only unreadable payloads, deleted along the batches before them
+			if err := b.add(batch{records: records}); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		select {
+		case b.slots <- struct{}{}:
+		case <-b.done:
+			return sent, b.failure()
+		}
+		if err := b.add(batch{lastSequence: payloads[len(payloads)-1].Sequence, records: records}); err != nil {
+			return sent, err
+		}
+		if err := b.stream.Send(&pb.MetricsBatch{Payloads: payloads}); err != nil {
+			return sent, fmt.Errorf("failed to send metrics: %w", err)
+		}
+		sent += len(payloads)
+	}
+}
+
+// next reads the payloads of the next batch from the spool, and the number of records read
+func (b *batchStream) next() ([]*pb.MetricsPayload, int, error) {
+	var payloads []*pb.MetricsPayload
+	var records, size int
+	for len(payloads) < _maxBatchPayloads && size < _maxBatchBytes {
+		data, ok, err := b.spool.queue.Next()
+		if err != nil {
+			return nil, 0, fmt.Errorf("read spool: %w", err)
+		}
+		if !ok {
+			break
+		}
+		records++
+		if payload := b.spool.decode(data); payload != nil {
+			payloads = append(payloads, payload)
+			size += proto.Size(payload)
+		}
+	}
+	return payloads, records, nil
+}
+
+// add records a batch in flight, deleting it right away if it has no payload and nothing is in flight
+func (b *batchStream) add(sent batch) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sent.lastSequence == 0 {
+		if len(b.inflight) > 0 {
+			b.inflight[len(b.inflight)-1].records += sent.records
+			return nil
+		}
+		return b.delete(sent.records)
+	}
+	b.inflight = append(b.inflight, sent)
+	return nil
+}
+
+// receive processes the acknowledgments until the stream ends
+func (b *batchStream) receive() {
+	defer close(b.done)
+	for {
+		ack, err := b.stream.Recv()
+		if err != nil {
+			b.fail(fmt.Errorf("failed to receive acknowledgment: %w", err))
+			return
+		}
+		if ack.GetStatus() != "ok" {
+			b.fail(fmt.Errorf("%w: %s: %s", ErrNotAcknowledged, ack.GetStatus(), ack.GetMessage()))
+			return
+		}
+		if err := b.acknowledge(ack.GetLastSequence()); err != nil {
+			b.fail(err)
+			return
+		}
+	}
+}
+
+// acknowledge deletes the batches in flight up to the sequence from the spool
+func (b *batchStream) acknowledge(sequence uint64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.inflight) > 0 && b.inflight[0].lastSequence <= sequence {
+		if err := b.delete(b.inflight[0].records); err != nil {
+			return err
+		}
+		b.inflight = b.inflight[1:]
+		<-b.slots
+	}
+	return nil
+}
+
+func (b *batchStream) delete(records int) error {
+	for i := 0; i < records; i++ {
+		if err := b.spool.queue.Ack(); err != nil {
+			return fmt.Errorf("delete spooled payload: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *batchStream) fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *batchStream) failure() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+func (b *batchStream) Close() error {
+	err := b.stream.CloseSend()
+	b.cancel()
+	<-b.done
+	return err
+}
@@ -0,0 +1,322 @@
+// Package systemd reads the state of the systemd units of the host and starts, stops or
+// restarts them, through the systemd manager on the system bus.
+package systemd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	_destination         = "org.freedesktop.systemd1"
+	_managerPath         = dbus.ObjectPath("/org/freedesktop/systemd1")
+	_managerInterface    = "org.freedesktop.systemd1.Manager"
+	_unitInterface       = "org.freedesktop.systemd1.Unit"
+	_serviceInterface    = "org.freedesktop.systemd1.Service"
+	_jobInterface        = "org.freedesktop.systemd1.Job"
+	_propertiesInterface = "org.freedesktop.DBus.Properties"
+
+	_errNoSuchUnit    = "org.freedesktop.systemd1.NoSuchUnit"
+	_errUnknownObject = "org.freedesktop.DBus.Error.UnknownObject"
+
+	_jobPollInterval = 100 * time.Millisecond
+)
+
+// ErrNoSuchUnit is returned for operations on units systemd does not know
+var ErrNoSuchUnit = errors.New("no such unit")
+
+// Operation is an operation on a unit
+type Operation string
+
+const (
+	Start   Operation = "start"
+	Stop    Operation = "stop"
+	Restart Operation = "restart"
+)
+
+var _operationMethods = map[Operation]string{
+	Start:   "StartUnit",
+	Stop:    "StopUnit",
+	Restart: "RestartUnit",
+}
+
+// Bus is a connection to the system bus. Call returns the body of the reply, or a dbus.Error
+// for the errors of the remote object.
+type Bus interface {
+	Call(ctx context.Context, dest string, path dbus.ObjectPath, iface, method string, args ...any) ([]any, error)
+	Close() error
+}
+
+// systemBus is a private connection to the system bus
+type systemBus struct {
+	conn *dbus.Conn
+}
+
+func (b *systemBus) Call(ctx context.Context, dest string, path dbus.ObjectPath, iface, method string, args ...any) ([]any, error) {
+	call := b.conn.Object(dest, path).CallWithContext(ctx, iface+"."+method, 0, args...)
+	return call.Body, call.Err
+}
+
+func (b *systemBus) Close() error {
+	return b.conn.Close()
+}
+
+// unitStatus is a unit as listed by ListUnits
+type unitStatus struct {
+	Name        string
+	Description string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	Followed    string
+	Path        dbus.ObjectPath
+	JobID       uint32
+	JobType     string
+	JobPath     dbus.ObjectPath
+}
+
+// Unit is a unit loaded by systemd, see systemctl list-units
+type Unit struct {
+	Name        string
+	Description string
+	// LoadState is e.g. loaded or not-found, ActiveState active, inactive or failed, and
+	// SubState the state of the unit type, e.g. running or exited for a service
+	LoadState   string
+	ActiveState string
+	SubState    string
+	path        dbus.ObjectPath
+}
+
+// Service is the state of the main process of a service unit and where its processes are
+type Service struct {
+	// Result is why the service last stopped or failed, e.g. success, exit-code, signal or timeout
+	Result string
+	// Restarts is how many times systemd restarted the service since it was started
+	Restarts uint32
+	// ExecMainCode is the CLD_* code of the last exit of the main process and ExecMainStatus
+	// its exit status, or the signal that killed it
+	ExecMainCode   int32
+	ExecMainStatus int32
+	// ControlGroup is the path of the cgroup of the service, empty when it is not running
+	ControlGroup string
+	// MemoryCurrent and CPUUsageNSec are the accounting of systemd, math.MaxUint64 when it is disabled
+	MemoryCurrent uint64
+	CPUUsageNSec  uint64
+}
+
+// Client calls the systemd manager. It connects to the bus on the first call, and again
+// after the connection broke.
+type Client struct {
+	dial func() (Bus, error)
+	bus  Bus
+	lock sync.Mutex
+	// pollInterval is how often a job is checked while waiting for it
+	pollInterval time.Duration
+}
+
+func NewClient(dial func() (Bus, error)) *Client {
+	return &Client{dial: dial, pollInterval: _jobPollInterval}
+}
+
+// NewSystemClient returns a client of the systemd manager on the system bus
+func NewSystemClient() *Client {
+	return NewClient(func() (Bus, error) {
+		conn, err := dbus.ConnectSystemBus()
+		if err != nil {
+			return nil, err
+		}
+		return &systemBus{conn: conn}, nil
+	})
+}
+
+// Booted reports whether systemd manages the host, as sd_booted(3)
+func Booted() bool {
+	info, err := os.Lstat("/run/systemd/system")
+	return err == nil && info.IsDir()
+}
+
+// Close closes the connection to the bus
+func (c *Client) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.bus == nil {
+		return nil
+	}
+	err := c.bus.Close()
+	c.bus = nil
+	return err
+}
+
+// call calls a method of systemd, dropping the connection on any error but those of systemd
+func (c *Client) call(ctx context.Context, path dbus.ObjectPath, iface, method string, args ...any) ([]any, error) {
+	c.lock.Lock()
+	if c.bus == nil {
+		bus, err := c.dial()
+		if err != nil {
+			c.lock.Unlock()
+			return nil, err
+		}
+		c.bus = bus
+	}
+	bus := c.bus
+	c.lock.Unlock()
+
+	values, err := bus.Call(ctx, _destination, path, iface, method, args...)
+	var remote dbus.Error
+	if err != nil && !errors.As(err, &remote) {
+		c.lock.Lock()
+		if c.bus == bus {
+			_ = bus.Close()
+			c.bus = nil
+		}
+		c.lock.Unlock()
+	}
+	return values, err
+}
+
+// properties returns the properties of an interface of the object at path
+func (c *Client) properties(ctx context.Context, path dbus.ObjectPath, iface string) (map[string]dbus.Variant, error) {
+	values, err := c.call(ctx, path, _propertiesInterface, "GetAll", iface)
+	if err != nil {
+		return nil, err
+	}
+	var props map[string]dbus.Variant
+	if err := dbus.Store(values, &props); err != nil {
+		return nil, fmt.Errorf("unexpected answer to GetAll: %w", err)
+	}
+	return props, nil
+}
+
+// property returns the value of a property, or the zero value when it is missing or of another type
+func property[T any](props map[string]dbus.Variant, name string) T {
+	value, _ := props[name].Value().(T)
+	return value
+}
+
+// ListUnits returns the units loaded by systemd
+func (c *Client) ListUnits(ctx context.Context) ([]Unit, error) {
+	values, err := c.call(ctx, _managerPath, _managerInterface, "ListUnits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list units: %w", err)
+	}
+	var rows []unitStatus
+	if err := dbus.Store(values, &rows); err != nil {
+		return nil, fmt.Errorf("unexpected answer to ListUnits: %w", err)
+	}
+
+	units := make([]Unit, 0, len(rows))
+	for _, row := range rows {
+		units = append(units, Unit{
+			Name:        row.Name,
+			Description: row.Description,
+			LoadState:   row.LoadState,
+			ActiveState: row.ActiveState,
+			SubState:    row.SubState,
+			path:        row.Path,
+		})
+	}
+	return units, nil
+}
+
+// Service returns the state of a service unit listed by ListUnits
+func (c *Client) Service(ctx context.Context, unit Unit) (Service, error) {
+	props, err := c.properties(ctx, unit.path, _serviceInterface)
+	if err != nil {
+		return Service{}, fmt.Errorf("failed to read service %s: %w", unit.Name, err)
+	}
+	return Service{
+		Result:         property[string](props, "Result"),
+		Restarts:       property[uint32](props, "NRestarts"),
+		ExecMainCode:   property[int32](props, "ExecMainCode"),
+		ExecMainStatus: property[int32](props, "ExecMainStatus"),
+		ControlGroup:   property[string](props, "ControlGroup"),
+		MemoryCurrent:  property[uint64](props, "MemoryCurrent"),
+		CPUUsageNSec:   property[uint64](props, "CPUUsageNSec"),
+	}, nil
+}
+
+// Unit returns the state of a unit, loading it if needed
+func (c *Client) Unit(ctx context.Context, name string) (Unit, error) {
+	values, err := c.call(ctx, _managerPath, _managerInterface, "LoadUnit", name)
+	if err != nil {
+		return Unit{}, unitError(name, err)
+	}
+	var path dbus.ObjectPath
+	if err := dbus.Store(values, &path); err != nil {
+		return Unit{}, fmt.Errorf("unexpected answer to LoadUnit: %w", err)
+	}
+	props, err := c.properties(ctx, path, _unitInterface)
+	if err != nil {
+		return Unit{}, fmt.Errorf("failed to read unit %s: %w", name, err)
+	}
+	return Unit{
+		Name:        name,
+		Description: property[string](props, "Description"),
+		LoadState:   property[string](props, "LoadState"),
+		ActiveState: property[string](props, "ActiveState"),
+		SubState:    property[string](props, "SubState"),
+		path:        path,
+	}, nil
+}
+
+// Control queues the operation on the unit, waits up to wait for systemd to carry it out and
+// returns the state of the unit then. A unit still changing state when wait is over is
+// returned as is, e.g. activating.
+func (c *Client) Control(ctx context.Context, name string, op Operation, wait time.Duration) (Unit, error) {
+	method, ok := _operationMethods[op]
+	if !ok {
+		return Unit{}, fmt.Errorf("unsupported operation %q", op)
+	}
+	values, err := c.call(ctx, _managerPath, _managerInterface, method, name, "replace")
+	if err != nil {
+		return Unit{}, unitError(name, err)
+	}
+	var job dbus.ObjectPath
+	if err := dbus.Store(values, &job); err != nil {
+		return Unit{}, fmt.Errorf("unexpected answer to %s: %w", method, err)
+	}
+
+	if err := c.waitJob(ctx, job, wait); err != nil {
+		return Unit{}, err
+	}
+	return c.Unit(ctx, name)
+}
+
+// waitJob waits until the job is over, i.e. systemd removed it, or wait is over. The calls are
+// never interrupted in the middle, which would break the connection.
+func (c *Client) waitJob(ctx context.Context, job dbus.ObjectPath, wait time.Duration) error {
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	for {
+		_, err := c.call(ctx, job, _propertiesInterface, "Get", _jobInterface, "State")
+		var remote dbus.Error
+		if errors.As(err, &remote) && remote.Name == _errUnknownObject {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read job %s: %w", job, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return nil
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+func unitError(name string, err error) error {
+	var remote dbus.Error
+	if errors.As(err, &remote) && remote.Name == _errNoSuchUnit {
+		return fmt.Errorf("%w %s", ErrNoSuchUnit, name)
+	}
+	return fmt.Errorf("unit %s: %w", name, err)
+}
@@ -0,0 +1,192 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBus answers the calls with answer and records them as "<path> <interface>.<method>"
+type fakeBus struct {
+	answer func(path dbus.ObjectPath, iface, method string, args []any) ([]any, error)
+	calls  []string
+	closed bool
+}
+
+func (f *fakeBus) Call(_ context.Context, dest string, path dbus.ObjectPath, iface, method string, args ...any) ([]any, error) {
+	if dest != _destination {
+		return nil, dbus.Error{Name: "org.freedesktop.DBus.Error.ServiceUnknown"}
+	}
+	f.calls = append(f.calls, string(path)+" "+iface+"."+method)
+	return f.answer(path, iface, method, args)
+}
+
+func (f *fakeBus) Close() error {
+	f.closed = true
+	return nil
+}
+
+func newTestClient(bus *fakeBus) *Client {
+	c := NewClient(func() (Bus, error) { return bus, nil })
+	c.pollInterval = time.Millisecond
+	return c
+}
+
+func variants(props map[string]any) map[string]dbus.Variant {
+	m := make(map[string]dbus.Variant, len(props))
+	for name, value := range props {
+		m[name] = dbus.MakeVariant(value)
+	}
+	return m
+}
+
+func TestClient_ListUnits(t *testing.T) {
+	bus := &fakeBus{answer: func(dbus.ObjectPath, string, string, []any) ([]any, error) {
+		return []any{[][]any{
+			{"nginx.service", "A high performance web server", "loaded", "active", "running", "", dbus.ObjectPath("/org/freedesktop/systemd1/unit/nginx_2eservice"), uint32(0), "", dbus.ObjectPath("/")},
+			{"backup.service", "Nightly backup", "loaded", "failed", "failed", "", dbus.ObjectPath("/org/freedesktop/systemd1/unit/backup_2eservice"), uint32(0), "", dbus.ObjectPath("/")},
+		}}, nil
+	}}
+	c := newTestClient(bus)
+
+	units, err := c.ListUnits(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Unit{
+		{Name: "nginx.service", Description: "A high performance web server", LoadState: "loaded", ActiveState: "active", SubState: "running", path: "/org/freedesktop/systemd1/unit/nginx_2eservice"},
+		{Name: "backup.service", Description: "Nightly backup", LoadState: "loaded", ActiveState: "failed", SubState: "failed", path: "/org/freedesktop/systemd1/unit/backup_2eservice"},
+	}, units)
+	assert.Equal(t, []string{"/org/freedesktop/systemd1 org.freedesktop.systemd1.Manager.ListUnits"}, bus.calls)
+}
+
+func TestClient_Service(t *testing.T) {
+	bus := &fakeBus{answer: func(path dbus.ObjectPath, iface, method string, args []any) ([]any, error) {
+		assert.Equal(t, []any{_serviceInterface}, args)
+		return []any{variants(map[string]any{
+			"Result":         "exit-code",
+			"NRestarts":      uint32(3),
+			"ExecMainCode":   int32(1),
+			"ExecMainStatus": int32(203),
+			"ControlGroup":   "/system.slice/backup.service",
+			"MemoryCurrent":  uint64(1 << 20),
+			"CPUUsageNSec":   uint64(2e9),
+			"Type":           "oneshot",
+		})}, nil
+	}}
+	c := newTestClient(bus)
+
+	service, err := c.Service(context.Background(), Unit{Name: "backup.service", path: "/org/freedesktop/systemd1/unit/backup_2eservice"})
+	require.NoError(t, err)
+	assert.Equal(t, Service{
+		Result:         "exit-code",
+		Restarts:       3,
+		ExecMainCode:   1,
+		ExecMainStatus: 203,
+		ControlGroup:   "/system.slice/backup.service",
+		MemoryCurrent:  1 << 20,
+		CPUUsageNSec:   2e9,
+	}, service)
+	assert.Equal(t, []string{"/org/freedesktop/systemd1/unit/backup_2eservice org.freedesktop.DBus.Properties.GetAll"}, bus.calls)
+}
+
+func TestClient_Control(t *testing.T) {
+	jobPolls := 0
+	bus := &fakeBus{answer: func(path dbus.ObjectPath, iface, method string, args []any) ([]any, error) {
+		switch method {
+		case "RestartUnit":
+			assert.Equal(t, []any{"nginx.service", "replace"}, args)
+			return []any{dbus.ObjectPath("/org/freedesktop/systemd1/job/42")}, nil
+		case "Get":
+			// The job runs for two polls, then systemd removes it
+			jobPolls++
+			if jobPolls > 2 {
+				return nil, dbus.Error{Name: _errUnknownObject}
+			}
+			return []any{dbus.MakeVariant("running")}, nil
+		case "LoadUnit":
+			return []any{dbus.ObjectPath("/org/freedesktop/systemd1/unit/nginx_2eservice")}, nil
+		default:
+			return []any{variants(map[string]any{"LoadState": "loaded", "ActiveState": "active", "SubState": "running"})}, nil
+		}
+	}}
+	c := newTestClient(bus)
+
+	unit, err := c.Control(context.Background(), "nginx.service", Restart, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "active", unit.ActiveState)
+	assert.Equal(t, "running", unit.SubState)
+	assert.Equal(t, []string{
+		"/org/freedesktop/systemd1 org.freedesktop.systemd1.Manager.RestartUnit",
+		"/org/freedesktop/systemd1/job/42 org.freedesktop.DBus.Properties.Get",
+		"/org/freedesktop/systemd1/job/42 org.freedesktop.DBus.Properties.Get",
+		"/org/freedesktop/systemd1/job/42 org.freedesktop.DBus.Properties.Get",
+		"/org/freedesktop/systemd1 org.freedesktop.systemd1.Manager.LoadUnit",
+		"/org/freedesktop/systemd1/unit/nginx_2eservice org.freedesktop.DBus.Properties.GetAll",
+	}, bus.calls)
+}
+
+func TestClient_ControlJobStillRunning(t *testing.T) {
+	bus := &fakeBus{answer: func(path dbus.ObjectPath, iface, method string, args []any) ([]any, error) {
+		switch method {
+		case "StartUnit":
+			return []any{dbus.ObjectPath("/org/freedesktop/systemd1/job/43")}, nil
+		case "Get":
+			return []any{dbus.MakeVariant("running")}, nil
+		case "LoadUnit":
+			return []any{dbus.ObjectPath("/org/freedesktop/systemd1/unit/slow_2eservice")}, nil
+		default:
+			return []any{variants(map[string]any{"LoadState": "loaded", "ActiveState": "activating", "SubState": "start"})}, nil
+		}
+	}}
+	c := newTestClient(bus)
+
+	unit, err := c.Control(context.Background(), "slow.service", Start, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "activating", unit.ActiveState, "the state is returned once the wait is over")
+}
+
+func TestClient_ControlErrors(t *testing.T) {
+	bus := &fakeBus{answer: func(path dbus.ObjectPath, iface, method string, args []any) ([]any, error) {
+		return nil, dbus.Error{Name: _errNoSuchUnit, Body: []any{"Unit redis.service not found."}}
+	}}
+	c := newTestClient(bus)
+
+	_, err := c.Control(context.Background(), "redis.service", Stop, time.Second)
+	assert.ErrorIs(t, err, ErrNoSuchUnit)
+	assert.False(t, bus.closed, "errors of systemd keep the connection")
+
+	_, err = c.Control(context.Background(), "redis.service", "reload", time.Second)
+	assert.Error(t, err)
+}
+
+func TestClient_Reconnects(t *testing.T) {
+	broken := &fakeBus{answer: func(dbus.ObjectPath, string, string, []any) ([]any, error) {
+		return nil, dbus.ErrClosed
+	}}
+	working := &fakeBus{answer: func(dbus.ObjectPath, string, string, []any) ([]any, error) {
+		return []any{[][]any(nil)}, nil
+	}}
+	buses := []*fakeBus{broken, working}
+	c := NewClient(func() (Bus, error) {
+		if len(buses) == 0 {
+			return nil, errors.New("no bus")
+		}
+		bus := buses[0]
+		buses = buses[1:]
+		return bus, nil
+	})
+
+	_, err := c.ListUnits(context.Background())
+	assert.ErrorIs(t, err, dbus.ErrClosed)
+	assert.True(t, broken.closed)
+
+	units, err := c.ListUnits(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, units)
+	require.NoError(t, c.Close())
+	assert.True(t, working.closed)
+}
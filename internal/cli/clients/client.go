@@ -1,29 +1,88 @@
 package clients
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/theotruvelot/g0s/internal/cli/config"
+	"github.com/theotruvelot/g0s/pkg/proto/admin"
+	"github.com/theotruvelot/g0s/pkg/proto/alert"
 	"github.com/theotruvelot/g0s/pkg/proto/auth"
+	"github.com/theotruvelot/g0s/pkg/proto/control"
 	"github.com/theotruvelot/g0s/pkg/proto/health"
+	"github.com/theotruvelot/g0s/pkg/proto/host"
+	"github.com/theotruvelot/g0s/pkg/proto/metric"
+	"github.com/theotruvelot/g0s/pkg/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Clients struct {
+	AdminClient       admin.AdminServiceClient
+	AlertClient       alert.AlertServiceClient
 	AuthClient        auth.AuthServiceClient
+	ControlClient     control.ControlServiceClient
 	HealthcheckClient health.HealthServiceClient
+	HostClient        host.HostServiceClient
+	MetricClient      metric.MetricServiceClient
 	conn              *grpc.ClientConn
+
+	mu      sync.RWMutex
+	session *session
 }
 
-func NewClients(serverAddr string) (*Clients, error) {
-	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewClients(serverAddr string, tlsCfg config.TLSConfig) (*Clients, error) {
+	creds, err := transportCredentials(tlsCfg)
+	if err != nil {
+		return nil, err
+	}
 
+	c := &Clients{}
+	conn, err := grpc.NewClient(serverAddr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithStreamInterceptor(c.streamInterceptor),
+	)
 	if err != nil {
 		return nil, err
 	}
-	return &Clients{
-		AuthClient:        auth.NewAuthServiceClient(conn),
-		HealthcheckClient: health.NewHealthServiceClient(conn),
-		conn:              conn,
-	}, nil
+
+	c.AdminClient = admin.NewAdminServiceClient(conn)
+	c.AlertClient = alert.NewAlertServiceClient(conn)
+	c.AuthClient = auth.NewAuthServiceClient(conn)
+	c.ControlClient = control.NewControlServiceClient(conn)
+	c.HealthcheckClient = health.NewHealthServiceClient(conn)
+	c.HostClient = host.NewHostServiceClient(conn)
+	c.MetricClient = metric.NewMetricServiceClient(conn)
+	c.conn = conn
+	return c, nil
+}
+
+// Authenticate attaches the tokens of cfg to every call. Expired tokens are renewed
+// with the refresh token and written back to the config file.
+func (c *Clients) Authenticate(cfg *config.Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = newSession(cfg, c.AuthClient)
+}
+
+func (c *Clients) currentSession() *session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+func transportCredentials(tlsCfg config.TLSConfig) (credentials.TransportCredentials, error) {
+	if !tlsCfg.IsEnabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg, err := utils.LoadClientTLSConfig(tlsCfg.CAFile, tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS configuration: %w", err)
+	}
+	return credentials.NewTLS(cfg), nil
 }
 
 func (c *Clients) Close() error {
@@ -0,0 +1,141 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/theotruvelot/g0s/internal/cli/config"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"github.com/theotruvelot/g0s/pkg/proto/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// _refreshMargin renews the access token slightly before it expires so a call
+// doesn't fail because of a token expiring on the way to the server
+const _refreshMargin = time.Minute
+
+// _authServicePrefix is the prefix of the methods that must never trigger a refresh
+const _authServicePrefix = "/auth.AuthService/"
+
+// session holds the tokens of the logged in user and renews them when they expire
+type session struct {
+	mu     sync.Mutex
+	cfg    *config.Config
+	client auth.AuthServiceClient
+	// save persists the renewed tokens, config.SaveConfig outside of tests
+	save func(*config.Config) error
+}
+
+func newSession(cfg *config.Config, client auth.AuthServiceClient) *session {
+	return &session{
+		cfg:    cfg,
+		client: client,
+		save:   config.SaveConfig,
+	}
+}
+
+// accessToken returns the current access token, renewing it first if it is about to expire
+func (s *session) accessToken(ctx context.Context) string {
+	s.mu.Lock()
+	token := s.cfg.JWTToken
+	s.mu.Unlock()
+
+	if !expiresSoon(token) {
+		return token
+	}
+	if err := s.refresh(ctx, token); err != nil {
+		logger.Warn("Failed to renew the access token", zap.Error(err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg.JWTToken
+}
+
+// refresh exchanges the refresh token for a new token pair, unless another call
+// already renewed the rejected token in the meantime
+func (s *session) refresh(ctx context.Context, rejected string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cfg.JWTToken != rejected {
+		return nil
+	}
+	if s.cfg.RefreshToken == "" {
+		return fmt.Errorf("no refresh token, please log in again")
+	}
+
+	res, err := s.client.RefreshToken(ctx, &auth.RefreshTokenRequest{JwtRefreshToken: s.cfg.RefreshToken})
+	if err != nil {
+		return fmt.Errorf("could not refresh token: %w", err)
+	}
+	if res.GetStatus() != auth.RefreshTokenResponse_OK {
+		return fmt.Errorf("failed to refresh token: %s, please log in again", res.GetStatus().String())
+	}
+
+	s.cfg.JWTToken = res.GetJwtToken()
+	s.cfg.RefreshToken = res.GetJwtRefreshToken()
+	logger.Info("Access token renewed", zap.String("username", s.cfg.Username))
+
+	// The refresh token we just used is no longer valid, losing the new one means logging in again
+	if err := s.save(s.cfg); err != nil {
+		logger.Error("Failed to save renewed tokens", zap.Error(err))
+	}
+	return nil
+}
+
+// expiresSoon reports whether the token expires within _refreshMargin. The signature is
+// not checked, the server does it: this only avoids sending a token known to be expired.
+func expiresSoon(token string) bool {
+	claims := &jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil || claims.ExpiresAt == nil {
+		return false
+	}
+	return time.Until(claims.ExpiresAt.Time) < _refreshMargin
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// unaryInterceptor attaches the access token to the call, and renews it then retries
+// once when the server rejects it
+func (c *Clients) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	s := c.currentSession()
+	if s == nil || strings.HasPrefix(method, _authServicePrefix) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	token := s.accessToken(ctx)
+	err := invoker(withToken(ctx, token), method, req, reply, cc, opts...)
+	if status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+
+	if refreshErr := s.refresh(ctx, token); refreshErr != nil {
+		logger.Warn("Failed to renew the access token", zap.Error(refreshErr))
+		return err
+	}
+	return invoker(withToken(ctx, s.accessToken(ctx)), method, req, reply, cc, opts...)
+}
+
+// streamInterceptor attaches the access token to the stream. Authentication errors of a
+// stream are only known once it is read, so tokens are renewed ahead of their expiry instead.
+func (c *Clients) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	s := c.currentSession()
+	if s == nil || strings.HasPrefix(method, _authServicePrefix) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	return streamer(withToken(ctx, s.accessToken(ctx)), desc, cc, method, opts...)
+}
@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/cli/config"
+	"github.com/theotruvelot/g0s/pkg/proto/auth"
+	"github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServer accepts the "Bearer valid" access token and rotates "refresh" into it once
+type fakeServer struct {
+	auth.UnimplementedAuthServiceServer
+	metric.UnimplementedMetricServiceServer
+
+	mu        sync.Mutex
+	refreshes int
+	used      bool
+}
+
+func (s *fakeServer) RefreshToken(_ context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshes++
+
+	if req.JwtRefreshToken != "refresh" || s.used {
+		return &auth.RefreshTokenResponse{Status: auth.RefreshTokenResponse_INVALID_REFRESH_TOKEN}, nil
+	}
+	s.used = true
+	return &auth.RefreshTokenResponse{
+		Status:          auth.RefreshTokenResponse_OK,
+		JwtToken:        "valid",
+		JwtRefreshToken: "refresh-2",
+	}, nil
+}
+
+func (s *fakeServer) GetMetrics(ctx context.Context, _ *metric.MetricsRequest) (*metric.MetricsList, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) != 1 || values[0] != "Bearer valid" {
+		return nil, status.Error(codes.Unauthenticated, "token has expired")
+	}
+	return &metric.MetricsList{}, nil
+}
+
+func startFakeServer(t *testing.T) (*fakeServer, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	fake := &fakeServer{}
+	server := grpc.NewServer()
+	auth.RegisterAuthServiceServer(server, fake)
+	metric.RegisterMetricServiceServer(server, fake)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return fake, lis.Addr().String()
+}
+
+func newTestClients(t *testing.T, addr string, cfg *config.Config) (*Clients, *[]config.Config) {
+	c, err := NewClients(addr, config.TLSConfig{})
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	var saved []config.Config
+	c.Authenticate(cfg)
+	c.session.save = func(cfg *config.Config) error {
+		saved = append(saved, *cfg)
+		return nil
+	}
+	return c, &saved
+}
+
+func TestClients_RefreshOnUnauthenticated(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	cfg := &config.Config{Username: "alice", JWTToken: "expired", RefreshToken: "refresh"}
+	c, saved := newTestClients(t, addr, cfg)
+
+	_, err := c.MetricClient.GetMetrics(context.Background(), &metric.MetricsRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "valid", cfg.JWTToken)
+	assert.Equal(t, "refresh-2", cfg.RefreshToken)
+	require.Len(t, *saved, 1)
+	assert.Equal(t, "refresh-2", (*saved)[0].RefreshToken)
+
+	// The renewed token is reused without refreshing again
+	_, err = c.MetricClient.GetMetrics(context.Background(), &metric.MetricsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.refreshes)
+}
+
+func TestClients_RefreshFailure(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	cfg := &config.Config{Username: "alice", JWTToken: "expired", RefreshToken: "revoked"}
+	c, saved := newTestClients(t, addr, cfg)
+
+	_, err := c.MetricClient.GetMetrics(context.Background(), &metric.MetricsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "expired", cfg.JWTToken)
+	assert.Empty(t, *saved)
+	assert.Equal(t, 1, fake.refreshes)
+}
+
+func TestClients_WithoutSession(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	c, err := NewClients(addr, config.TLSConfig{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.MetricClient.GetMetrics(context.Background(), &metric.MetricsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 0, fake.refreshes)
+}
+
+func TestExpiresSoon(t *testing.T) {
+	assert.False(t, expiresSoon(""))
+	assert.False(t, expiresSoon("not-a-jwt"))
+	// {"alg":"HS256"}.{"exp":1}
+	assert.True(t, expiresSoon("eyJhbGciOiJIUzI1NiJ9.eyJleHAiOjF9.c2ln"))
+}
@@ -8,10 +8,25 @@ import (
 )
 
 type Config struct {
-	ServerURL    string `yaml:"server_url"`
-	Username     string `yaml:"username"`
-	JWTToken     string `yaml:"jwt_token"`
-	RefreshToken string `yaml:"refresh_token"`
+	ServerURL    string    `yaml:"server_url"`
+	Username     string    `yaml:"username"`
+	JWTToken     string    `yaml:"jwt_token"`
+	RefreshToken string    `yaml:"refresh_token"`
+	TLS          TLSConfig `yaml:"tls,omitempty"`
+}
+
+// TLSConfig holds the transport security settings used to reach the server
+type TLSConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	CAFile     string `yaml:"ca_file,omitempty"`
+	CertFile   string `yaml:"cert_file,omitempty"`
+	KeyFile    string `yaml:"key_file,omitempty"`
+	ServerName string `yaml:"server_name,omitempty"`
+}
+
+// IsEnabled reports whether the connection has to use TLS
+func (c TLSConfig) IsEnabled() bool {
+	return c.Enabled || c.CAFile != "" || c.CertFile != "" || c.KeyFile != ""
 }
 
 func GetConfigPath() (string, error) {
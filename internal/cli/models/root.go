@@ -28,7 +28,8 @@ type RootModel struct {
 	height       int
 }
 
-func NewRootModel(grpcClients *clients.Clients) *RootModel {
+// NewRootModel creates the root model. tlsCfg is used to reach the server from the login page
+func NewRootModel(grpcClients *clients.Clients, tlsCfg config.TLSConfig) *RootModel {
 	m := &RootModel{
 		grpcClients: grpcClients,
 		width:       80, // Default width
@@ -43,7 +44,9 @@ func NewRootModel(grpcClients *clients.Clients) *RootModel {
 	} else {
 		logger.Info("No configuration found, going to login page")
 		m.currentPage = PageLogin
-		m.loginModel = login.NewModel(services.NewAuthService(grpcClients))
+		authService := services.NewAuthService(grpcClients)
+		authService.TLS = tlsCfg
+		m.loginModel = login.NewModel(authService)
 		m.loginModel = m.setModelDimensions(m.loginModel).(login.Model)
 	}
 
@@ -124,12 +127,13 @@ func (m *RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Quit
 				}
 
-				m.grpcClients, err = clients.NewClients(cfg.ServerURL)
+				m.grpcClients, err = clients.NewClients(cfg.ServerURL, cfg.TLS)
 				if err != nil {
 					logger.Error("Failed to create gRPC clients after login", zap.Error(err))
 					m.err = err
 					return m, tea.Quit
 				}
+				m.grpcClients.Authenticate(cfg)
 
 				m.currentPage = PageLoading
 				m.loadingModel = loading.NewModel(m.grpcClients)
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/config"
 )
 
 func TestNewRootModel(t *testing.T) {
@@ -36,13 +37,13 @@ func TestNewRootModel(t *testing.T) {
 			var err error
 
 			if tt.serverURL != "" {
-				grpcClients, err = clients.NewClients(tt.serverURL)
+				grpcClients, err = clients.NewClients(tt.serverURL, config.TLSConfig{})
 				if err != nil {
 					t.Fatalf("Failed to create gRPC clients: %v", err)
 				}
 			}
 
-			model := NewRootModel(grpcClients)
+			model := NewRootModel(grpcClients, config.TLSConfig{})
 
 			if tt.expectNotNil {
 				if model == nil {
@@ -54,11 +55,11 @@ func TestNewRootModel(t *testing.T) {
 }
 
 func TestRootModel_Init(t *testing.T) {
-	grpcClients, err := clients.NewClients("localhost:50051")
+	grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create gRPC clients: %v", err)
 	}
-	model := NewRootModel(grpcClients)
+	model := NewRootModel(grpcClients, config.TLSConfig{})
 
 	cmd := model.Init()
 	if cmd == nil {
@@ -91,11 +92,11 @@ func TestRootModel_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients("localhost:50051")
+			grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
-			model := NewRootModel(grpcClients)
+			model := NewRootModel(grpcClients, config.TLSConfig{})
 
 			updatedModel, cmd := model.Update(tt.msg)
 
@@ -116,11 +117,11 @@ func TestRootModel_Update(t *testing.T) {
 }
 
 func TestRootModel_View(t *testing.T) {
-	grpcClients, err := clients.NewClients("localhost:50051")
+	grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create gRPC clients: %v", err)
 	}
-	model := NewRootModel(grpcClients)
+	model := NewRootModel(grpcClients, config.TLSConfig{})
 
 	view := model.View()
 	if view == "" {
@@ -129,11 +130,11 @@ func TestRootModel_View(t *testing.T) {
 }
 
 func TestRootModel_HasError(t *testing.T) {
-	grpcClients, err := clients.NewClients("localhost:50051")
+	grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create gRPC clients: %v", err)
 	}
-	model := NewRootModel(grpcClients)
+	model := NewRootModel(grpcClients, config.TLSConfig{})
 
 	// Initially should have no error
 	if model.HasError() {
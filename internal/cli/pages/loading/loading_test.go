@@ -6,6 +6,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/config"
+	"github.com/theotruvelot/g0s/pkg/proto/health"
 )
 
 func TestNewModel(t *testing.T) {
@@ -25,7 +27,7 @@ func TestNewModel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients(tt.serverURL)
+			grpcClients, err := clients.NewClients(tt.serverURL, config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
@@ -49,7 +51,7 @@ func TestNewModel(t *testing.T) {
 }
 
 func TestModel_Init(t *testing.T) {
-	grpcClients, err := clients.NewClients("localhost:50051")
+	grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create gRPC clients: %v", err)
 	}
@@ -62,7 +64,7 @@ func TestModel_Init(t *testing.T) {
 }
 
 func TestModel_Update_WindowSize(t *testing.T) {
-	grpcClients, err := clients.NewClients("localhost:50051")
+	grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create gRPC clients: %v", err)
 	}
@@ -115,7 +117,7 @@ func TestModel_Update_KeyMessages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients("localhost:50051")
+			grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
@@ -181,7 +183,7 @@ func TestModel_Update_StepMsg(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients("localhost:50051")
+			grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
@@ -210,7 +212,7 @@ func TestModel_Update_HealthCheckResult(t *testing.T) {
 			name: "successful health check",
 			result: HealthCheckResult{
 				Success:   true,
-				Status:    "ok",
+				Status:    health.HealthCheckResponse_SERVING,
 				Latency:   "10ms",
 				Timestamp: "2023-01-01T00:00:00Z",
 			},
@@ -222,7 +224,7 @@ func TestModel_Update_HealthCheckResult(t *testing.T) {
 			name: "failed health check - first retry",
 			result: HealthCheckResult{
 				Success:   false,
-				Status:    "error",
+				Status:    health.HealthCheckResponse_NOT_SERVING,
 				Error:     errors.New("connection failed"),
 				Latency:   "0ms",
 				Timestamp: "2023-01-01T00:00:00Z",
@@ -235,7 +237,7 @@ func TestModel_Update_HealthCheckResult(t *testing.T) {
 			name: "failed health check - max retries reached",
 			result: HealthCheckResult{
 				Success:   false,
-				Status:    "error",
+				Status:    health.HealthCheckResponse_NOT_SERVING,
 				Error:     errors.New("connection failed"),
 				Latency:   "0ms",
 				Timestamp: "2023-01-01T00:00:00Z",
@@ -248,7 +250,7 @@ func TestModel_Update_HealthCheckResult(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients("localhost:50051")
+			grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
@@ -305,7 +307,7 @@ func TestModel_View(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			grpcClients, err := clients.NewClients("localhost:50051")
+			grpcClients, err := clients.NewClients("localhost:50051", config.TLSConfig{})
 			if err != nil {
 				t.Fatalf("Failed to create gRPC clients: %v", err)
 			}
@@ -146,6 +146,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Username:     m.username,
 			JWTToken:     msg.jwtToken,
 			RefreshToken: msg.refreshToken,
+			TLS:          m.authService.TLS,
 		}
 
 		if err := config.SaveConfig(cfg); err != nil {
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/pkg/proto/admin"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type AdminService struct {
+	Clients *clients.Clients
+}
+
+func NewAdminService(clients *clients.Clients) *AdminService {
+	return &AdminService{
+		Clients: clients,
+	}
+}
+
+func (a *AdminService) CreateUser(ctx context.Context, username, role string, hostGroups []string) (*admin.User, error) {
+	req := &admin.CreateUserRequest{Username: username, Role: role, HostGroups: hostGroups}
+	return a.Clients.AdminClient.CreateUser(ctx, req)
+}
+
+func (a *AdminService) ListUsers(ctx context.Context) ([]*admin.User, error) {
+	res, err := a.Clients.AdminClient.ListUsers(ctx, &admin.ListUsersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetUsers(), nil
+}
+
+func (a *AdminService) DisableUser(ctx context.Context, username string) (*admin.User, error) {
+	return a.Clients.AdminClient.DisableUser(ctx, &admin.DisableUserRequest{Username: username})
+}
+
+func (a *AdminService) SetUserRole(ctx context.Context, username, role string) (*admin.User, error) {
+	return a.Clients.AdminClient.SetUserRole(ctx, &admin.SetUserRoleRequest{Username: username, Role: role})
+}
+
+// SetUserHostGroups replaces the host groups of the user, no group gives access to every host
+func (a *AdminService) SetUserHostGroups(ctx context.Context, username string, hostGroups []string) (*admin.User, error) {
+	req := &admin.SetUserHostGroupsRequest{Username: username, HostGroups: hostGroups}
+	return a.Clients.AdminClient.SetUserHostGroups(ctx, req)
+}
+
+// IssueToken creates a token for the user. The returned value is the only time the token can be read.
+// A zero ttl creates a token that never expires.
+func (a *AdminService) IssueToken(ctx context.Context, username, name string, scopes []string, ttl time.Duration) (*admin.IssueTokenResponse, error) {
+	req := &admin.IssueTokenRequest{Username: username, Name: name, Scopes: scopes}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+	return a.Clients.AdminClient.IssueToken(ctx, req)
+}
+
+func (a *AdminService) ListTokens(ctx context.Context, username string) ([]*admin.ApiToken, error) {
+	res, err := a.Clients.AdminClient.ListTokens(ctx, &admin.ListTokensRequest{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetTokens(), nil
+}
+
+func (a *AdminService) RevokeToken(ctx context.Context, username, tokenID string) (*admin.ApiToken, error) {
+	return a.Clients.AdminClient.RevokeToken(ctx, &admin.RevokeTokenRequest{Username: username, TokenId: tokenID})
+}
+
+// PutHostGroup creates the host group or replaces its patterns and tags
+func (a *AdminService) PutHostGroup(ctx context.Context, name string, hostPatterns []string, hostTags map[string]string) (*admin.HostGroup, error) {
+	return a.Clients.AdminClient.PutHostGroup(ctx, &admin.HostGroup{Name: name, HostPatterns: hostPatterns, HostTags: hostTags})
+}
+
+func (a *AdminService) ListHostGroups(ctx context.Context) ([]*admin.HostGroup, error) {
+	res, err := a.Clients.AdminClient.ListHostGroups(ctx, &admin.ListHostGroupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetHostGroups(), nil
+}
+
+func (a *AdminService) DeleteHostGroup(ctx context.Context, name string) error {
+	_, err := a.Clients.AdminClient.DeleteHostGroup(ctx, &admin.DeleteHostGroupRequest{Name: name})
+	return err
+}
+
+// CreateJoinToken creates a single-use token an agent enrolls with. The returned value is the
+// only time the token can be read. A zero ttl uses the default lifetime of the server.
+func (a *AdminService) CreateJoinToken(ctx context.Context, ttl time.Duration) (*admin.CreateJoinTokenResponse, error) {
+	req := &admin.CreateJoinTokenRequest{}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+	return a.Clients.AdminClient.CreateJoinToken(ctx, req)
+}
+
+func (a *AdminService) RevokeAgent(ctx context.Context, hostname string) (*admin.Agent, error) {
+	return a.Clients.AdminClient.RevokeAgent(ctx, &admin.RevokeAgentRequest{Hostname: hostname})
+}
+
+func (a *AdminService) DecommissionHost(ctx context.Context, hostname string) error {
+	_, err := a.Clients.AdminClient.DecommissionHost(ctx, &admin.DecommissionHostRequest{Hostname: hostname})
+	return err
+}
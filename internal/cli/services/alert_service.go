@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/pkg/proto/alert"
+)
+
+type AlertService struct {
+	Clients *clients.Clients
+}
+
+func NewAlertService(clients *clients.Clients) *AlertService {
+	return &AlertService{
+		Clients: clients,
+	}
+}
+
+// ListAlerts returns the latest alerts in state, every state if unspecified, of the hosts matching hostFilter
+func (a *AlertService) ListAlerts(ctx context.Context, state alert.AlertState, hostFilter string, limit int) ([]*alert.Alert, error) {
+	req := &alert.ListAlertsRequest{State: state, HostFilter: hostFilter, Limit: int32(limit)}
+	res, err := a.Clients.AlertClient.ListAlerts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetAlerts(), nil
+}
+
+func (a *AlertService) ListAlertRules(ctx context.Context) ([]*alert.AlertRule, error) {
+	res, err := a.Clients.AlertClient.ListAlertRules(ctx, &alert.ListAlertRulesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetRules(), nil
+}
+
+func (a *AlertService) PutAlertRule(ctx context.Context, rule *alert.AlertRule) (*alert.AlertRule, error) {
+	return a.Clients.AlertClient.PutAlertRule(ctx, rule)
+}
+
+func (a *AlertService) DeleteAlertRule(ctx context.Context, name string) error {
+	_, err := a.Clients.AlertClient.DeleteAlertRule(ctx, &alert.DeleteAlertRuleRequest{Name: name})
+	return err
+}
+
+func (a *AlertService) CreateSilence(ctx context.Context, silence *alert.Silence) (*alert.Silence, error) {
+	return a.Clients.AlertClient.CreateSilence(ctx, silence)
+}
+
+func (a *AlertService) ListSilences(ctx context.Context, includeExpired bool) ([]*alert.Silence, error) {
+	res, err := a.Clients.AlertClient.ListSilences(ctx, &alert.ListSilencesRequest{IncludeExpired: includeExpired})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetSilences(), nil
+}
+
+func (a *AlertService) ExpireSilence(ctx context.Context, id string) (*alert.Silence, error) {
+	return a.Clients.AlertClient.ExpireSilence(ctx, &alert.ExpireSilenceRequest{Id: id})
+}
+
+func (a *AlertService) PutMaintenanceWindow(ctx context.Context, window *alert.MaintenanceWindow) (*alert.MaintenanceWindow, error) {
+	return a.Clients.AlertClient.PutMaintenanceWindow(ctx, window)
+}
+
+func (a *AlertService) ListMaintenanceWindows(ctx context.Context) ([]*alert.MaintenanceWindow, error) {
+	res, err := a.Clients.AlertClient.ListMaintenanceWindows(ctx, &alert.ListMaintenanceWindowsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetWindows(), nil
+}
+
+func (a *AlertService) DeleteMaintenanceWindow(ctx context.Context, name string) error {
+	_, err := a.Clients.AlertClient.DeleteMaintenanceWindow(ctx, &alert.DeleteMaintenanceWindowRequest{Name: name})
+	return err
+}
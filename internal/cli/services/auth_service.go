@@ -7,11 +7,14 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/internal/cli/config"
 	"github.com/theotruvelot/g0s/pkg/proto/auth"
 )
 
 type AuthService struct {
 	Clients *clients.Clients
+	// TLS is used by Login to reach a server that is not configured yet
+	TLS config.TLSConfig
 }
 
 func NewAuthService(clients *clients.Clients) *AuthService {
@@ -21,7 +24,7 @@ func NewAuthService(clients *clients.Clients) *AuthService {
 }
 
 func (a *AuthService) Login(ctx context.Context, serverURL, username, token string) (*auth.AuthenticateResponse, error) {
-	tempClients, err := clients.NewClients(serverURL)
+	tempClients, err := clients.NewClients(serverURL, a.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("could not create temporary gRPC client: %w", err)
 	}
@@ -42,16 +45,17 @@ func (a *AuthService) Login(ctx context.Context, serverURL, username, token stri
 	return res, nil
 }
 
-//TODO REFRESH TOKEN
-//func (a *AuthService) RefreshToken(ctx context.Context, refresh string) (*auth.RefreshTokenResponse, error) {
-//	req := &auth.RefreshTokenRequest{JwtRefreshToken: refresh}
-//	res, err := a.Clients.AuthClient.RefreshToken(ctx, req)
-//	if err != nil {
-//		return nil, fmt.Errorf("could not refresh token: %w", err)
-//	}
-//	if res.Status != auth.RefreshTokenResponse_OK {
-//		return nil, fmt.Errorf("failed to refresh token: %s", res.Status.String())
-//	}
-//
-//	return res, nil
-//}
+// RefreshToken exchanges a refresh token for a new token pair. The refresh token can't be used again.
+// Calls made through Clients renew their tokens on their own, see Clients.Authenticate.
+func (a *AuthService) RefreshToken(ctx context.Context, refresh string) (*auth.RefreshTokenResponse, error) {
+	req := &auth.RefreshTokenRequest{JwtRefreshToken: refresh}
+	res, err := a.Clients.AuthClient.RefreshToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh token: %w", err)
+	}
+	if res.Status != auth.RefreshTokenResponse_OK {
+		return nil, fmt.Errorf("failed to refresh token: %s", res.Status.String())
+	}
+
+	return res, nil
+}
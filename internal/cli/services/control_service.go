@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/pkg/proto/control"
+)
+
+type ControlService struct {
+	Clients *clients.Clients
+}
+
+func NewControlService(clients *clients.Clients) *ControlService {
+	return &ControlService{
+		Clients: clients,
+	}
+}
+
+// SignalProcess sends a signal to processes of a host through its agent and returns the audit
+// record of the action, completed with the result of the agent
+func (c *ControlService) SignalProcess(ctx context.Context, req *control.SignalProcessRequest) (*control.ActionResult, error) {
+	return c.Clients.ControlClient.SignalProcess(ctx, req)
+}
+
+// ControlUnit starts, stops or restarts a systemd unit of a host through its agent and returns
+// the audit record of the action, completed with the result of the agent
+func (c *ControlService) ControlUnit(ctx context.Context, req *control.ControlUnitRequest) (*control.ActionResult, error) {
+	return c.Clients.ControlClient.ControlUnit(ctx, req)
+}
+
+// ListActions returns the latest actions on hostname, on every host the user can see if empty
+func (c *ControlService) ListActions(ctx context.Context, hostname string, limit int32) ([]*control.ActionResult, error) {
+	res, err := c.Clients.ControlClient.ListActions(ctx, &control.ListActionsRequest{Hostname: hostname, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetActions(), nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/cli/clients"
+	"github.com/theotruvelot/g0s/pkg/proto/host"
+)
+
+type HostService struct {
+	Clients *clients.Clients
+}
+
+func NewHostService(clients *clients.Clients) *HostService {
+	return &HostService{
+		Clients: clients,
+	}
+}
+
+// ListHosts returns the hosts the user can see having all the given tags
+func (h *HostService) ListHosts(ctx context.Context, tags map[string]string) ([]*host.Host, error) {
+	res, err := h.Clients.HostClient.ListHosts(ctx, &host.ListHostsRequest{Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetHosts(), nil
+}
+
+func (h *HostService) GetHost(ctx context.Context, hostname string) (*host.Host, error) {
+	return h.Clients.HostClient.GetHost(ctx, &host.GetHostRequest{Hostname: hostname})
+}
+
+// WatchHostEvents calls fn with every state transition of the hosts matching the host filter
+// until ctx is done
+func (h *HostService) WatchHostEvents(ctx context.Context, hostFilter string, fn func(*host.HostEvent)) error {
+	stream, err := h.Clients.HostClient.WatchHostEvents(ctx, &host.WatchHostEventsRequest{HostFilter: hostFilter})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		fn(event)
+	}
+}
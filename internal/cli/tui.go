@@ -12,7 +12,7 @@ import (
 )
 
 // RunWithConfig initializes and runs the TUI application
-func RunWithConfig(serverURL, apiToken string) error {
+func RunWithConfig(serverURL, apiToken string, tlsCfg config.TLSConfig) error {
 	logger.Info("Starting TUI application")
 
 	var grpcClients *clients.Clients
@@ -21,7 +21,7 @@ func RunWithConfig(serverURL, apiToken string) error {
 	// If CLI parameters are provided, use them
 	if serverURL != "" && apiToken != "" {
 		logger.Info("Using CLI parameters", zap.String("server", serverURL))
-		grpcClients, err = clients.NewClients(serverURL)
+		grpcClients, err = clients.NewClients(serverURL, tlsCfg)
 		if err != nil {
 			logger.Error("Failed to create gRPC clients", zap.Error(err))
 			return fmt.Errorf("failed to create gRPC clients: %w", err)
@@ -33,17 +33,18 @@ func RunWithConfig(serverURL, apiToken string) error {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 		logger.Info("Using config file", zap.String("server", cfg.ServerURL))
-		grpcClients, err = clients.NewClients(cfg.ServerURL)
+		grpcClients, err = clients.NewClients(cfg.ServerURL, cfg.TLS)
 		if err != nil {
 			logger.Error("Failed to create gRPC clients", zap.Error(err))
 			return fmt.Errorf("failed to create gRPC clients: %w", err)
 		}
+		grpcClients.Authenticate(cfg)
 	} else {
 		logger.Info("No configuration found, will configure after login")
 		grpcClients = nil
 	}
 
-	rootModel := models.NewRootModel(grpcClients)
+	rootModel := models.NewRootModel(grpcClients, tlsCfg)
 
 	program := tea.NewProgram(
 		rootModel,
@@ -0,0 +1,238 @@
+// Package alerting parses the threshold alert rules evaluated by the server
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severities of the rules, a rule without severity is a warning
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Severities lists every severity, from the least to the most severe
+var Severities = []string{SeverityInfo, SeverityWarning, SeverityCritical}
+
+var (
+	ErrInvalidRuleName = errors.New("rule name must be 1 to 64 letters, digits, dots, dashes or underscores")
+	ErrInvalidExpr     = errors.New("invalid rule expression")
+	ErrInvalidSeverity = errors.New("severity must be info, warning or critical")
+)
+
+var (
+	_ruleNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+	// _exprPattern is <metric> <op> <threshold> [for <duration>] [on <matchers>]
+	_exprPattern = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(>=|<=|==|!=|>|<)\s*(\S+?)(?:\s+for\s+(\S+))?(?:\s+on\s+(.+?))?\s*$`)
+	// _matcherPattern is <label><op>"<value>", the value being a Go quoted string
+	_matcherPattern = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*("(?:[^"\\]|\\.)*")\s*(?:,|$)`)
+)
+
+// Matcher selects series on the value of one of their labels
+type Matcher struct {
+	Label string
+	Op    string
+	Value string
+	re    *regexp.Regexp
+}
+
+// Matches reports whether the labels satisfy the matcher, a missing label being empty
+func (m Matcher) Matches(labels map[string]string) bool {
+	value := labels[m.Label]
+	switch m.Op {
+	case "=":
+		return value == m.Value
+	case "!=":
+		return value != m.Value
+	case "=~":
+		return m.re.MatchString(value)
+	default:
+		return !m.re.MatchString(value)
+	}
+}
+
+// Rule is a threshold on a metric: series of the metric matching every matcher whose value
+// passes the threshold for the duration of the rule are firing
+type Rule struct {
+	Name        string
+	Expr        string
+	Severity    string
+	Description string
+
+	Metric    string
+	Op        string
+	Threshold float64
+	For       time.Duration
+	Matchers  []Matcher
+}
+
+// NewRule validates and parses a rule
+func NewRule(name, expr, severity, description string) (*Rule, error) {
+	if !_ruleNamePattern.MatchString(name) {
+		return nil, ErrInvalidRuleName
+	}
+	if severity == "" {
+		severity = SeverityWarning
+	}
+	if !slices.Contains(Severities, severity) {
+		return nil, ErrInvalidSeverity
+	}
+
+	rule, err := ParseExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	rule.Name = name
+	rule.Severity = severity
+	rule.Description = description
+	return rule, nil
+}
+
+// ParseExpr parses a rule expression, e.g. `cpu_usage_percent_avg > 90 for 5m on host=~"web-.*"`.
+// Regular expressions are anchored on both ends, the same way VictoriaMetrics matches them.
+func ParseExpr(expr string) (*Rule, error) {
+	parts := _exprPattern.FindStringSubmatch(expr)
+	if parts == nil {
+		return nil, fmt.Errorf("%w: expected <metric> <op> <threshold> [for <duration>] [on <label>=~\"<regex>\", ...]", ErrInvalidExpr)
+	}
+
+	rule := &Rule{
+		Expr:   strings.TrimSpace(expr),
+		Metric: parts[1],
+		Op:     parts[2],
+	}
+
+	threshold, err := strconv.ParseFloat(parts[3], 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid threshold %q", ErrInvalidExpr, parts[3])
+	}
+	rule.Threshold = threshold
+
+	if parts[4] != "" {
+		rule.For, err = time.ParseDuration(parts[4])
+		if err != nil || rule.For < 0 {
+			return nil, fmt.Errorf("%w: invalid duration %q", ErrInvalidExpr, parts[4])
+		}
+	}
+
+	rule.Matchers, err = parseMatchers(parts[5])
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func parseMatchers(s string) ([]Matcher, error) {
+	var matchers []Matcher
+	for rest := strings.TrimSpace(s); rest != ""; {
+		loc := _matcherPattern.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			return nil, fmt.Errorf("%w: invalid matcher %q", ErrInvalidExpr, rest)
+		}
+
+		value, err := strconv.Unquote(rest[loc[6]:loc[7]])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid matcher value %s", ErrInvalidExpr, rest[loc[6]:loc[7]])
+		}
+		matcher := Matcher{
+			Label: rest[loc[2]:loc[3]],
+			Op:    rest[loc[4]:loc[5]],
+			Value: value,
+		}
+		if matcher.Op == "=~" || matcher.Op == "!~" {
+			matcher.re, err = regexp.Compile("^(?:" + value + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid regex %q: %v", ErrInvalidExpr, value, err)
+			}
+		}
+
+		matchers = append(matchers, matcher)
+		rest = strings.TrimSpace(rest[loc[1]:])
+	}
+	return matchers, nil
+}
+
+// Matches reports whether the series is selected by the rule
+func (r *Rule) Matches(metric string, labels map[string]string) bool {
+	if metric != r.Metric {
+		return false
+	}
+	for _, matcher := range r.Matchers {
+		if !matcher.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Exceeds reports whether the value passes the threshold of the rule
+func (r *Rule) Exceeds(value float64) bool {
+	switch r.Op {
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	case "<":
+		return value < r.Threshold
+	case "<=":
+		return value <= r.Threshold
+	case "==":
+		return value == r.Threshold
+	default:
+		return value != r.Threshold
+	}
+}
+
+// rulesFile is the format of the rules file of the server
+type rulesFile struct {
+	Rules []struct {
+		Name        string `yaml:"name"`
+		Expr        string `yaml:"expr"`
+		Severity    string `yaml:"severity"`
+		Description string `yaml:"description"`
+	} `yaml:"rules"`
+}
+
+// LoadRulesFile reads the rules of a YAML file:
+//
+//	rules:
+//	  - name: high-cpu
+//	    expr: cpu_usage_percent_avg > 90 for 5m on host=~"web-.*"
+//	    severity: critical
+//	    description: CPU of a web server is saturated
+func LoadRulesFile(path string) ([]*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	rules := make([]*Rule, 0, len(file.Rules))
+	names := make(map[string]bool, len(file.Rules))
+	for i, r := range file.Rules {
+		rule, err := NewRule(r.Name, r.Expr, r.Severity, r.Description)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d of %s: %w", i+1, path, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %d of %s: duplicate rule name %q", i+1, path, rule.Name)
+		}
+		names[rule.Name] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,115 @@
+package alerting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpr(t *testing.T) {
+	rule, err := ParseExpr(`cpu_usage_percent_avg > 90 for 5m on host=~"web-.*"`)
+	require.NoError(t, err)
+	assert.Equal(t, "cpu_usage_percent_avg", rule.Metric)
+	assert.Equal(t, ">", rule.Op)
+	assert.Equal(t, 90.0, rule.Threshold)
+	assert.Equal(t, 5*time.Minute, rule.For)
+	require.Len(t, rule.Matchers, 1)
+	assert.Equal(t, Matcher{Label: "host", Op: "=~", Value: "web-.*", re: rule.Matchers[0].re}, rule.Matchers[0])
+
+	rule, err = ParseExpr(`disk_used_percent>=95.5 on host!="db-1", path="/"`)
+	require.NoError(t, err)
+	assert.Equal(t, ">=", rule.Op)
+	assert.Equal(t, 95.5, rule.Threshold)
+	assert.Zero(t, rule.For)
+	assert.Len(t, rule.Matchers, 2)
+
+	for _, expr := range []string{
+		"",
+		"cpu_usage_percent_avg",
+		"cpu_usage_percent_avg > high",
+		"cpu_usage_percent_avg > 90 for ever",
+		`cpu_usage_percent_avg > 90 on host=~"web-("`,
+		`cpu_usage_percent_avg > 90 on host=web`,
+		`cpu_usage_percent_avg > 90 on host="web" model="x"`,
+	} {
+		_, err := ParseExpr(expr)
+		assert.ErrorIs(t, err, ErrInvalidExpr, expr)
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	rule, err := ParseExpr(`cpu_usage_percent > 90 on host=~"web-.*", core_id!~"0|1"`)
+	require.NoError(t, err)
+
+	assert.True(t, rule.Matches("cpu_usage_percent", map[string]string{"host": "web-1", "core_id": "2"}))
+	assert.False(t, rule.Matches("cpu_usage_percent", map[string]string{"host": "web-1", "core_id": "1"}))
+	assert.False(t, rule.Matches("cpu_usage_percent", map[string]string{"host": "db-web-1", "core_id": "2"}))
+	assert.False(t, rule.Matches("cpu_usage_percent_avg", map[string]string{"host": "web-1"}))
+}
+
+func TestRule_Exceeds(t *testing.T) {
+	tests := []struct {
+		expr     string
+		value    float64
+		expected bool
+	}{
+		{"m > 1", 2, true},
+		{"m > 1", 1, false},
+		{"m >= 1", 1, true},
+		{"m < 1", 0, true},
+		{"m <= 1", 2, false},
+		{"m == 0", 0, true},
+		{"m != 0", 0, false},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseExpr(tt.expr)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, rule.Exceeds(tt.value), "%s with %v", tt.expr, tt.value)
+	}
+}
+
+func TestNewRule(t *testing.T) {
+	rule, err := NewRule("high-cpu", "cpu_usage_percent_avg > 90", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityWarning, rule.Severity)
+
+	_, err = NewRule("high cpu", "cpu_usage_percent_avg > 90", "", "")
+	assert.ErrorIs(t, err, ErrInvalidRuleName)
+	_, err = NewRule("high-cpu", "cpu_usage_percent_avg > 90", "page", "")
+	assert.ErrorIs(t, err, ErrInvalidSeverity)
+}
+
+func TestLoadRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - name: high-cpu
+    expr: cpu_usage_percent_avg > 90 for 5m on host=~"web-.*"
+    severity: critical
+    description: CPU of a web server is saturated
+  - name: low-ram
+    expr: ram_available < 1e8
+`), 0o600))
+
+	rules, err := LoadRulesFile(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "high-cpu", rules[0].Name)
+	assert.Equal(t, SeverityCritical, rules[0].Severity)
+	assert.Equal(t, 1e8, rules[1].Threshold)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - name: high-cpu
+    expr: cpu_usage_percent_avg > 90
+  - name: high-cpu
+    expr: cpu_usage_percent_avg > 95
+`), 0o600))
+	_, err = LoadRulesFile(path)
+	assert.ErrorContains(t, err, "duplicate rule name")
+}
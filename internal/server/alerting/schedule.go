@@ -0,0 +1,185 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxWindowDuration bounds the duration of a maintenance window
+const MaxWindowDuration = 7 * 24 * time.Hour
+
+var (
+	ErrInvalidSchedule       = errors.New("invalid schedule")
+	ErrInvalidWindowName     = errors.New("window name must be 1 to 64 letters, digits, dots, dashes or underscores")
+	ErrInvalidWindowDuration = errors.New("window duration must be positive and at most 7 days")
+	ErrInvalidTimezone       = errors.New("invalid time zone")
+)
+
+// scheduleField is the range of a field of a cron schedule
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var _scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a cron schedule of five fields: minute, hour, day of month, month and day of week,
+// e.g. "0 22 * * 0" for every Sunday at 22:00. Fields are `*`, values, ranges and lists, with an
+// optional step, e.g. "*/15" or "1-5". Sunday is 0 or 7. As with cron, a day matches when either
+// the day of month or the day of week matches if both are restricted.
+type Schedule struct {
+	Spec string
+
+	fields [5]uint64
+	// domAny and dowAny are set when the day of month or day of week field is `*`
+	domAny bool
+	dowAny bool
+}
+
+// ParseSchedule parses a cron schedule
+func ParseSchedule(spec string) (*Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(_scheduleFields) {
+		return nil, fmt.Errorf("%w: expected 5 fields: minute hour day-of-month month day-of-week", ErrInvalidSchedule)
+	}
+
+	s := &Schedule{Spec: strings.Join(parts, " ")}
+	for i, part := range parts {
+		bits, err := parseScheduleField(part, _scheduleFields[i])
+		if err != nil {
+			return nil, err
+		}
+		s.fields[i] = bits
+	}
+	// Sunday is both 0 and 7
+	if s.fields[4]&(1<<7) != 0 {
+		s.fields[4] |= 1
+	}
+	s.domAny = parts[2] == "*"
+	s.dowAny = parts[4] == "*"
+	return s, nil
+}
+
+// parseScheduleField returns the set of values of a field, as a bitset
+func parseScheduleField(s string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q in %s", ErrInvalidSchedule, stepPart, field.name)
+			}
+		}
+
+		low, high := field.min, field.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidSchedule, field.name, item)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidSchedule, field.name, item)
+				}
+			} else if hasStep {
+				high = field.max
+			}
+		}
+		if low < field.min || high > field.max || low > high {
+			return 0, fmt.Errorf("%w: %s %q out of range %d-%d", ErrInvalidSchedule, field.name, item, field.min, field.max)
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Matches reports whether the schedule fires at the minute of t, in the location of t
+func (s *Schedule) Matches(t time.Time) bool {
+	has := func(field, value int) bool { return s.fields[field]&(1<<value) != 0 }
+	if !has(0, t.Minute()) || !has(1, t.Hour()) || !has(3, int(t.Month())) {
+		return false
+	}
+
+	dom, dow := has(2, t.Day()), has(4, int(t.Weekday()))
+	switch {
+	case s.domAny || s.dowAny:
+		return dom && dow
+	default:
+		return dom || dow
+	}
+}
+
+// Active reports whether t is within duration of a time the schedule fired at, i.e. whether a
+// window starting on the schedule and lasting duration is open at t
+func (s *Schedule) Active(t time.Time, duration time.Duration) bool {
+	start := t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	for at := start; t.Sub(at) < duration; at = at.Add(-time.Minute) {
+		if s.Matches(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// Window is a recurring maintenance window of the hosts matching its selector
+type Window struct {
+	Name     string
+	Schedule *Schedule
+	Location *time.Location
+	Duration time.Duration
+	Selector *Selector
+}
+
+// NewWindow validates and parses a maintenance window opening on the cron schedule in the
+// time zone, UTC if empty, and lasting duration
+func NewWindow(name, schedule, timezone string, duration time.Duration, hostFilter string, tags map[string]string) (*Window, error) {
+	if !_ruleNamePattern.MatchString(name) {
+		return nil, ErrInvalidWindowName
+	}
+	if duration <= 0 || duration > MaxWindowDuration {
+		return nil, ErrInvalidWindowDuration
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, timezone)
+	}
+	parsed, err := ParseSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := NewSelector(hostFilter, tags, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Window{
+		Name:     name,
+		Schedule: parsed,
+		Location: location,
+		Duration: duration,
+		Selector: selector,
+	}, nil
+}
+
+// Active reports whether the window is open at t
+func (w *Window) Active(t time.Time) bool {
+	return w.Schedule.Active(t.In(w.Location), w.Duration)
+}
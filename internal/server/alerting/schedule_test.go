@@ -0,0 +1,97 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "0 22 * * 0", "*/15 1-5,7 1 */2 *", "0 0 * * 7", " 30  2 * * 6 "} {
+		_, err := ParseSchedule(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "0 22 * * sun", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := ParseSchedule(spec)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, spec)
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	// Sunday 2024-06-02
+	sunday := time.Date(2024, time.June, 2, 22, 0, 0, 0, time.UTC)
+
+	s, err := ParseSchedule("0 22 * * 0")
+	require.NoError(t, err)
+	assert.True(t, s.Matches(sunday))
+	assert.False(t, s.Matches(sunday.Add(time.Minute)))
+	assert.False(t, s.Matches(sunday.AddDate(0, 0, 1)))
+
+	s, err = ParseSchedule("0 22 * * 7")
+	require.NoError(t, err)
+	assert.True(t, s.Matches(sunday), "7 is Sunday too")
+
+	s, err = ParseSchedule("*/20 9-17 * * 1-5")
+	require.NoError(t, err)
+	assert.True(t, s.Matches(time.Date(2024, time.June, 3, 9, 40, 0, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2024, time.June, 3, 9, 50, 0, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)))
+
+	// Either the day of month or the day of week matches when both are restricted
+	s, err = ParseSchedule("0 0 1 * 0")
+	require.NoError(t, err)
+	assert.True(t, s.Matches(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, s.Matches(time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedule_Active(t *testing.T) {
+	s, err := ParseSchedule("0 22 * * 0")
+	require.NoError(t, err)
+	start := time.Date(2024, time.June, 2, 22, 0, 0, 0, time.UTC)
+
+	assert.False(t, s.Active(start.Add(-time.Second), 4*time.Hour))
+	assert.True(t, s.Active(start, 4*time.Hour))
+	assert.True(t, s.Active(start.Add(3*time.Hour+59*time.Minute+30*time.Second), 4*time.Hour))
+	assert.False(t, s.Active(start.Add(4*time.Hour), 4*time.Hour))
+}
+
+func TestSelector(t *testing.T) {
+	_, err := NewSelector("", nil, "")
+	assert.ErrorIs(t, err, ErrEmptySelector)
+	_, err = NewSelector("web-(", nil, "")
+	assert.ErrorIs(t, err, ErrInvalidHostFilter)
+	_, err = NewSelector("", nil, "high cpu")
+	assert.ErrorIs(t, err, ErrInvalidRuleName)
+
+	s, err := NewSelector("web-.*", map[string]string{"env": "prod"}, "high-cpu")
+	require.NoError(t, err)
+	prod := map[string]string{"env": "prod", "team": "web"}
+	assert.True(t, s.Matches("high-cpu", "web-1", prod))
+	assert.False(t, s.Matches("low-disk", "web-1", prod))
+	assert.False(t, s.Matches("high-cpu", "db-web-1", prod))
+	assert.False(t, s.Matches("high-cpu", "web-1", map[string]string{"env": "staging"}))
+	assert.True(t, s.MatchesHost("web-1", prod))
+}
+
+func TestNewWindow(t *testing.T) {
+	w, err := NewWindow("sunday-patching", "0 22 * * 0", "Europe/Paris", 4*time.Hour, "web-.*", nil)
+	require.NoError(t, err)
+	// Sunday 22:00 in Paris is 20:00 UTC in summer
+	assert.True(t, w.Active(time.Date(2024, time.June, 2, 20, 30, 0, 0, time.UTC)))
+	assert.False(t, w.Active(time.Date(2024, time.June, 2, 19, 30, 0, 0, time.UTC)))
+
+	_, err = NewWindow("sunday patching", "0 22 * * 0", "", time.Hour, "web-.*", nil)
+	assert.ErrorIs(t, err, ErrInvalidWindowName)
+	_, err = NewWindow("w", "0 22 * * 0", "", 8*24*time.Hour, "web-.*", nil)
+	assert.ErrorIs(t, err, ErrInvalidWindowDuration)
+	_, err = NewWindow("w", "0 22 * * 0", "Mars/Olympus", time.Hour, "web-.*", nil)
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+	_, err = NewWindow("w", "0 22 * *", "", time.Hour, "web-.*", nil)
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+	_, err = NewWindow("w", "0 22 * * 0", "", time.Hour, "", nil)
+	assert.ErrorIs(t, err, ErrEmptySelector)
+}
@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrEmptySelector     = errors.New("a host filter, tags or a rule is required")
+	ErrInvalidHostFilter = errors.New("invalid host filter")
+)
+
+// Selector selects the alerts of a silence or the hosts of a maintenance window, on the hostname,
+// the tags of the host and the rule of the alert. Empty fields select everything.
+type Selector struct {
+	HostFilter string
+	Tags       map[string]string
+	Rule       string
+
+	host *regexp.Regexp
+}
+
+// NewSelector validates and compiles a selector. The host filter is a regular expression
+// anchored on both ends, the way host filters are matched everywhere else.
+func NewSelector(hostFilter string, tags map[string]string, rule string) (*Selector, error) {
+	if hostFilter == "" && len(tags) == 0 && rule == "" {
+		return nil, ErrEmptySelector
+	}
+	if rule != "" && !_ruleNamePattern.MatchString(rule) {
+		return nil, ErrInvalidRuleName
+	}
+
+	s := &Selector{HostFilter: hostFilter, Tags: tags, Rule: rule}
+	if hostFilter != "" {
+		var err error
+		s.host, err = regexp.Compile("^(?:" + hostFilter + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidHostFilter, err)
+		}
+	}
+	return s, nil
+}
+
+// MatchesHost reports whether the host matches the host filter and has every tag of the selector
+func (s *Selector) MatchesHost(hostname string, tags map[string]string) bool {
+	if s.host != nil && !s.host.MatchString(hostname) {
+		return false
+	}
+	for key, value := range s.Tags {
+		if v, ok := tags[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether the alert of the rule on the host is selected
+func (s *Selector) Matches(rule, hostname string, tags map[string]string) bool {
+	if s.Rule != "" && s.Rule != rule {
+		return false
+	}
+	return s.MatchesHost(hostname, tags)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrMissingClientCertificate = errors.New("missing client certificate")
+	ErrHostnameMismatch         = errors.New("agent credential does not match the reported hostname")
+	ErrAgentRevoked             = errors.New("agent credential revoked")
+)
+
+type agentContextKey struct{}
+
+type agentClaimsContextKey struct{}
+
+// NewAgentClaimsContext returns a copy of ctx carrying the verified credential of an enrolled agent
+func NewAgentClaimsContext(ctx context.Context, claims *AgentClaims) context.Context {
+	return context.WithValue(ctx, agentClaimsContextKey{}, claims)
+}
+
+// AgentClaimsFromContext returns the verified credential of the calling agent, if any
+func AgentClaimsFromContext(ctx context.Context) (*AgentClaims, bool) {
+	claims, ok := ctx.Value(agentClaimsContextKey{}).(*AgentClaims)
+	return claims, ok
+}
+
+// NewAgentContext returns a copy of ctx carrying the verified client certificate of an agent
+func NewAgentContext(ctx context.Context, cert *x509.Certificate) context.Context {
+	return context.WithValue(ctx, agentContextKey{}, cert)
+}
+
+// AgentCertificateFromContext returns the verified client certificate of the calling agent, if any
+func AgentCertificateFromContext(ctx context.Context) (*x509.Certificate, bool) {
+	cert, ok := ctx.Value(agentContextKey{}).(*x509.Certificate)
+	return cert, ok
+}
+
+// VerifyAgentHostname checks that the hostname reported by an agent is the one it enrolled
+// with or the one its certificate was issued for, either as common name or as DNS SAN.
+// Wildcards are not accepted so a certificate always identifies a single host. Requests
+// without a verified credential or certificate are accepted: the auth interceptor decides
+// whether one is required.
+func VerifyAgentHostname(ctx context.Context, hostname string) error {
+	if claims, ok := AgentClaimsFromContext(ctx); ok {
+		if hostname == "" || !strings.EqualFold(claims.Hostname, hostname) {
+			return ErrHostnameMismatch
+		}
+		return nil
+	}
+
+	cert, ok := AgentCertificateFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if hostname == "" {
+		return ErrHostnameMismatch
+	}
+	if strings.EqualFold(cert.Subject.CommonName, hostname) {
+		return nil
+	}
+	for _, name := range cert.DNSNames {
+		if strings.EqualFold(name, hostname) {
+			return nil
+		}
+	}
+
+	return ErrHostnameMismatch
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// _apiTokenPrefix and _joinTokenPrefix make g0s tokens easy to recognize, e.g. by secret scanners
+const (
+	_apiTokenPrefix  = "g0s_"
+	_joinTokenPrefix = "g0sj_"
+)
+
+// _apiTokenBytes is the amount of randomness of an API token
+const _apiTokenBytes = 32
+
+const (
+	// ScopeMetricsRead allows reading the stored and live metrics
+	ScopeMetricsRead = "metrics:read"
+	// ScopeHostsOperate allows acting on the hosts, e.g. silencing their alerts, for operators and admins
+	ScopeHostsOperate = "hosts:operate"
+	// ScopeAdmin allows managing users and tokens, for admin users only
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists every scope an API token can be granted
+var Scopes = []string{ScopeMetricsRead, ScopeHostsOperate, ScopeAdmin}
+
+var ErrInvalidScope = errors.New("invalid scope")
+
+// GenerateAPIToken returns a new random API token and its hash. Only the hash is meant
+// to be stored, the token itself is shown once to the user.
+func GenerateAPIToken() (token string, hash string, err error) {
+	return generateToken(_apiTokenPrefix)
+}
+
+// GenerateJoinToken returns a new random join token, used once by an agent to enroll, and its hash
+func GenerateJoinToken() (token string, hash string, err error) {
+	return generateToken(_joinTokenPrefix)
+}
+
+func generateToken(prefix string) (string, string, error) {
+	secret := make([]byte, _apiTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("generate token: %w", err)
+	}
+
+	token := prefix + base64.RawURLEncoding.EncodeToString(secret)
+	return token, HashAPIToken(token), nil
+}
+
+// HashAPIToken returns the hex encoded SHA-256 of a token. Tokens are random so a
+// fast hash is enough, there is nothing to brute force.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchAPIToken reports whether token hashes to hash, in constant time
+func MatchAPIToken(token, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIToken(token)), []byte(hash)) == 1
+}
+
+// ValidateScopes checks that every scope is known. An empty list is valid.
+func ValidateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+	}
+	return nil
+}
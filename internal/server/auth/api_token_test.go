@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIToken(t *testing.T) {
+	token, hash, err := GenerateAPIToken()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(token, _apiTokenPrefix))
+	assert.NotContains(t, hash, token)
+	assert.Equal(t, HashAPIToken(token), hash)
+
+	other, otherHash, err := GenerateAPIToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestMatchAPIToken(t *testing.T) {
+	token, hash, err := GenerateAPIToken()
+	require.NoError(t, err)
+
+	assert.True(t, MatchAPIToken(token, hash))
+	assert.False(t, MatchAPIToken(token+"x", hash))
+	assert.False(t, MatchAPIToken("", hash))
+	assert.False(t, MatchAPIToken(token, ""))
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes(nil))
+	assert.NoError(t, ValidateScopes(Scopes))
+
+	err := ValidateScopes([]string{ScopeMetricsRead, "metrics:write"})
+	assert.ErrorIs(t, err, ErrInvalidScope)
+	assert.Contains(t, err.Error(), "metrics:write")
+}
@@ -1,13 +1,21 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	"go.uber.org/zap"
+	"slices"
 	"time"
 )
 
+const _issuer = "g0s"
+
+// _agentAudience tells agent credentials apart from user access tokens, both being signed with the same secret
+const _agentAudience = "g0s-agent"
+
 var (
 	ErrInvalidToken   = errors.New("invalid token")
 	ErrExpiredToken   = errors.New("token has expired")
@@ -16,12 +24,30 @@ var (
 
 type JWTClaims struct {
 	Username string `json:"username"`
+	// Scopes are the scopes of the API token the user logged in with
+	Scopes []string `json:"scopes,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasScope reports whether the claims grant scope
+func (c *JWTClaims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// AgentClaims are the claims of the credential an enrolled agent authenticates with.
+// The jti is the credential ID of the host so enrolling the host again invalidates the old credential.
+type AgentClaims struct {
+	HostID   string `json:"host_id"`
+	Hostname string `json:"hostname"`
 	jwt.RegisteredClaims
 }
 
 type Token struct {
 	Token        string
 	RefreshToken string
+	// RefreshTokenID is the jti of the refresh token, used to make it single use
+	RefreshTokenID   string
+	RefreshExpiresAt time.Time
 }
 
 type JWTService struct {
@@ -36,11 +62,12 @@ func NewJWTService(secret string, refreshSecret string) *JWTService {
 	}
 }
 
-func (j *JWTService) GenerateJWT(username string) (Token, error) {
+func (j *JWTService) GenerateJWT(username string, scopes []string) (Token, error) {
 	claims := &JWTClaims{
 		Username: username,
+		Scopes:   scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "g0s",
+			Issuer:    _issuer,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)), // 7 days
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -55,7 +82,8 @@ func (j *JWTService) GenerateJWT(username string) (Token, error) {
 	refreshClaims := &JWTClaims{
 		Username: username,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "g0s",
+			ID:        uuid.New().String(),
+			Issuer:    _issuer,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(30 * 24 * time.Hour)), // 30 days
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -67,8 +95,10 @@ func (j *JWTService) GenerateJWT(username string) (Token, error) {
 		return Token{}, err
 	}
 	return Token{
-		Token:        signedToken,
-		RefreshToken: refreshSignedToken,
+		Token:            signedToken,
+		RefreshToken:     refreshSignedToken,
+		RefreshTokenID:   refreshClaims.ID,
+		RefreshExpiresAt: refreshClaims.ExpiresAt.Time,
 	}, nil
 }
 
@@ -83,14 +113,22 @@ func (j *JWTService) CheckJWT(tokenString string, isRefresh bool) (*JWTClaims, e
 			secret = j.refreshSecret
 		}
 		return []byte(secret), nil
-	})
+	}, jwt.WithIssuer(_issuer))
 
-	if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now()) {
-		return nil, ErrExpiredToken
+	if err == nil && slices.Contains(claims.Audience, _agentAudience) {
+		// Agent credentials are not user access tokens
+		return nil, ErrInvalidToken
 	}
-
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, ErrExpiredToken
+		case errors.Is(err, jwt.ErrTokenMalformed), errors.Is(err, ErrMalformedToken):
+			return nil, ErrMalformedToken
+		default:
+			logger.Debug("JWT validation failed", zap.Error(err))
+			return nil, ErrInvalidToken
+		}
 	}
 
 	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
@@ -99,3 +137,66 @@ func (j *JWTService) CheckJWT(tokenString string, isRefresh bool) (*JWTClaims, e
 
 	return nil, ErrInvalidToken
 }
+
+// GenerateAgentJWT returns the credential of an enrolled host. It doesn't expire, it is
+// invalidated by revoking the host or enrolling it again.
+func (j *JWTService) GenerateAgentJWT(hostID, hostname, credentialID string) (string, error) {
+	claims := &AgentClaims{
+		HostID:   hostID,
+		Hostname: hostname,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       credentialID,
+			Issuer:   _issuer,
+			Subject:  hostname,
+			Audience: jwt.ClaimStrings{_agentAudience},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.secret))
+	if err != nil {
+		logger.Error("Error signing agent JWT", zap.Error(err))
+		return "", err
+	}
+	return signedToken, nil
+}
+
+// CheckAgentJWT validates an agent credential. Whether the host is still enrolled
+// with this credential is up to the caller.
+func (j *JWTService) CheckAgentJWT(tokenString string) (*AgentClaims, error) {
+	claims := &AgentClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrMalformedToken
+		}
+		return []byte(j.secret), nil
+	}, jwt.WithIssuer(_issuer), jwt.WithAudience(_agentAudience))
+
+	if err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrTokenMalformed), errors.Is(err, ErrMalformedToken):
+			return nil, ErrMalformedToken
+		default:
+			logger.Debug("Agent JWT validation failed", zap.Error(err))
+			return nil, ErrInvalidToken
+		}
+	}
+
+	if !token.Valid || claims.HostID == "" || claims.Hostname == "" || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+type claimsContextKey struct{}
+
+// NewContext returns a copy of ctx carrying the validated JWT claims of the caller
+func NewContext(ctx context.Context, claims *JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the validated JWT claims of the caller, if any
+func ClaimsFromContext(ctx context.Context) (*JWTClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*JWTClaims)
+	return claims, ok
+}
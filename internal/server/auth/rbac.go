@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+)
+
+// Permission is an action a role may be allowed to perform
+type Permission string
+
+const (
+	// PermissionMetricsRead allows reading the metrics of the hosts the user can see
+	PermissionMetricsRead Permission = "metrics:read"
+	// PermissionHostsOperate allows acting on the hosts the user can see
+	PermissionHostsOperate Permission = "hosts:operate"
+	// PermissionAdmin allows managing users, tokens and host groups
+	PermissionAdmin Permission = "admin"
+)
+
+var _rolePermissions = map[Role][]Permission{
+	RoleViewer:   {PermissionMetricsRead},
+	RoleOperator: {PermissionMetricsRead, PermissionHostsOperate},
+	RoleAdmin:    {PermissionMetricsRead, PermissionHostsOperate, PermissionAdmin},
+}
+
+var (
+	ErrAccessDenied       = errors.New("access denied")
+	ErrInvalidHostPattern = errors.New("invalid host pattern")
+	ErrInvalidHostTag     = errors.New("invalid host tag")
+)
+
+// Can reports whether the role grants the permission
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(_rolePermissions[r], permission)
+}
+
+// ValidateHostPattern checks that pattern is a valid hostname glob, e.g. "web-*"
+func ValidateHostPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("%w: empty pattern", ErrInvalidHostPattern)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidHostPattern, pattern)
+	}
+	return nil
+}
+
+// ValidateHostTags checks that the tags selecting the hosts of a group have a key, e.g. env=prod
+func ValidateHostTags(tags map[string]string) error {
+	for key := range tags {
+		if key == "" {
+			return fmt.Errorf("%w: empty key", ErrInvalidHostTag)
+		}
+	}
+	return nil
+}
+
+// Access is what an authenticated user is allowed to do and see
+type Access struct {
+	Username string
+	Role     Role
+	// AllHosts is set when the user isn't limited to host groups
+	AllHosts bool
+	// HostPatterns are the hostname globs of the host groups of the user
+	HostPatterns []string
+	// HostTags are the tags selecting the hosts of the host groups of the user, one set per
+	// group: a host having every tag of a set is allowed
+	HostTags []map[string]string
+}
+
+// Can reports whether the role of the user grants the permission
+func (a *Access) Can(permission Permission) bool {
+	return a.Role.Can(permission)
+}
+
+// AllowsHost reports whether the user may see the host, matched by its hostname or its tags
+func (a *Access) AllowsHost(hostname string, tags map[string]string) bool {
+	if a.AllHosts {
+		return true
+	}
+	for _, pattern := range a.HostPatterns {
+		if ok, _ := path.Match(pattern, hostname); ok {
+			return true
+		}
+	}
+	for _, selector := range a.HostTags {
+		if len(selector) > 0 && hasTags(tags, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags reports whether tags has every tag of selector
+func hasTags(tags, selector map[string]string) bool {
+	for key, value := range selector {
+		if tag, ok := tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+	return true
+}
+
+type accessContextKey struct{}
+
+// NewAccessContext returns a copy of ctx carrying the access of the caller
+func NewAccessContext(ctx context.Context, access *Access) context.Context {
+	return context.WithValue(ctx, accessContextKey{}, access)
+}
+
+// AccessFromContext returns the access of the caller, if any
+func AccessFromContext(ctx context.Context) (*Access, bool) {
+	access, ok := ctx.Value(accessContextKey{}).(*Access)
+	return access, ok
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Can(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		expected   bool
+	}{
+		{RoleViewer, PermissionMetricsRead, true},
+		{RoleViewer, PermissionHostsOperate, false},
+		{RoleViewer, PermissionAdmin, false},
+		{RoleOperator, PermissionMetricsRead, true},
+		{RoleOperator, PermissionHostsOperate, true},
+		{RoleOperator, PermissionAdmin, false},
+		{RoleAdmin, PermissionMetricsRead, true},
+		{RoleAdmin, PermissionHostsOperate, true},
+		{RoleAdmin, PermissionAdmin, true},
+		{Role("unknown"), PermissionMetricsRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.permission), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.role.Can(tt.permission))
+		})
+	}
+}
+
+func TestAccess_AllowsHost(t *testing.T) {
+	all := &Access{Role: RoleViewer, AllHosts: true}
+	assert.True(t, all.AllowsHost("anything", nil))
+
+	limited := &Access{Role: RoleViewer, HostPatterns: []string{"web-*", "db-1"}}
+	assert.True(t, limited.AllowsHost("web-1", nil))
+	assert.True(t, limited.AllowsHost("db-1", nil))
+	assert.False(t, limited.AllowsHost("db-2", nil))
+	assert.False(t, limited.AllowsHost("", nil))
+
+	tagged := &Access{
+		Role:         RoleViewer,
+		HostPatterns: []string{"web-*"},
+		HostTags:     []map[string]string{{"env": "prod", "team": "db"}, {}},
+	}
+	assert.True(t, tagged.AllowsHost("web-1", nil))
+	assert.True(t, tagged.AllowsHost("db-2", map[string]string{"env": "prod", "team": "db", "az": "a"}))
+	assert.False(t, tagged.AllowsHost("db-2", map[string]string{"env": "prod"}), "every tag of a group is required")
+	assert.False(t, tagged.AllowsHost("db-2", map[string]string{"env": "staging", "team": "db"}))
+	assert.False(t, tagged.AllowsHost("db-2", nil), "a group without tags selects no host by tag")
+
+	// A user limited to groups without hosts sees nothing
+	none := &Access{Role: RoleAdmin}
+	assert.False(t, none.AllowsHost("web-1", nil))
+}
+
+func TestValidateHostPattern(t *testing.T) {
+	assert.NoError(t, ValidateHostPattern("web-*"))
+	assert.NoError(t, ValidateHostPattern("db-[0-9]"))
+	assert.ErrorIs(t, ValidateHostPattern(""), ErrInvalidHostPattern)
+	assert.ErrorIs(t, ValidateHostPattern("db-["), ErrInvalidHostPattern)
+}
+
+func TestValidateHostTags(t *testing.T) {
+	assert.NoError(t, ValidateHostTags(map[string]string{"env": "prod", "canary": ""}))
+	assert.NoError(t, ValidateHostTags(nil))
+	assert.ErrorIs(t, ValidateHostTags(map[string]string{"": "prod"}), ErrInvalidHostTag)
+}
+
+func TestAccessContext(t *testing.T) {
+	_, ok := AccessFromContext(context.Background())
+	assert.False(t, ok)
+
+	access := &Access{Username: "alice", Role: RoleAdmin, AllHosts: true}
+	got, ok := AccessFromContext(NewAccessContext(context.Background(), access))
+	require.True(t, ok)
+	assert.Equal(t, access, got)
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Role is the set of permissions granted to a user
+type Role string
+
+const (
+	// RoleViewer can read metrics
+	RoleViewer Role = "viewer"
+	// RoleOperator can read metrics and act on hosts
+	RoleOperator Role = "operator"
+	// RoleAdmin can do everything, including managing users and host groups
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists every role, from the least to the most privileged
+var Roles = []Role{RoleViewer, RoleOperator, RoleAdmin}
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// ParseRole returns the role named s
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if !slices.Contains(Roles, role) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, s)
+	}
+	return role, nil
+}
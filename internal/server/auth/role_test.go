@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	for _, role := range Roles {
+		parsed, err := ParseRole(string(role))
+		require.NoError(t, err)
+		assert.Equal(t, role, parsed)
+	}
+
+	_, err := ParseRole("root")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	_, err = ParseRole("")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
@@ -0,0 +1,317 @@
+// Package gateway serves the gRPC services of the server as a JSON API over HTTP
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// PathPrefix is the prefix of every path of the gateway
+	PathPrefix = "/api/v1/"
+	// _maxRequestBytes is the largest request body accepted, the default limit of gRPC
+	_maxRequestBytes = 4 << 20
+)
+
+var (
+	_marshal   = protojson.MarshalOptions{UseProtoNames: true}
+	_unmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// method is a unary or server streaming method of a registered service
+type method struct {
+	fullMethod string
+	impl       interface{}
+	unary      *grpc.MethodDesc
+	stream     *grpc.StreamDesc
+}
+
+// Gateway is an HTTP handler calling the registered gRPC services in process. Every method is
+// served at POST /api/v1/<service>/<method> with its request as JSON body, e.g.
+// /api/v1/host.HostService/ListHosts, and the same interceptors as the gRPC server apply.
+// Server streaming methods answer with one JSON message per line. Client streaming methods,
+// only used by the agents, are not served.
+type Gateway struct {
+	methods           map[string]*method
+	routes            []route
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+	ctx               context.Context
+	cancel            context.CancelFunc
+}
+
+// New returns a gateway running the interceptors, in order, around every call
+func New(unaryInterceptors []grpc.UnaryServerInterceptor, streamInterceptors []grpc.StreamServerInterceptor) *Gateway {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Gateway{
+		methods:           make(map[string]*method),
+		routes:            _routes,
+		unaryInterceptor:  chainUnary(unaryInterceptors),
+		streamInterceptor: chainStream(streamInterceptors),
+		ctx:               ctx,
+		cancel:            cancel,
+	}
+}
+
+// RegisterService implements grpc.ServiceRegistrar so the handlers register on the gateway
+// the same way they register on the gRPC server
+func (g *Gateway) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	for i := range desc.Methods {
+		fullMethod := "/" + desc.ServiceName + "/" + desc.Methods[i].MethodName
+		g.methods[fullMethod] = &method{fullMethod: fullMethod, impl: impl, unary: &desc.Methods[i]}
+	}
+	for i := range desc.Streams {
+		if desc.Streams[i].ClientStreams {
+			continue
+		}
+		fullMethod := "/" + desc.ServiceName + "/" + desc.Streams[i].StreamName
+		g.methods[fullMethod] = &method{fullMethod: fullMethod, impl: impl, stream: &desc.Streams[i]}
+	}
+}
+
+// Shutdown ends the streaming responses in progress
+func (g *Gateway) Shutdown() {
+	g.cancel()
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, PathPrefix) {
+		writeError(w, status.Error(codes.NotFound, "not found"))
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		if fullMethod, req, ok, err := g.route(r); ok {
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			g.call(w, r, g.methods[fullMethod], func(m interface{}) error {
+				proto.Merge(m.(proto.Message), req)
+				return nil
+			})
+			return
+		}
+	}
+
+	m, ok := g.methods["/"+strings.TrimPrefix(r.URL.Path, PathPrefix)]
+	if !ok {
+		writeError(w, status.Error(codes.NotFound, "unknown method"))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, status.Error(codes.Unimplemented, "methods are called with POST"))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, _maxRequestBytes))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "failed to read request body"))
+		return
+	}
+	g.call(w, r, m, func(m interface{}) error {
+		if len(body) == 0 {
+			return nil
+		}
+		if err := _unmarshal.Unmarshal(body, m.(proto.Message)); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+		}
+		return nil
+	})
+}
+
+// call runs the method with the request decoded by dec and writes its response
+func (g *Gateway) call(w http.ResponseWriter, r *http.Request, m *method, dec func(interface{}) error) {
+	ctx := incomingContext(r)
+
+	if m.unary != nil {
+		res, err := m.unary.Handler(m.impl, ctx, dec, g.unaryInterceptor)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res.(proto.Message))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-g.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream := &serverStream{ctx: ctx, w: w, dec: dec}
+	info := &grpc.StreamServerInfo{FullMethod: m.fullMethod, IsServerStream: true}
+	err := g.streamInterceptor(m.impl, stream, info, m.stream.Handler)
+	switch {
+	case err == nil:
+	case !stream.started:
+		writeError(w, err)
+	default:
+		// The status is already sent, report the error as the last line
+		_ = stream.writeLine(errorBody(err))
+	}
+}
+
+// incomingContext returns the context of the request as the gRPC server would build it,
+// carrying the Authorization header as metadata and the client address as peer
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		md.Set("authorization", authorization)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return ctx
+}
+
+// serverStream is a server stream writing every message as a line of JSON
+type serverStream struct {
+	ctx      context.Context
+	w        http.ResponseWriter
+	dec      func(interface{}) error
+	received bool
+	started  bool
+}
+
+func (s *serverStream) SetHeader(metadata.MD) error  { return nil }
+func (s *serverStream) SendHeader(metadata.MD) error { return nil }
+func (s *serverStream) SetTrailer(metadata.MD)       {}
+func (s *serverStream) Context() context.Context     { return s.ctx }
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	data, err := _marshal.Marshal(m.(proto.Message))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	return s.writeLine(data)
+}
+
+// RecvMsg decodes the request once, the gateway only serves server streams
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+	return s.dec(m)
+}
+
+func (s *serverStream) writeLine(data []byte) error {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "application/x-ndjson")
+		s.w.WriteHeader(http.StatusOK)
+	}
+	if _, err := s.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, m proto.Message) {
+	data, err := _marshal.Marshal(m)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(status.Code(err)))
+	_, _ = w.Write(errorBody(err))
+}
+
+// errorBody is the JSON body of an error, e.g. {"code":"NotFound","message":"host not found"}
+func errorBody(err error) []byte {
+	st := status.Convert(err)
+	data, _ := json.Marshal(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{st.Code().String(), st.Message()})
+	return data
+}
+
+// HTTPStatus returns the HTTP status matching a gRPC code
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// chainUnary returns an interceptor running the interceptors in order
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// chainStream returns an interceptor running the interceptors in order
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pbhost "github.com/theotruvelot/g0s/pkg/proto/host"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeHostService struct {
+	pbhost.UnimplementedHostServiceServer
+	tags map[string]string
+}
+
+func (s *fakeHostService) ListHosts(_ context.Context, req *pbhost.ListHostsRequest) (*pbhost.ListHostsResponse, error) {
+	s.tags = req.Tags
+	return &pbhost.ListHostsResponse{Hosts: []*pbhost.Host{{Hostname: "web-1"}}}, nil
+}
+
+func (s *fakeHostService) GetHost(_ context.Context, req *pbhost.GetHostRequest) (*pbhost.Host, error) {
+	if req.Hostname != "web-1" {
+		return nil, status.Error(codes.NotFound, "host not found")
+	}
+	return &pbhost.Host{Hostname: "web-1", IpAddress: "10.0.0.1", State: pbhost.HostState_HOST_STATE_ONLINE}, nil
+}
+
+func (s *fakeHostService) WatchHostEvents(req *pbhost.WatchHostEventsRequest, stream grpc.ServerStreamingServer[pbhost.HostEvent]) error {
+	for _, hostname := range []string{"web-1", "web-2"} {
+		if err := stream.Send(&pbhost.HostEvent{Hostname: hostname, State: pbhost.HostState_HOST_STATE_OFFLINE}); err != nil {
+			return err
+		}
+	}
+	if req.HostFilter == "fail" {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return nil
+}
+
+// checkToken stands in for the auth interceptors: only the "good" bearer token is accepted
+func checkToken(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) == 0 || values[0] != "Bearer good" {
+		return status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+	return nil
+}
+
+func newTestGateway(t *testing.T) (*httptest.Server, *fakeHostService) {
+	var calls []string
+	g := New(
+		[]grpc.UnaryServerInterceptor{
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				calls = append(calls, "first")
+				return handler(ctx, req)
+			},
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				require.Equal(t, []string{"first"}, calls[len(calls)-1:], "interceptors run in order")
+				if err := checkToken(ctx); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			},
+		},
+		[]grpc.StreamServerInterceptor{
+			func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := checkToken(ss.Context()); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			},
+		},
+	)
+	hosts := &fakeHostService{}
+	pbhost.RegisterHostServiceServer(g, hosts)
+
+	server := httptest.NewServer(g)
+	t.Cleanup(server.Close)
+	return server, hosts
+}
+
+func do(t *testing.T, method, url, token, body string) (*http.Response, map[string]interface{}) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&decoded))
+	return res, decoded
+}
+
+func TestGateway_Unary(t *testing.T) {
+	server, _ := newTestGateway(t)
+	url := server.URL + "/api/v1/host.HostService/GetHost"
+
+	res, body := do(t, http.MethodPost, url, "good", `{"hostname": "web-1"}`)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	assert.Equal(t, "web-1", body["hostname"])
+	assert.Equal(t, "10.0.0.1", body["ip_address"], "fields are named as in the proto files")
+	assert.Equal(t, "HOST_STATE_ONLINE", body["state"])
+
+	res, body = do(t, http.MethodPost, url, "", `{"hostname": "web-1"}`)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, "Unauthenticated", body["code"])
+
+	res, body = do(t, http.MethodPost, url, "good", `{"hostname": "db-1"}`)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "host not found", body["message"])
+
+	res, _ = do(t, http.MethodPost, url, "good", `{"hostname":`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, _ = do(t, http.MethodGet, url, "good", "")
+	assert.Equal(t, http.StatusNotImplemented, res.StatusCode)
+
+	res, _ = do(t, http.MethodPost, server.URL+"/api/v1/host.HostService/DeleteHost", "good", "{}")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	res, _ = do(t, http.MethodGet, server.URL+"/metrics", "good", "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestGateway_Routes(t *testing.T) {
+	server, hosts := newTestGateway(t)
+
+	res, body := do(t, http.MethodGet, server.URL+"/api/v1/hosts?tag=env=prod&tag=team=web", "good", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Len(t, body["hosts"], 1)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "web"}, hosts.tags)
+
+	res, _ = do(t, http.MethodGet, server.URL+"/api/v1/hosts?tag=prod", "good", "")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, body = do(t, http.MethodGet, server.URL+"/api/v1/hosts/web-1", "good", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "web-1", body["hostname"])
+
+	res, _ = do(t, http.MethodGet, server.URL+"/api/v1/hosts/web-1", "", "")
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res, _ = do(t, http.MethodGet, server.URL+"/api/v1/alerts", "good", "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, "routes of services not registered are not served")
+}
+
+func TestGateway_ServerStream(t *testing.T) {
+	server, _ := newTestGateway(t)
+	url := server.URL + "/api/v1/host.HostService/WatchHostEvents"
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"host_filter": "fail"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer good")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 3)
+	assert.Equal(t, "web-1", lines[0]["hostname"])
+	assert.Equal(t, "web-2", lines[1]["hostname"])
+	assert.Equal(t, "Unavailable", lines[2]["code"], "errors after the first message end the stream")
+
+	res, body := do(t, http.MethodPost, url, "", "{}")
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Equal(t, "Unauthenticated", body["code"])
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusOK, HTTPStatus(codes.OK))
+	assert.Equal(t, http.StatusBadRequest, HTTPStatus(codes.InvalidArgument))
+	assert.Equal(t, http.StatusForbidden, HTTPStatus(codes.PermissionDenied))
+	assert.Equal(t, http.StatusConflict, HTTPStatus(codes.Aborted))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus(codes.Internal))
+}
@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	pbalert "github.com/theotruvelot/g0s/pkg/proto/alert"
+	pbcontrol "github.com/theotruvelot/g0s/pkg/proto/control"
+	pbhost "github.com/theotruvelot/g0s/pkg/proto/host"
+	pbmetric "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// route is a GET shortcut to a method, its request being built from the path and query
+type route struct {
+	// path follows PathPrefix, a trailing slash means the rest of the path is a parameter
+	path       string
+	fullMethod string
+	bind       func(param string, r *http.Request) (proto.Message, error)
+}
+
+// _routes are the GET shortcuts to the read methods, for scripts and curl
+var _routes = []route{
+	{
+		// /api/v1/hosts?tag=env=prod
+		path:       "hosts",
+		fullMethod: pbhost.HostService_ListHosts_FullMethodName,
+		bind: func(_ string, r *http.Request) (proto.Message, error) {
+			tags := make(map[string]string)
+			for _, tag := range r.URL.Query()["tag"] {
+				key, value, ok := strings.Cut(tag, "=")
+				if !ok {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q, expected key=value", tag)
+				}
+				tags[key] = value
+			}
+			return &pbhost.ListHostsRequest{Tags: tags}, nil
+		},
+	},
+	{
+		// /api/v1/hosts/web-1
+		path:       "hosts/",
+		fullMethod: pbhost.HostService_GetHost_FullMethodName,
+		bind: func(hostname string, _ *http.Request) (proto.Message, error) {
+			return &pbhost.GetHostRequest{Hostname: hostname}, nil
+		},
+	},
+	{
+		// /api/v1/metrics?host=web-.*&type=cpu
+		path:       "metrics",
+		fullMethod: pbmetric.MetricService_GetMetrics_FullMethodName,
+		bind: func(_ string, r *http.Request) (proto.Message, error) {
+			query := r.URL.Query()
+			return &pbmetric.MetricsRequest{HostFilter: query.Get("host"), MetricType: query.Get("type")}, nil
+		},
+	},
+	{
+		// /api/v1/alerts?state=firing&host=web-.*&limit=50
+		path:       "alerts",
+		fullMethod: pbalert.AlertService_ListAlerts_FullMethodName,
+		bind: func(_ string, r *http.Request) (proto.Message, error) {
+			query := r.URL.Query()
+			req := &pbalert.ListAlertsRequest{HostFilter: query.Get("host")}
+			if state := query.Get("state"); state != "" {
+				value, ok := pbalert.AlertState_value["ALERT_STATE_"+strings.ToUpper(state)]
+				if !ok {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid alert state %q", state)
+				}
+				req.State = pbalert.AlertState(value)
+			}
+			if limit := query.Get("limit"); limit != "" {
+				value, err := strconv.ParseInt(limit, 10, 32)
+				if err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid limit %q", limit)
+				}
+				req.Limit = int32(value)
+			}
+			return req, nil
+		},
+	},
+	{
+		path:       "alert-rules",
+		fullMethod: pbalert.AlertService_ListAlertRules_FullMethodName,
+		bind: func(_ string, _ *http.Request) (proto.Message, error) {
+			return &pbalert.ListAlertRulesRequest{}, nil
+		},
+	},
+	{
+		// /api/v1/silences?all=true
+		path:       "silences",
+		fullMethod: pbalert.AlertService_ListSilences_FullMethodName,
+		bind: func(_ string, r *http.Request) (proto.Message, error) {
+			all, _ := strconv.ParseBool(r.URL.Query().Get("all"))
+			return &pbalert.ListSilencesRequest{IncludeExpired: all}, nil
+		},
+	},
+	{
+		path:       "maintenance-windows",
+		fullMethod: pbalert.AlertService_ListMaintenanceWindows_FullMethodName,
+		bind: func(_ string, _ *http.Request) (proto.Message, error) {
+			return &pbalert.ListMaintenanceWindowsRequest{}, nil
+		},
+	},
+	{
+		// /api/v1/actions?host=web-1&limit=20
+		path:       "actions",
+		fullMethod: pbcontrol.ControlService_ListActions_FullMethodName,
+		bind: func(_ string, r *http.Request) (proto.Message, error) {
+			query := r.URL.Query()
+			req := &pbcontrol.ListActionsRequest{Hostname: query.Get("host")}
+			if limit := query.Get("limit"); limit != "" {
+				value, err := strconv.ParseInt(limit, 10, 32)
+				if err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid limit %q", limit)
+				}
+				req.Limit = int32(value)
+			}
+			return req, nil
+		},
+	},
+}
+
+// route returns the method and request of the GET shortcut matching the request, if any
+func (g *Gateway) route(r *http.Request) (string, proto.Message, bool, error) {
+	path := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	for _, rt := range g.routes {
+		var param string
+		if prefix, ok := strings.CutSuffix(rt.path, "/"); ok {
+			rest, found := strings.CutPrefix(path, prefix+"/")
+			if !found || rest == "" || strings.Contains(rest, "/") {
+				continue
+			}
+			param = rest
+		} else if path != rt.path {
+			continue
+		}
+
+		if _, registered := g.methods[rt.fullMethod]; !registered {
+			return "", nil, false, nil
+		}
+		req, err := rt.bind(param, r)
+		return rt.fullMethod, req, true, err
+	}
+	return "", nil, false, nil
+}
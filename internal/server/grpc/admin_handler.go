@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/admin"
+	"google.golang.org/grpc"
+)
+
+type AdminHandler struct {
+	pb.UnimplementedAdminServiceServer
+	service *service.AdminService
+}
+
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		service: adminService,
+	}
+}
+
+func (h *AdminHandler) RegisterServices(server grpc.ServiceRegistrar) {
+	pb.RegisterAdminServiceServer(server, h)
+	logger.Debug("Admin gRPC service registered")
+}
+
+func (h *AdminHandler) Shutdown() {}
+
+func (h *AdminHandler) NotifyShutdown() {}
+
+func (h *AdminHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	return h.service.CreateUser(ctx, req)
+}
+
+func (h *AdminHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	return h.service.ListUsers(ctx, req)
+}
+
+func (h *AdminHandler) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.User, error) {
+	return h.service.DisableUser(ctx, req)
+}
+
+func (h *AdminHandler) IssueToken(ctx context.Context, req *pb.IssueTokenRequest) (*pb.IssueTokenResponse, error) {
+	return h.service.IssueToken(ctx, req)
+}
+
+func (h *AdminHandler) ListTokens(ctx context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
+	return h.service.ListTokens(ctx, req)
+}
+
+func (h *AdminHandler) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.ApiToken, error) {
+	return h.service.RevokeToken(ctx, req)
+}
+
+func (h *AdminHandler) CreateJoinToken(ctx context.Context, req *pb.CreateJoinTokenRequest) (*pb.CreateJoinTokenResponse, error) {
+	return h.service.CreateJoinToken(ctx, req)
+}
+
+func (h *AdminHandler) RevokeAgent(ctx context.Context, req *pb.RevokeAgentRequest) (*pb.Agent, error) {
+	return h.service.RevokeAgent(ctx, req)
+}
+
+func (h *AdminHandler) DecommissionHost(ctx context.Context, req *pb.DecommissionHostRequest) (*pb.DecommissionHostResponse, error) {
+	return h.service.DecommissionHost(ctx, req)
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/alert"
+	"google.golang.org/grpc"
+)
+
+type AlertHandler struct {
+	pb.UnimplementedAlertServiceServer
+	service        *service.AlertService
+	silenceService *service.SilenceService
+}
+
+func NewAlertHandler(alertService *service.AlertService, silenceService *service.SilenceService) *AlertHandler {
+	return &AlertHandler{
+		service:        alertService,
+		silenceService: silenceService,
+	}
+}
+
+func (h *AlertHandler) RegisterServices(server grpc.ServiceRegistrar) {
+	pb.RegisterAlertServiceServer(server, h)
+	logger.Debug("Alert gRPC service registered")
+}
+
+func (h *AlertHandler) Shutdown() {
+	h.service.Shutdown()
+	h.silenceService.Shutdown()
+}
+
+func (h *AlertHandler) NotifyShutdown() {
+	h.service.Shutdown()
+	h.silenceService.Shutdown()
+}
+
+func (h *AlertHandler) ListAlerts(ctx context.Context, req *pb.ListAlertsRequest) (*pb.ListAlertsResponse, error) {
+	return h.service.ListAlerts(ctx, req)
+}
+
+func (h *AlertHandler) ListAlertRules(ctx context.Context, req *pb.ListAlertRulesRequest) (*pb.ListAlertRulesResponse, error) {
+	return h.service.ListAlertRules(ctx, req)
+}
+
+func (h *AlertHandler) PutAlertRule(ctx context.Context, req *pb.AlertRule) (*pb.AlertRule, error) {
+	return h.service.PutAlertRule(ctx, req)
+}
+
+func (h *AlertHandler) DeleteAlertRule(ctx context.Context, req *pb.DeleteAlertRuleRequest) (*pb.DeleteAlertRuleResponse, error) {
+	return h.service.DeleteAlertRule(ctx, req)
+}
+
+func (h *AlertHandler) CreateSilence(ctx context.Context, req *pb.Silence) (*pb.Silence, error) {
+	return h.silenceService.CreateSilence(ctx, req)
+}
+
+func (h *AlertHandler) ListSilences(ctx context.Context, req *pb.ListSilencesRequest) (*pb.ListSilencesResponse, error) {
+	return h.silenceService.ListSilences(ctx, req)
+}
+
+func (h *AlertHandler) ExpireSilence(ctx context.Context, req *pb.ExpireSilenceRequest) (*pb.Silence, error) {
+	return h.silenceService.ExpireSilence(ctx, req)
+}
+
+func (h *AlertHandler) PutMaintenanceWindow(ctx context.Context, req *pb.MaintenanceWindow) (*pb.MaintenanceWindow, error) {
+	return h.silenceService.PutMaintenanceWindow(ctx, req)
+}
+
+func (h *AlertHandler) ListMaintenanceWindows(ctx context.Context, req *pb.ListMaintenanceWindowsRequest) (*pb.ListMaintenanceWindowsResponse, error) {
+	return h.silenceService.ListMaintenanceWindows(ctx, req)
+}
+
+func (h *AlertHandler) DeleteMaintenanceWindow(ctx context.Context, req *pb.DeleteMaintenanceWindowRequest) (*pb.DeleteMaintenanceWindowResponse, error) {
+	return h.silenceService.DeleteMaintenanceWindow(ctx, req)
+}
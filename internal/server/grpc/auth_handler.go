@@ -8,25 +8,29 @@ import (
 	"github.com/theotruvelot/g0s/internal/server/service"
 	pb "github.com/theotruvelot/g0s/pkg/proto/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthHandler struct {
-	AuthService *service.AuthService
+	AuthService       *service.AuthService
+	EnrollmentService *service.EnrollmentService
 	pb.UnimplementedAuthServiceServer
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+func NewAuthHandler(authService *service.AuthService, enrollmentService *service.EnrollmentService) *AuthHandler {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &AuthHandler{
-		AuthService: authService,
-		ctx:         ctx,
-		cancel:      cancel,
+		AuthService:       authService,
+		EnrollmentService: enrollmentService,
+		ctx:               ctx,
+		cancel:            cancel,
 	}
 }
 
-func (h *AuthHandler) RegisterServices(server *grpc.Server) {
+func (h *AuthHandler) RegisterServices(server grpc.ServiceRegistrar) {
 	pb.RegisterAuthServiceServer(server, h)
 	logger.Debug("Auth gRPC service registered")
 }
@@ -58,3 +62,42 @@ func (h *AuthHandler) Authenticate(ctx context.Context, req *pb.AuthenticateRequ
 		JwtRefreshToken: token.RefreshToken,
 	}, nil
 }
+
+func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	token, err := h.AuthService.RefreshToken(req.JwtRefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			return &pb.RefreshTokenResponse{
+				Status: pb.RefreshTokenResponse_INVALID_REFRESH_TOKEN,
+			}, nil
+		}
+		return &pb.RefreshTokenResponse{
+			Status: pb.RefreshTokenResponse_ERROR,
+		}, err
+	}
+
+	return &pb.RefreshTokenResponse{
+		Status:          pb.RefreshTokenResponse_OK,
+		JwtToken:        token.Token,
+		JwtRefreshToken: token.RefreshToken,
+	}, nil
+}
+
+func (h *AuthHandler) EnrollAgent(ctx context.Context, req *pb.EnrollAgentRequest) (*pb.EnrollAgentResponse, error) {
+	host, credential, err := h.EnrollmentService.EnrollAgent(req.JoinToken, req.Hostname)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidJoinToken):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrInvalidHostname):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, "failed to enroll agent")
+		}
+	}
+
+	return &pb.EnrollAgentResponse{
+		HostId:     host.ID.String(),
+		Credential: credential,
+	}, nil
+}
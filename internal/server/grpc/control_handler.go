@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/control"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ControlHandler struct {
+	pb.UnimplementedControlServiceServer
+	service *service.ControlService
+}
+
+func NewControlHandler(controlService *service.ControlService) *ControlHandler {
+	return &ControlHandler{
+		service: controlService,
+	}
+}
+
+func (h *ControlHandler) RegisterServices(server grpc.ServiceRegistrar) {
+	pb.RegisterControlServiceServer(server, h)
+	logger.Debug("Control gRPC service registered")
+}
+
+func (h *ControlHandler) Shutdown() {
+	h.service.Shutdown()
+}
+
+func (h *ControlHandler) NotifyShutdown() {
+	h.service.Shutdown()
+}
+
+func (h *ControlHandler) AgentControl(stream pb.ControlService_AgentControlServer) error {
+	ctx := stream.Context()
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := msg.GetHello()
+	if hello == nil {
+		return status.Error(codes.InvalidArgument, "the first message must be the hello of the agent")
+	}
+	if err := auth.VerifyAgentHostname(ctx, hello.Hostname); err != nil {
+		logger.Warn("Rejecting control stream from agent with mismatching certificate",
+			zap.String("hostname", hello.Hostname),
+			zap.Error(err))
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return h.service.AgentControl(ctx, hello.Hostname, stream.Send, stream.Recv)
+}
+
+func (h *ControlHandler) SignalProcess(ctx context.Context, req *pb.SignalProcessRequest) (*pb.ActionResult, error) {
+	return h.service.SignalProcess(ctx, req)
+}
+
+func (h *ControlHandler) ControlUnit(ctx context.Context, req *pb.ControlUnitRequest) (*pb.ActionResult, error) {
+	return h.service.ControlUnit(ctx, req)
+}
+
+func (h *ControlHandler) ListActions(ctx context.Context, req *pb.ListActionsRequest) (*pb.ListActionsResponse, error) {
+	return h.service.ListActions(ctx, req)
+}
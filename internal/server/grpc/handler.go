@@ -12,6 +12,10 @@ import (
 // Handler orchestrates all gRPC handlers
 type Handler struct {
 	authHandler        *AuthHandler
+	adminHandler       *AdminHandler
+	hostHandler        *HostHandler
+	alertHandler       *AlertHandler
+	controlHandler     *ControlHandler
 	metricsHandler     *MetricsHandler
 	healthCheckHandler *HealthCheckHandler
 	ctx                context.Context
@@ -19,13 +23,17 @@ type Handler struct {
 }
 
 // New creates a new handler orchestrator
-func New(store *metrics.Manager, authService *service.AuthService, healthCheckService *service.HealthCheckService) *Handler {
+func New(store *metrics.Manager, authService *service.AuthService, enrollmentService *service.EnrollmentService, adminService *service.AdminService, hostService *service.HostService, alertService *service.AlertService, silenceService *service.SilenceService, controlService *service.ControlService, healthCheckService *service.HealthCheckService) *Handler {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	metricService := service.NewMetricService(store)
+	metricService := service.NewMetricService(store, hostService, alertService)
 
 	return &Handler{
-		authHandler:        NewAuthHandler(authService),
+		authHandler:        NewAuthHandler(authService, enrollmentService),
+		adminHandler:       NewAdminHandler(adminService),
+		hostHandler:        NewHostHandler(hostService),
+		alertHandler:       NewAlertHandler(alertService, silenceService),
+		controlHandler:     NewControlHandler(controlService),
 		metricsHandler:     NewMetricsHandler(metricService),
 		healthCheckHandler: NewHealthCheckHandler(healthCheckService),
 		ctx:                ctx,
@@ -33,9 +41,13 @@ func New(store *metrics.Manager, authService *service.AuthService, healthCheckSe
 	}
 }
 
-// RegisterServices registers all gRPC services
-func (h *Handler) RegisterServices(server *grpc.Server) {
+// RegisterServices registers all gRPC services, on the gRPC server or the HTTP gateway
+func (h *Handler) RegisterServices(server grpc.ServiceRegistrar) {
 	h.authHandler.RegisterServices(server)
+	h.adminHandler.RegisterServices(server)
+	h.hostHandler.RegisterServices(server)
+	h.alertHandler.RegisterServices(server)
+	h.controlHandler.RegisterServices(server)
 	h.metricsHandler.RegisterServices(server)
 	h.healthCheckHandler.RegisterServices(server)
 	logger.Debug("All gRPC services registered")
@@ -45,6 +57,10 @@ func (h *Handler) RegisterServices(server *grpc.Server) {
 func (h *Handler) Shutdown() {
 	logger.Info("Shutting down all gRPC handlers")
 	h.authHandler.Shutdown()
+	h.adminHandler.Shutdown()
+	h.hostHandler.Shutdown()
+	h.alertHandler.Shutdown()
+	h.controlHandler.Shutdown()
 	h.metricsHandler.Shutdown()
 	h.healthCheckHandler.Shutdown()
 	h.cancel()
@@ -54,6 +70,10 @@ func (h *Handler) Shutdown() {
 func (h *Handler) NotifyShutdown() {
 	logger.Info("Notifying all handlers about server shutdown")
 	h.authHandler.NotifyShutdown()
+	h.adminHandler.NotifyShutdown()
+	h.hostHandler.NotifyShutdown()
+	h.alertHandler.NotifyShutdown()
+	h.controlHandler.NotifyShutdown()
 	h.metricsHandler.NotifyShutdown()
 	h.healthCheckHandler.NotifyShutdown()
 	h.cancel()
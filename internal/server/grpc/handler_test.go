@@ -4,16 +4,27 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap/zaptest"
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/internal/server/storage/metrics"
 	"google.golang.org/grpc"
 )
 
+func newTestHandler() *Handler {
+	hostService := service.NewHostService(nil, 0)
+	return New(metrics.NewMetricsManager("http://localhost:8428"), nil, nil, nil, hostService, service.NewAlertService(nil, nil, nil, nil, nil), service.NewSilenceService(nil, nil, nil), service.NewControlService(nil, nil), service.NewHealthCheckService(hostService))
+}
+
 func TestNew(t *testing.T) {
-	logger := zaptest.NewLogger(t)
-	handler := New(logger, nil)
+	handler := newTestHandler()
 
 	assert.NotNil(t, handler)
-	assert.Equal(t, logger, handler.logger)
+	assert.NotNil(t, handler.authHandler)
+	assert.NotNil(t, handler.adminHandler)
+	assert.NotNil(t, handler.hostHandler)
+	assert.NotNil(t, handler.alertHandler)
+	assert.NotNil(t, handler.controlHandler)
+	assert.NotNil(t, handler.metricsHandler)
+	assert.NotNil(t, handler.healthCheckHandler)
 }
 
 func TestHandler_RegisterServices(t *testing.T) {
@@ -27,8 +38,7 @@ func TestHandler_RegisterServices(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			logger := zaptest.NewLogger(t)
-			handler := New(logger, nil)
+			handler := newTestHandler()
 			server := grpc.NewServer()
 
 			// This should not panic
@@ -38,35 +48,21 @@ func TestHandler_RegisterServices(t *testing.T) {
 
 			// Verify server is still valid
 			assert.NotNil(t, server)
+			assert.Contains(t, server.GetServiceInfo(), "metric.MetricService")
+			assert.Contains(t, server.GetServiceInfo(), "health.HealthService")
+			assert.Contains(t, server.GetServiceInfo(), "auth.AuthService")
+			assert.Contains(t, server.GetServiceInfo(), "admin.AdminService")
+			assert.Contains(t, server.GetServiceInfo(), "host.HostService")
+			assert.Contains(t, server.GetServiceInfo(), "alert.AlertService")
 		})
 	}
 }
 
-func TestHandler_WithLogger(t *testing.T) {
-	// Create a custom logger to verify it's being used
-	logger := zaptest.NewLogger(t)
-	handler := New(logger, nil)
-
-	// Verify the logger is properly set
-	assert.Equal(t, logger, handler.logger)
-
-	// Test that the handler works with the logger
-	server := grpc.NewServer()
-
-	// This should not panic and should work correctly
-	assert.NotPanics(t, func() {
-		handler.RegisterServices(server)
-	})
-}
-
-func TestHandler_MultipleRegistrations(t *testing.T) {
-	logger := zaptest.NewLogger(t)
-	handler := New(logger, nil)
-	server := grpc.NewServer()
+func TestHandler_Shutdown(t *testing.T) {
+	handler := newTestHandler()
 
-	// Register services multiple times should not panic
 	assert.NotPanics(t, func() {
-		handler.RegisterServices(server)
-		handler.RegisterServices(server)
+		handler.NotifyShutdown()
+		handler.Shutdown()
 	})
 }
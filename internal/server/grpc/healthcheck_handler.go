@@ -4,11 +4,15 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
 	"github.com/theotruvelot/g0s/internal/server/service"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	"github.com/theotruvelot/g0s/pkg/proto/health"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type HealthCheckHandler struct {
@@ -22,7 +26,7 @@ func NewHealthCheckHandler(svc *service.HealthCheckService) *HealthCheckHandler
 	}
 }
 
-func (h *HealthCheckHandler) RegisterServices(server *grpc.Server) {
+func (h *HealthCheckHandler) RegisterServices(server grpc.ServiceRegistrar) {
 	health.RegisterHealthServiceServer(server, h)
 	logger.Debug("Health check gRPC service registered")
 }
@@ -39,9 +43,24 @@ func (h *HealthCheckHandler) Check(ctx context.Context, req *health.HealthCheckR
 	return h.service.Check(ctx, req)
 }
 
+func (h *HealthCheckHandler) Hello(ctx context.Context, req *health.HelloRequest) (*health.HelloResponse, error) {
+	addr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+	return h.service.Hello(ctx, req, addr)
+}
+
 func (h *HealthCheckHandler) Watch(req *health.HealthCheckRequest, stream health.HealthService_WatchServer) error {
 	logger.Info("New health watch stream started")
 	ctx := stream.Context()
+	if err := auth.VerifyAgentHostname(ctx, req.Hostname); err != nil {
+		logger.Warn("Rejecting health watch from agent with mismatching certificate",
+			zap.String("hostname", req.Hostname),
+			zap.Error(err))
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	clientID := uuid.New().String()
 	p, _ := peer.FromContext(ctx)
 	ip := ""
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/theotruvelot/g0s/internal/server/service"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/host"
+	"google.golang.org/grpc"
+)
+
+type HostHandler struct {
+	pb.UnimplementedHostServiceServer
+	service *service.HostService
+}
+
+func NewHostHandler(hostService *service.HostService) *HostHandler {
+	return &HostHandler{
+		service: hostService,
+	}
+}
+
+func (h *HostHandler) RegisterServices(server grpc.ServiceRegistrar) {
+	pb.RegisterHostServiceServer(server, h)
+	logger.Debug("Host gRPC service registered")
+}
+
+func (h *HostHandler) Shutdown() {
+	h.service.Shutdown()
+}
+
+func (h *HostHandler) NotifyShutdown() {
+	h.service.Shutdown()
+}
+
+func (h *HostHandler) ListHosts(ctx context.Context, req *pb.ListHostsRequest) (*pb.ListHostsResponse, error) {
+	return h.service.ListHosts(ctx, req)
+}
+
+func (h *HostHandler) GetHost(ctx context.Context, req *pb.GetHostRequest) (*pb.Host, error) {
+	return h.service.GetHost(ctx, req)
+}
+
+func (h *HostHandler) WatchHostEvents(req *pb.WatchHostEventsRequest, stream pb.HostService_WatchHostEventsServer) error {
+	return h.service.WatchHostEvents(stream.Context(), req, stream.Send)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/theotruvelot/g0s/pkg/logger"
 	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
 	"google.golang.org/grpc"
+	// Agents compress their metrics batches with gzip
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
 type MetricsHandler struct {
@@ -20,7 +22,7 @@ func NewMetricsHandler(store *service.MetricService) *MetricsHandler {
 	}
 }
 
-func (h *MetricsHandler) RegisterServices(server *grpc.Server) {
+func (h *MetricsHandler) RegisterServices(server grpc.ServiceRegistrar) {
 	pb.RegisterMetricServiceServer(server, h)
 	logger.Debug("Metrics gRPC service registered")
 }
@@ -33,10 +35,18 @@ func (h *MetricsHandler) NotifyShutdown() {
 	h.service.NotifyShutdown()
 }
 
-func (h *MetricsHandler) SendStreamMetrics(stream pb.MetricService_StreamMetricsServer) error {
+func (h *MetricsHandler) StreamMetrics(stream pb.MetricService_StreamMetricsServer) error {
 	return h.service.SendStreamMetrics(stream)
 }
 
-func (h *MetricsHandler) GetMetrics(ctx context.Context, req *pb.MetricsRequest) (*pb.MetricsPayload, error) {
+func (h *MetricsHandler) StreamMetricsBatches(stream pb.MetricService_StreamMetricsBatchesServer) error {
+	return h.service.SendMetricsBatches(stream)
+}
+
+func (h *MetricsHandler) GetMetrics(ctx context.Context, req *pb.MetricsRequest) (*pb.MetricsList, error) {
 	return h.service.GetMetrics(ctx, req)
 }
+
+func (h *MetricsHandler) GetMetricsStream(req *pb.MetricsRequest, stream pb.MetricService_GetMetricsStreamServer) error {
+	return h.service.GetMetricsStream(req, stream)
+}
@@ -2,7 +2,14 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	pbadmin "github.com/theotruvelot/g0s/pkg/proto/admin"
+	pbalert "github.com/theotruvelot/g0s/pkg/proto/alert"
+	pbauth "github.com/theotruvelot/g0s/pkg/proto/auth"
+	pbcontrol "github.com/theotruvelot/g0s/pkg/proto/control"
 	pbhealth "github.com/theotruvelot/g0s/pkg/proto/health"
+	pbhost "github.com/theotruvelot/g0s/pkg/proto/host"
 	pbmetric "github.com/theotruvelot/g0s/pkg/proto/metric"
 	"strings"
 
@@ -10,7 +17,9 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -24,14 +33,66 @@ const (
 	JWTAuth
 	// MTLSAuth means mTLS authentication required (for agents)
 	MTLSAuth
+	// AgentAuth means an enrolled agent, authenticated with a client certificate or the credential it enrolled for
+	AgentAuth
 )
 
+// AccessResolver loads the role and host scope of an authenticated user
+type AccessResolver interface {
+	// ResolveAccess returns auth.ErrAccessDenied if the user can't do anything anymore
+	ResolveAccess(ctx context.Context, username string) (*auth.Access, error)
+}
+
+// AgentChecker checks that the credential of an enrolled agent is still valid
+type AgentChecker interface {
+	// CheckAgent returns auth.ErrAgentRevoked if the host was revoked or enrolled again since
+	CheckAgent(ctx context.Context, claims *auth.AgentClaims) error
+}
+
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	// JWTSecret will hold the JWT secret for validation (future use)
-	JWTSecret string
+	// JWTService validates the access tokens of JWTAuth methods and the credentials of AgentAuth methods
+	JWTService *auth.JWTService
+	// AccessResolver loads the access of the callers of JWTAuth methods for the RBAC check
+	AccessResolver AccessResolver
+	// AgentChecker checks the credentials of the callers of AgentAuth methods
+	AgentChecker AgentChecker
 	// RequiredMethods maps gRPC method names to required auth types
 	RequiredMethods map[string]AuthType
+	// RequiredScopes maps JWTAuth method names to the token scope they require
+	RequiredScopes map[string]string
+	// RequiredPermissions maps JWTAuth method names to the permission the role of the caller must grant
+	RequiredPermissions map[string]auth.Permission
+}
+
+// authTypeFor returns the auth type required by a method. Methods missing from
+// RequiredMethods require a logged in user so a new RPC is never public by mistake.
+func (c AuthConfig) authTypeFor(fullMethod string) AuthType {
+	authType, exists := c.RequiredMethods[fullMethod]
+	if !exists {
+		return JWTAuth
+	}
+	return authType
+}
+
+// permissionFor returns the permission required by a JWTAuth method. Methods missing from
+// RequiredPermissions require the admin permission so a new RPC is never open to every user by mistake.
+func (c AuthConfig) permissionFor(fullMethod string) auth.Permission {
+	permission, exists := c.RequiredPermissions[fullMethod]
+	if !exists {
+		return auth.PermissionAdmin
+	}
+	return permission
+}
+
+// authenticatedStream overrides the context of a server stream with the authenticated one
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
 }
 
 // AuthUnaryInterceptor returns a unary interceptor for authentication
@@ -43,10 +104,7 @@ func AuthUnaryInterceptor(config AuthConfig) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 		// Check if this method requires authentication
-		authType, exists := config.RequiredMethods[info.FullMethod]
-		if !exists {
-			authType = NoAuth // Default to no auth if not specified
-		}
+		authType := config.authTypeFor(info.FullMethod)
 
 		logger.Debug("Checking authentication",
 			zap.String("method", info.FullMethod),
@@ -54,7 +112,8 @@ func AuthUnaryInterceptor(config AuthConfig) grpc.UnaryServerInterceptor {
 		)
 
 		// Perform authentication based on type
-		if err := authenticateRequest(ctx, authType, config); err != nil {
+		ctx, err := authenticateRequest(ctx, info.FullMethod, authType, config)
+		if err != nil {
 			logger.Warn("Authentication failed",
 				zap.String("method", info.FullMethod),
 				zap.Error(err),
@@ -76,10 +135,7 @@ func AuthStreamInterceptor(config AuthConfig) grpc.StreamServerInterceptor {
 		handler grpc.StreamHandler,
 	) error {
 		// Check if this method requires authentication
-		authType, exists := config.RequiredMethods[info.FullMethod]
-		if !exists {
-			authType = NoAuth // Default to no auth if not specified
-		}
+		authType := config.authTypeFor(info.FullMethod)
 
 		logger.Debug("Checking authentication for stream",
 			zap.String("method", info.FullMethod),
@@ -87,7 +143,8 @@ func AuthStreamInterceptor(config AuthConfig) grpc.StreamServerInterceptor {
 		)
 
 		// Perform authentication based on type
-		if err := authenticateRequest(stream.Context(), authType, config); err != nil {
+		ctx, err := authenticateRequest(stream.Context(), info.FullMethod, authType, config)
+		if err != nil {
 			logger.Warn("Stream authentication failed",
 				zap.String("method", info.FullMethod),
 				zap.Error(err),
@@ -96,89 +153,288 @@ func AuthStreamInterceptor(config AuthConfig) grpc.StreamServerInterceptor {
 		}
 
 		// If auth passed or not required, continue with the stream
-		return handler(srv, stream)
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
 	}
 }
 
-// authenticateRequest performs the actual authentication logic
-func authenticateRequest(ctx context.Context, authType AuthType, config AuthConfig) error {
+// authenticateRequest performs the actual authentication logic and returns
+// the request context enriched with the caller identity
+func authenticateRequest(ctx context.Context, fullMethod string, authType AuthType, config AuthConfig) (context.Context, error) {
 	switch authType {
 	case NoAuth:
 		// No authentication required
-		return nil
+		return ctx, nil
 
 	case JWTAuth:
-		// TODO: Implement JWT authentication for CLI
-		return authenticateJWT(ctx, config)
+		return authenticateJWT(ctx, fullMethod, config)
 
 	case MTLSAuth:
-		// TODO: Implement mTLS authentication for agents
-		return authenticateMTLS(ctx, config)
+		return authenticateMTLS(ctx)
+
+	case AgentAuth:
+		return authenticateAgent(ctx, config)
 
 	default:
 		logger.Error("Unknown authentication type", zap.Int("auth_type", int(authType)))
-		return status.Error(codes.Internal, "unknown authentication type")
+		return nil, status.Error(codes.Internal, "unknown authentication type")
 	}
 }
 
-// authenticateJWT validates JWT tokens (placeholder for future implementation)
-func authenticateJWT(ctx context.Context, config AuthConfig) error {
+// authenticateJWT validates the bearer access token of the request, checks it grants the
+// scope the method requires and stores its claims in the context. The role of the caller
+// is then checked by authorize.
+func authenticateJWT(ctx context.Context, fullMethod string, config AuthConfig) (context.Context, error) {
+	if config.JWTService == nil {
+		logger.Error("JWT authentication required but no JWT service configured")
+		return nil, status.Error(codes.Internal, "authentication is not configured")
+	}
+
 	// Get metadata from context
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Error(codes.Unauthenticated, "missing metadata")
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	token, err := bearerToken(md)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := config.JWTService.CheckJWT(token, false)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrExpiredToken):
+			return nil, status.Error(codes.Unauthenticated, "token has expired, please refresh it or log in again")
+		case errors.Is(err, auth.ErrMalformedToken):
+			return nil, status.Error(codes.Unauthenticated, "malformed token")
+		default:
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+	}
+
+	if scope, ok := config.RequiredScopes[fullMethod]; ok && !claims.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "token is missing the %q scope", scope)
+	}
+
+	logger.Debug("JWT authentication succeeded",
+		zap.String("username", claims.Username),
+		zap.Time("expires_at", claims.ExpiresAt.Time),
+	)
+
+	return authorize(auth.NewContext(ctx, claims), fullMethod, claims.Username, config)
+}
+
+// authorize loads the access of the caller, checks that its role grants the permission the
+// method requires and stores it in the context so handlers can filter the hosts it sees.
+// The access is loaded on every call so role changes apply without waiting for tokens to expire.
+func authorize(ctx context.Context, fullMethod string, username string, config AuthConfig) (context.Context, error) {
+	if config.AccessResolver == nil {
+		logger.Error("RBAC check required but no access resolver configured")
+		return nil, status.Error(codes.Internal, "authorization is not configured")
+	}
+
+	access, err := config.AccessResolver.ResolveAccess(ctx, username)
+	if err != nil {
+		if errors.Is(err, auth.ErrAccessDenied) {
+			return nil, status.Error(codes.PermissionDenied, "access denied")
+		}
+		logger.Error("Failed to resolve user access", zap.String("username", username), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check permissions")
+	}
+
+	permission := config.permissionFor(fullMethod)
+	if !access.Can(permission) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s role doesn't grant the %q permission", access.Role, permission)
 	}
 
+	return auth.NewAccessContext(ctx, access), nil
+}
+
+// bearerToken returns the token of the authorization header of the request
+func bearerToken(md metadata.MD) (string, error) {
 	// Look for authorization header
-	auth := md.Get("authorization")
-	if len(auth) == 0 {
-		return status.Error(codes.Unauthenticated, "missing authorization header")
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
 	}
 
 	// Check if it's a Bearer token
-	token := auth[0]
-	if !strings.HasPrefix(token, "Bearer ") {
-		return status.Error(codes.Unauthenticated, "invalid authorization format")
+	token, found := strings.CutPrefix(authorization[0], "Bearer ")
+	if !found || token == "" {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization format, expected \"Bearer <token>\"")
+	}
+	return token, nil
+}
+
+// authenticateAgent accepts agents presenting a client certificate verified against the
+// client CA, like MTLSAuth, and otherwise requires the bearer credential the agent got when
+// enrolling. Its claims are stored in the context for the hostname checks of the handlers.
+func authenticateAgent(ctx context.Context, config AuthConfig) (context.Context, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			return authenticateMTLS(ctx)
+		}
+	}
+
+	if config.JWTService == nil || config.AgentChecker == nil {
+		logger.Error("Agent authentication required but no JWT service or agent checker configured")
+		return nil, status.Error(codes.Internal, "authentication is not configured")
 	}
 
-	// TODO: Implement actual JWT validation
-	logger.Debug("JWT authentication placeholder - would validate token here",
-		zap.String("token_prefix", token[:20]+"..."),
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	token, err := bearerToken(md)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "the agent must enroll with a join token or present a client certificate")
+	}
+
+	claims, err := config.JWTService.CheckAgentJWT(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid agent credential")
+	}
+
+	if err := config.AgentChecker.CheckAgent(ctx, claims); err != nil {
+		if errors.Is(err, auth.ErrAgentRevoked) {
+			return nil, status.Error(codes.Unauthenticated, "agent credential revoked, enroll the host again")
+		}
+		logger.Error("Failed to check agent credential", zap.String("hostname", claims.Hostname), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check agent credential")
+	}
+
+	logger.Debug("Agent authentication succeeded",
+		zap.String("hostname", claims.Hostname),
+		zap.String("host_id", claims.HostID),
 	)
 
-	// For now, just log and pass through
-	// In the future, validate the JWT token here
-	return nil
+	return auth.NewAgentClaimsContext(ctx, claims), nil
 }
 
-// authenticateMTLS validates mTLS certificates (placeholder for future implementation)
-func authenticateMTLS(ctx context.Context, config AuthConfig) error {
-	// TODO: Implement mTLS certificate validation
-	// This would typically involve:
-	// 1. Extracting client certificate from TLS connection
-	// 2. Validating certificate chain
-	// 3. Checking certificate against allowed CAs/certificates
+// authenticateMTLS requires a client certificate verified against the client CA during the
+// TLS handshake and stores it in the context. Matching the certificate against the hostname
+// reported by the agent is done by the handlers, once the hostname is known.
+func authenticateMTLS(ctx context.Context) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing peer information")
+	}
 
-	logger.Debug("mTLS authentication placeholder - would validate certificates here")
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "a TLS connection is required")
+	}
+
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "a client certificate signed by the agent CA is required")
+	}
 
-	// For now, just log and pass through
-	// In the future, validate the client certificate here
-	return nil
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	logger.Debug("mTLS authentication succeeded",
+		zap.String("common_name", cert.Subject.CommonName),
+		zap.Strings("dns_names", cert.DNSNames),
+	)
+
+	return auth.NewAgentContext(ctx, cert), nil
 }
 
-// DefaultAuthConfig returns a default authentication configuration
-func DefaultAuthConfig() AuthConfig {
-	return AuthConfig{
+// DefaultAuthConfig returns a default authentication configuration.
+// agentAuth is the auth type of the agent streams, AgentAuth unless unenrolled agents are allowed.
+func DefaultAuthConfig(jwtService *auth.JWTService, accessResolver AccessResolver, agentChecker AgentChecker, agentAuth AuthType) AuthConfig {
+	config := AuthConfig{
+		JWTService:     jwtService,
+		AccessResolver: accessResolver,
+		AgentChecker:   agentChecker,
 		RequiredMethods: map[string]AuthType{
-			// Health check methods don't require auth
+			// Health check doesn't require auth
 			pbhealth.HealthService_Check_FullMethodName: NoAuth,
-			pbhealth.HealthService_Watch_FullMethodName: NoAuth,
 
-			// For now, metric methods don't require auth either
-			// We'll update this later when implementing actual auth
-			pbmetric.MetricService_StreamMetrics_FullMethodName:    NoAuth,
-			pbmetric.MetricService_GetMetrics_FullMethodName:       NoAuth,
-			pbmetric.MetricService_GetMetricsStream_FullMethodName: NoAuth,
+			// Authentication methods are how clients get a token
+			pbauth.AuthService_Authenticate_FullMethodName: NoAuth,
+			pbauth.AuthService_RefreshToken_FullMethodName: NoAuth,
+			// Enrollment is authenticated by the join token of the request
+			pbauth.AuthService_EnrollAgent_FullMethodName: NoAuth,
+
+			// Agent streams are authenticated with a client certificate or an agent credential
+			pbhealth.HealthService_Hello_FullMethodName:                agentAuth,
+			pbhealth.HealthService_Watch_FullMethodName:                agentAuth,
+			pbmetric.MetricService_StreamMetrics_FullMethodName:        agentAuth,
+			pbmetric.MetricService_StreamMetricsBatches_FullMethodName: agentAuth,
+			pbcontrol.ControlService_AgentControl_FullMethodName:       agentAuth,
+
+			// Reading metrics, the host inventory and alerts requires a logged in user
+			pbmetric.MetricService_GetMetrics_FullMethodName:           JWTAuth,
+			pbmetric.MetricService_GetMetricsStream_FullMethodName:     JWTAuth,
+			pbhost.HostService_ListHosts_FullMethodName:                JWTAuth,
+			pbhost.HostService_GetHost_FullMethodName:                  JWTAuth,
+			pbhost.HostService_WatchHostEvents_FullMethodName:          JWTAuth,
+			pbalert.AlertService_ListAlerts_FullMethodName:             JWTAuth,
+			pbalert.AlertService_ListAlertRules_FullMethodName:         JWTAuth,
+			pbalert.AlertService_ListSilences_FullMethodName:           JWTAuth,
+			pbalert.AlertService_ListMaintenanceWindows_FullMethodName: JWTAuth,
+
+			// Silencing alerts requires an operator
+			pbalert.AlertService_CreateSilence_FullMethodName: JWTAuth,
+			pbalert.AlertService_ExpireSilence_FullMethodName: JWTAuth,
+
+			// Acting on hosts through their agents, and reading the audit of these actions, requires an operator
+			pbcontrol.ControlService_SignalProcess_FullMethodName: JWTAuth,
+			pbcontrol.ControlService_ControlUnit_FullMethodName:   JWTAuth,
+			pbcontrol.ControlService_ListActions_FullMethodName:   JWTAuth,
+		},
+		RequiredScopes: map[string]string{
+			pbmetric.MetricService_GetMetrics_FullMethodName:           auth.ScopeMetricsRead,
+			pbmetric.MetricService_GetMetricsStream_FullMethodName:     auth.ScopeMetricsRead,
+			pbhost.HostService_ListHosts_FullMethodName:                auth.ScopeMetricsRead,
+			pbhost.HostService_GetHost_FullMethodName:                  auth.ScopeMetricsRead,
+			pbhost.HostService_WatchHostEvents_FullMethodName:          auth.ScopeMetricsRead,
+			pbalert.AlertService_ListAlerts_FullMethodName:             auth.ScopeMetricsRead,
+			pbalert.AlertService_ListAlertRules_FullMethodName:         auth.ScopeMetricsRead,
+			pbalert.AlertService_ListSilences_FullMethodName:           auth.ScopeMetricsRead,
+			pbalert.AlertService_ListMaintenanceWindows_FullMethodName: auth.ScopeMetricsRead,
+			pbalert.AlertService_CreateSilence_FullMethodName:          auth.ScopeHostsOperate,
+			pbalert.AlertService_ExpireSilence_FullMethodName:          auth.ScopeHostsOperate,
+			pbcontrol.ControlService_SignalProcess_FullMethodName:      auth.ScopeHostsOperate,
+			pbcontrol.ControlService_ControlUnit_FullMethodName:        auth.ScopeHostsOperate,
+			pbcontrol.ControlService_ListActions_FullMethodName:        auth.ScopeHostsOperate,
+		},
+		RequiredPermissions: map[string]auth.Permission{
+			pbmetric.MetricService_GetMetrics_FullMethodName:           auth.PermissionMetricsRead,
+			pbmetric.MetricService_GetMetricsStream_FullMethodName:     auth.PermissionMetricsRead,
+			pbhost.HostService_ListHosts_FullMethodName:                auth.PermissionMetricsRead,
+			pbhost.HostService_GetHost_FullMethodName:                  auth.PermissionMetricsRead,
+			pbhost.HostService_WatchHostEvents_FullMethodName:          auth.PermissionMetricsRead,
+			pbalert.AlertService_ListAlerts_FullMethodName:             auth.PermissionMetricsRead,
+			pbalert.AlertService_ListAlertRules_FullMethodName:         auth.PermissionMetricsRead,
+			pbalert.AlertService_ListSilences_FullMethodName:           auth.PermissionMetricsRead,
+			pbalert.AlertService_ListMaintenanceWindows_FullMethodName: auth.PermissionMetricsRead,
+			pbalert.AlertService_CreateSilence_FullMethodName:          auth.PermissionHostsOperate,
+			pbalert.AlertService_ExpireSilence_FullMethodName:          auth.PermissionHostsOperate,
+			pbcontrol.ControlService_SignalProcess_FullMethodName:      auth.PermissionHostsOperate,
+			pbcontrol.ControlService_ControlUnit_FullMethodName:        auth.PermissionHostsOperate,
+			pbcontrol.ControlService_ListActions_FullMethodName:        auth.PermissionHostsOperate,
 		},
 	}
+
+	// User management requires a logged in admin using a token with the admin scope
+	for _, method := range pbadmin.AdminService_ServiceDesc.Methods {
+		fullMethod := "/" + pbadmin.AdminService_ServiceDesc.ServiceName + "/" + method.MethodName
+		config.RequiredMethods[fullMethod] = JWTAuth
+		config.RequiredScopes[fullMethod] = auth.ScopeAdmin
+		config.RequiredPermissions[fullMethod] = auth.PermissionAdmin
+	}
+
+	// Alert rules and maintenance windows are managed by admins too
+	for _, fullMethod := range []string{
+		pbalert.AlertService_PutAlertRule_FullMethodName,
+		pbalert.AlertService_DeleteAlertRule_FullMethodName,
+		pbalert.AlertService_PutMaintenanceWindow_FullMethodName,
+		pbalert.AlertService_DeleteMaintenanceWindow_FullMethodName,
+	} {
+		config.RequiredMethods[fullMethod] = JWTAuth
+		config.RequiredScopes[fullMethod] = auth.ScopeAdmin
+		config.RequiredPermissions[fullMethod] = auth.PermissionAdmin
+	}
+
+	return config
 }
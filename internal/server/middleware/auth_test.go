@@ -0,0 +1,515 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	pbadmin "github.com/theotruvelot/g0s/pkg/proto/admin"
+	pbalert "github.com/theotruvelot/g0s/pkg/proto/alert"
+	pbcontrol "github.com/theotruvelot/g0s/pkg/proto/control"
+	pbhost "github.com/theotruvelot/g0s/pkg/proto/host"
+	pbmetric "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_testSecret        = "test-secret"
+	_testRefreshSecret = "test-refresh-secret"
+)
+
+// fakeAccessResolver resolves the access of the users of the tests, unknown users are denied
+type fakeAccessResolver map[string]*auth.Access
+
+func (r fakeAccessResolver) ResolveAccess(_ context.Context, username string) (*auth.Access, error) {
+	access, ok := r[username]
+	if !ok {
+		return nil, auth.ErrAccessDenied
+	}
+	return access, nil
+}
+
+var _testAccessResolver = fakeAccessResolver{
+	"alice": {Username: "alice", Role: auth.RoleAdmin, AllHosts: true},
+	"bob":   {Username: "bob", Role: auth.RoleViewer, HostPatterns: []string{"web-*"}},
+}
+
+// fakeAgentChecker accepts the current credential of the hosts of the tests
+type fakeAgentChecker map[string]string
+
+func (c fakeAgentChecker) CheckAgent(_ context.Context, claims *auth.AgentClaims) error {
+	if c[claims.HostID] != claims.ID {
+		return auth.ErrAgentRevoked
+	}
+	return nil
+}
+
+var _testAgentChecker = fakeAgentChecker{"host-web-1": "credential-2"}
+
+func newTestAuthConfig() AuthConfig {
+	return DefaultAuthConfig(auth.NewJWTService(_testSecret, _testRefreshSecret), _testAccessResolver, _testAgentChecker, AgentAuth)
+}
+
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func signToken(t *testing.T, secret string, expiresAt time.Time) string {
+	claims := &auth.JWTClaims{
+		Username: "alice",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "g0s",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(expiresAt.Add(-time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	config := newTestAuthConfig()
+	tokens, err := config.JWTService.GenerateJWT("alice", auth.Scopes)
+	require.NoError(t, err)
+	unscopedTokens, err := config.JWTService.GenerateJWT("alice", nil)
+	require.NoError(t, err)
+	readOnlyTokens, err := config.JWTService.GenerateJWT("alice", []string{auth.ScopeMetricsRead})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		method       string
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "public method without token",
+			ctx:          context.Background(),
+			method:       "/health.HealthService/Check",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "missing metadata",
+			ctx:          context.Background(),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "missing metadata",
+		},
+		{
+			name:         "missing authorization header",
+			ctx:          metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value")),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "missing authorization header",
+		},
+		{
+			name:         "not a bearer token",
+			ctx:          withAuthorization("Basic YWxpY2U6c2VjcmV0"),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "invalid authorization format",
+		},
+		{
+			name:         "malformed token",
+			ctx:          withAuthorization("Bearer not-a-jwt"),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "malformed token",
+		},
+		{
+			name:         "expired token",
+			ctx:          withAuthorization("Bearer " + signToken(t, _testSecret, time.Now().Add(-time.Minute))),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "token has expired",
+		},
+		{
+			name:         "token signed with another secret",
+			ctx:          withAuthorization("Bearer " + signToken(t, "another-secret", time.Now().Add(time.Hour))),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "invalid token",
+		},
+		{
+			name:         "refresh token used as access token",
+			ctx:          withAuthorization("Bearer " + tokens.RefreshToken),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "invalid token",
+		},
+		{
+			name:         "unknown method requires a token",
+			ctx:          context.Background(),
+			method:       "/unknown.Service/Method",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "token without the required scope",
+			ctx:          withAuthorization("Bearer " + unscopedTokens.Token),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "metrics:read",
+		},
+		{
+			name:         "admin method with a read only token",
+			ctx:          withAuthorization("Bearer " + readOnlyTokens.Token),
+			method:       pbadmin.AdminService_CreateUser_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "admin",
+		},
+		{
+			name:         "valid token",
+			ctx:          withAuthorization("Bearer " + tokens.Token),
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.OK,
+		},
+	}
+
+	interceptor := AuthUnaryInterceptor(config)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCtx = ctx
+				return "ok", nil
+			}
+
+			resp, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode != codes.OK {
+				assert.Nil(t, resp)
+				assert.Contains(t, status.Convert(err).Message(), tt.expectedMsg)
+				return
+			}
+			assert.Equal(t, "ok", resp)
+			require.NotNil(t, handlerCtx)
+		})
+	}
+}
+
+func TestAuthUnaryInterceptor_ClaimsInContext(t *testing.T) {
+	config := newTestAuthConfig()
+	tokens, err := config.JWTService.GenerateJWT("alice", auth.Scopes)
+	require.NoError(t, err)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		claims, ok := auth.ClaimsFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "alice", claims.Username)
+		assert.True(t, claims.HasScope(auth.ScopeMetricsRead))
+		assert.True(t, claims.ExpiresAt.After(time.Now()))
+		return nil, nil
+	}
+
+	_, err = AuthUnaryInterceptor(config)(
+		withAuthorization("Bearer "+tokens.Token),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: pbmetric.MetricService_GetMetrics_FullMethodName},
+		handler,
+	)
+	require.NoError(t, err)
+}
+
+func TestAuthStreamInterceptor(t *testing.T) {
+	config := newTestAuthConfig()
+	tokens, err := config.JWTService.GenerateJWT("bob", auth.Scopes)
+	require.NoError(t, err)
+
+	info := &grpc.StreamServerInfo{
+		FullMethod:     pbmetric.MetricService_GetMetricsStream_FullMethodName,
+		IsServerStream: true,
+	}
+	interceptor := AuthStreamInterceptor(config)
+
+	err = interceptor(nil, &mockServerStream{ctx: context.Background()}, info, mockStreamHandler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream := &mockServerStream{ctx: withAuthorization("Bearer " + tokens.Token)}
+	err = interceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+		claims, ok := auth.ClaimsFromContext(stream.Context())
+		require.True(t, ok)
+		assert.Equal(t, "bob", claims.Username)
+
+		access, ok := auth.AccessFromContext(stream.Context())
+		require.True(t, ok)
+		assert.True(t, access.AllowsHost("web-1", nil))
+		assert.False(t, access.AllowsHost("db-1", nil))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestAuthUnaryInterceptor_RBAC(t *testing.T) {
+	config := newTestAuthConfig()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name         string
+		username     string
+		method       string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "viewer reads metrics",
+			username:     "bob",
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer lists hosts",
+			username:     "bob",
+			method:       pbhost.HostService_ListHosts_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer lists alerts",
+			username:     "bob",
+			method:       pbalert.AlertService_ListAlerts_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer manages alert rules",
+			username:     "bob",
+			method:       pbalert.AlertService_PutAlertRule_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "admin manages alert rules",
+			username:     "alice",
+			method:       pbalert.AlertService_PutAlertRule_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer lists silences",
+			username:     "bob",
+			method:       pbalert.AlertService_ListSilences_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer creates a silence",
+			username:     "bob",
+			method:       pbalert.AlertService_CreateSilence_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "admin creates a silence",
+			username:     "alice",
+			method:       pbalert.AlertService_CreateSilence_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer signals a process",
+			username:     "bob",
+			method:       pbcontrol.ControlService_SignalProcess_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "admin signals a process",
+			username:     "alice",
+			method:       pbcontrol.ControlService_SignalProcess_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "viewer restarts a unit",
+			username:     "bob",
+			method:       pbcontrol.ControlService_ControlUnit_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "viewer manages users",
+			username:     "bob",
+			method:       pbadmin.AdminService_CreateUser_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "admin manages host groups",
+			username:     "alice",
+			method:       pbadmin.AdminService_PutHostGroup_FullMethodName,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "disabled or deleted user",
+			username:     "mallory",
+			method:       pbmetric.MetricService_GetMetrics_FullMethodName,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "method without a permission requires an admin",
+			username:     "bob",
+			method:       "/unknown.Service/Method",
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := config.JWTService.GenerateJWT(tt.username, auth.Scopes)
+			require.NoError(t, err)
+
+			_, err = AuthUnaryInterceptor(config)(
+				withAuthorization("Bearer "+tokens.Token),
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: tt.method},
+				handler,
+			)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func withPeer(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4242},
+		AuthInfo: authInfo,
+	})
+}
+
+func TestAuthStreamInterceptor_MTLS(t *testing.T) {
+	agentCert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "web-1"},
+		DNSNames: []string{"web-1.example.com"},
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{
+			name:         "plaintext connection",
+			ctx:          withPeer(nil),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "TLS without client certificate",
+			ctx:          withPeer(credentials.TLSInfo{State: tls.ConnectionState{}}),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "verified client certificate",
+			ctx: withPeer(credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{agentCert}},
+			}}),
+			expectedCode: codes.OK,
+		},
+	}
+
+	info := &grpc.StreamServerInfo{
+		FullMethod:     pbmetric.MetricService_StreamMetrics_FullMethodName,
+		IsClientStream: true,
+		IsServerStream: true,
+	}
+	interceptor := AuthStreamInterceptor(newTestAuthConfig())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := interceptor(nil, &mockServerStream{ctx: tt.ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+				cert, ok := auth.AgentCertificateFromContext(stream.Context())
+				require.True(t, ok)
+				assert.Equal(t, agentCert, cert)
+
+				assert.NoError(t, auth.VerifyAgentHostname(stream.Context(), "web-1"))
+				assert.NoError(t, auth.VerifyAgentHostname(stream.Context(), "WEB-1.example.com"))
+				assert.ErrorIs(t, auth.VerifyAgentHostname(stream.Context(), "db-1"), auth.ErrHostnameMismatch)
+				assert.ErrorIs(t, auth.VerifyAgentHostname(stream.Context(), ""), auth.ErrHostnameMismatch)
+				return nil
+			})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestAuthStreamInterceptor_AgentCredential(t *testing.T) {
+	config := newTestAuthConfig()
+	current, err := config.JWTService.GenerateAgentJWT("host-web-1", "web-1", "credential-2")
+	require.NoError(t, err)
+	replaced, err := config.JWTService.GenerateAgentJWT("host-web-1", "web-1", "credential-1")
+	require.NoError(t, err)
+	userTokens, err := config.JWTService.GenerateJWT("alice", auth.Scopes)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{
+			name:         "no credential",
+			ctx:          context.Background(),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "user access token",
+			ctx:          withAuthorization("Bearer " + userTokens.Token),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "credential replaced by a new enrollment",
+			ctx:          withAuthorization("Bearer " + replaced),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "current credential",
+			ctx:          withAuthorization("Bearer " + current),
+			expectedCode: codes.OK,
+		},
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: pbmetric.MetricService_StreamMetrics_FullMethodName}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AuthStreamInterceptor(config)(nil, &mockServerStream{ctx: tt.ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+				claims, ok := auth.AgentClaimsFromContext(stream.Context())
+				require.True(t, ok)
+				assert.Equal(t, "host-web-1", claims.HostID)
+
+				assert.NoError(t, auth.VerifyAgentHostname(stream.Context(), "web-1"))
+				assert.ErrorIs(t, auth.VerifyAgentHostname(stream.Context(), "db-1"), auth.ErrHostnameMismatch)
+				return nil
+			})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestAuthUnaryInterceptor_AgentCredentialIsNotAUserToken(t *testing.T) {
+	config := newTestAuthConfig()
+	credential, err := config.JWTService.GenerateAgentJWT("host-web-1", "web-1", "credential-2")
+	require.NoError(t, err)
+
+	_, err = AuthUnaryInterceptor(config)(
+		withAuthorization("Bearer "+credential),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: pbmetric.MetricService_GetMetrics_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil },
+	)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestDefaultAuthConfig_AgentAuth(t *testing.T) {
+	config := DefaultAuthConfig(nil, nil, nil, NoAuth)
+	err := AuthStreamInterceptor(config)(nil, &mockServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{
+		FullMethod: pbmetric.MetricService_StreamMetrics_FullMethodName,
+	}, mockStreamHandler)
+	assert.NoError(t, err)
+
+	// Without a verified certificate in the context any hostname is accepted
+	assert.NoError(t, auth.VerifyAgentHostname(context.Background(), "db-1"))
+}
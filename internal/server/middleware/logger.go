@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/theotruvelot/g0s/internal/server/telemetry"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -11,7 +12,7 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// LoggingUnaryInterceptor logs unary gRPC requests and responses
+// LoggingUnaryInterceptor logs unary gRPC requests and responses, and records their latency
 func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -37,6 +38,7 @@ func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
 		resp, err := handler(ctx, req)
 
 		duration := time.Since(start)
+		telemetry.GRPCRequestDuration.With(info.FullMethod, status.Code(err).String()).Observe(duration.Seconds())
 
 		// Log response
 		fields := []zap.Field{
@@ -61,7 +63,7 @@ func LoggingUnaryInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
-// LoggingStreamInterceptor logs streaming gRPC requests
+// LoggingStreamInterceptor logs streaming gRPC requests, and counts the open streams
 func LoggingStreamInterceptor() grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
@@ -86,9 +88,13 @@ func LoggingStreamInterceptor() grpc.StreamServerInterceptor {
 		)
 
 		// Execute the handler
+		active := telemetry.GRPCActiveStreams.With(info.FullMethod)
+		active.Inc()
 		err := handler(srv, stream)
+		active.Dec()
 
 		duration := time.Since(start)
+		telemetry.GRPCStreams.With(info.FullMethod, status.Code(err).String()).Inc()
 
 		// Log stream end
 		fields := []zap.Field{
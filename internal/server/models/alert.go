@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// AlertState is the stage of an alert in its lifecycle
+type AlertState string
+
+const (
+	// AlertStatePending is an alert whose condition holds, for less than the duration of its rule
+	AlertStatePending AlertState = "pending"
+	// AlertStateFiring is an alert whose condition held for the duration of its rule
+	AlertStateFiring AlertState = "firing"
+	// AlertStateResolved is a fired alert whose condition no longer holds
+	AlertStateResolved AlertState = "resolved"
+)
+
+// AlertRule is an alert rule managed through the API. Rules of the server rules file are
+// not stored.
+type AlertRule struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string    `gorm:"uniqueIndex;not null"`
+	Expr        string    `gorm:"not null"`
+	Severity    string    `gorm:"not null"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Alert is an occurrence of a rule matching a series. It is kept once resolved as the
+// alert history; pending alerts whose condition stops holding before firing are dropped.
+type Alert struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	RuleName string    `gorm:"index;not null"`
+	// Fingerprint identifies the rule and series the alert is about
+	Fingerprint string            `gorm:"index;not null"`
+	Hostname    string            `gorm:"index;not null"`
+	Labels      map[string]string `gorm:"type:jsonb;serializer:json"`
+	State       AlertState        `gorm:"index;not null"`
+	Severity    string            `gorm:"not null"`
+	Description string
+	Value       float64
+	StartedAt   time.Time `gorm:"index"`
+	FiredAt     *time.Time
+	ResolvedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
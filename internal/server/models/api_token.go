@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// APIToken is a named credential a user logs in with. Only the hash of the token is stored.
+type APIToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;index;not null"`
+	Name       string    `gorm:"not null"`
+	TokenHash  string    `gorm:"uniqueIndex;not null"`
+	Scopes     []string  `gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	Revoked    bool `gorm:"not null;default:false"`
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Expired reports whether the token has an expiry and it is past
+func (t *APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !t.ExpiresAt.After(now)
+}
+
+// Active reports whether the token can still be used to log in
+func (t *APIToken) Active(now time.Time) bool {
+	return !t.Revoked && !t.Expired(now)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// HostState is the liveness of a host as seen by the server
+type HostState string
+
+const (
+	// HostStateUnknown is the state of a host that never reported
+	HostStateUnknown HostState = "unknown"
+	// HostStateOnline is a host sending its metrics on time
+	HostStateOnline HostState = "online"
+	// HostStateDegraded is a host whose metrics are late
+	HostStateDegraded HostState = "degraded"
+	// HostStateOffline is a host without health watch nor metrics for several intervals
+	HostStateOffline HostState = "offline"
+	// HostStateDecommissioned is a host removed by an admin, until it reports again
+	HostStateDecommissioned HostState = "decommissioned"
+)
+
+// Host is a machine running an agent. It is recorded when the agent enrolls or first reports,
+// and its inventory is refreshed from every metrics payload and health watch.
+type Host struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Hostname string    `gorm:"uniqueIndex;not null"`
+	// CredentialID is the jti of the current credential of the agent, enrolling again replaces it.
+	// It is nil for agents that never enrolled, e.g. authenticated with a client certificate.
+	CredentialID *uuid.UUID `gorm:"type:uuid"`
+	EnrolledAt   *time.Time
+	Revoked      bool `gorm:"not null;default:false"`
+	RevokedAt    *time.Time
+
+	State          HostState `gorm:"not null;default:unknown"`
+	StateChangedAt *time.Time
+	// InMaintenance is set while a maintenance window of the host is open
+	InMaintenance bool `gorm:"not null;default:false"`
+
+	FirstSeenAt *time.Time
+	LastSeenAt  *time.Time `gorm:"index"`
+	// LastMetricsAt is when the last metrics payload was received, CollectionInterval the
+	// interval in seconds the agent sends them at
+	LastMetricsAt      *time.Time
+	CollectionInterval int
+
+	OS                   string
+	Platform             string
+	PlatformFamily       string
+	PlatformVersion      string
+	KernelVersion        string
+	KernelArch           string
+	VirtualizationSystem string
+	VirtualizationRole   string
+	AgentVersion         string
+	// ProtocolVersion, AgentFeatures and Collectors are what the agent announced in its
+	// handshake, ProtocolVersion is 0 for agents predating it
+	ProtocolVersion int
+	AgentFeatures   []string `gorm:"type:jsonb;serializer:json"`
+	Collectors      []string `gorm:"type:jsonb;serializer:json"`
+	// IPAddress is the address the agent last connected from
+	IPAddress string
+	Tags      map[string]string `gorm:"type:jsonb;serializer:json"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// HostActionStatus is the outcome of an action on a host
+type HostActionStatus string
+
+const (
+	// HostActionPending is an action sent to the agent, without result yet
+	HostActionPending HostActionStatus = "pending"
+	// HostActionSucceeded is an action the agent carried out
+	HostActionSucceeded HostActionStatus = "succeeded"
+	// HostActionRefused is an action the configuration of the agent does not allow
+	HostActionRefused HostActionStatus = "refused"
+	// HostActionNotFound is an action on processes or a unit the agent did not find
+	HostActionNotFound HostActionStatus = "not_found"
+	// HostActionFailed is an action the agent could not carry out, or did not answer in time
+	HostActionFailed HostActionStatus = "failed"
+)
+
+// HostAction is the audit record of an action requested on a host through its agent, such as
+// signalling a process or restarting a systemd unit. It is written before the action is sent to the agent, then completed
+// with its result.
+type HostAction struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Hostname string    `gorm:"index;not null"`
+	// Action is what was requested, e.g. "signal TERM" or "restart"
+	Action      string `gorm:"not null"`
+	PID         int32
+	ProcessName string
+	Unit        string
+	RequestedBy string `gorm:"not null"`
+	Comment     string
+	Status      HostActionStatus `gorm:"not null"`
+	Message     string
+	// PIDs are the processes the action was carried out on
+	PIDs        []int32   `gorm:"type:jsonb;serializer:json"`
+	RequestedAt time.Time `gorm:"index;not null"`
+	CompletedAt *time.Time
+}
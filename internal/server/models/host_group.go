@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// HostGroup is a named set of hosts users can be limited to
+type HostGroup struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name string    `gorm:"uniqueIndex;not null"`
+	// HostPatterns are hostname globs, e.g. "web-*", and HostTags the tags selecting hosts,
+	// e.g. env=prod: a host is in the group if it matches a pattern or has every tag
+	HostPatterns []string          `gorm:"type:jsonb;serializer:json;not null"`
+	HostTags     map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// JoinToken is a short-lived, single-use token an agent exchanges for its credential.
+// Only the hash of the token is stored.
+type JoinToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	// CreatedBy is the username of the admin who created the token
+	CreatedBy string    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	// HostID is the host enrolled with the token, once used
+	HostID    *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// RefreshToken tracks an issued refresh token by its jti so it can only be used once
+type RefreshToken struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Username string    `gorm:"index;not null"`
+	// APITokenID is the API token the session was opened with, revoking it ends the session
+	APITokenID uuid.UUID `gorm:"type:uuid;index"`
+	ExpiresAt  time.Time `gorm:"index;not null"`
+	UsedAt     *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Silence suppresses the notifications of the alerts it matches between StartsAt and EndsAt.
+// Empty HostFilter, Tags and RuleName match every alert, at least one of them is set.
+type Silence struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	// HostFilter is a regular expression on the hostname, Tags the tags the host must have
+	HostFilter string
+	Tags       map[string]string `gorm:"type:jsonb;serializer:json"`
+	RuleName   string
+	StartsAt   time.Time `gorm:"not null"`
+	EndsAt     time.Time `gorm:"index;not null"`
+	CreatedBy  string    `gorm:"not null"`
+	Comment    string
+	// AllHosts, HostPatterns and HostTags are the hosts the creator could see, the silence only
+	// applies to them
+	AllHosts     bool                `gorm:"not null;default:false"`
+	HostPatterns []string            `gorm:"type:jsonb;serializer:json"`
+	HostTags     []map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// MaintenanceWindow is a recurring window during which the hosts it matches are in
+// maintenance and the notifications of their alerts are suppressed
+type MaintenanceWindow struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name string    `gorm:"uniqueIndex;not null"`
+	// Schedule is the cron schedule the window opens at, in Timezone, and Duration how long
+	// it stays open
+	Schedule   string        `gorm:"not null"`
+	Timezone   string        `gorm:"not null"`
+	Duration   time.Duration `gorm:"not null"`
+	HostFilter string
+	Tags       map[string]string `gorm:"type:jsonb;serializer:json"`
+	CreatedBy  string            `gorm:"not null"`
+	Comment    string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
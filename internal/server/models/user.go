@@ -7,10 +7,14 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Username  string    `gorm:"unique;not null"`
-	Token     string    `gorm:"unique;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Username string    `gorm:"unique;not null"`
+	// Role is one of auth.Roles
+	Role     string `gorm:"not null;default:viewer"`
+	Disabled bool   `gorm:"not null;default:false"`
+	// HostGroups limit the hosts the user can see to the ones of these groups, all hosts if empty
+	HostGroups []string `gorm:"type:jsonb;serializer:json"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
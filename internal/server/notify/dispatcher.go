@@ -0,0 +1,253 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// _maxAttempts is the number of times a message is sent before being dropped
+	_maxAttempts = 3
+	// _defaultRetryDelay is the delay before the first retry, doubled for every other retry
+	_defaultRetryDelay = 2 * time.Second
+	// _sendTimeout bounds every attempt to send a message
+	_sendTimeout = 30 * time.Second
+	// _channelBuffer is the number of alerts buffered per channel before new alerts are dropped
+	_channelBuffer = 256
+)
+
+// channel groups the alerts it receives by rule and state, and sends every group once it
+// waited for GroupWait, no more often than its rate limit allows
+type channel struct {
+	config   ChannelConfig
+	notifier Notifier
+	alerts   chan models.Alert
+	interval time.Duration
+
+	groups   []*pendingGroup
+	lastSent time.Time
+}
+
+type pendingGroup struct {
+	group *Group
+	due   time.Time
+}
+
+func newChannel(config ChannelConfig, notifier Notifier) *channel {
+	if config.GroupWait <= 0 {
+		config.GroupWait = DefaultGroupWait
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = DefaultRateLimit
+	}
+	return &channel{
+		config:   config,
+		notifier: notifier,
+		alerts:   make(chan models.Alert, _channelBuffer),
+		interval: time.Minute / time.Duration(config.RateLimit),
+	}
+}
+
+// add adds the alert to the pending group of its rule and state, starting one if needed
+func (c *channel) add(alert models.Alert, now time.Time) {
+	for _, pending := range c.groups {
+		if pending.group.Rule == alert.RuleName && pending.group.State == alert.State {
+			pending.group.add(alert)
+			return
+		}
+	}
+
+	group := &Group{
+		Rule:        alert.RuleName,
+		State:       alert.State,
+		Severity:    alert.Severity,
+		Description: alert.Description,
+	}
+	group.add(alert)
+	c.groups = append(c.groups, &pendingGroup{group: group, due: now.Add(c.config.GroupWait)})
+}
+
+// drain adds the alerts waiting in the buffer of the channel
+func (c *channel) drain(now time.Time) {
+	for {
+		select {
+		case alert := <-c.alerts:
+			c.add(alert, now)
+		default:
+			return
+		}
+	}
+}
+
+// next removes and returns the oldest group due now if the rate limit allows sending it.
+// Otherwise it returns how long to wait for the next one, zero if there is no pending group.
+func (c *channel) next(now time.Time) (*Group, time.Duration) {
+	if len(c.groups) == 0 {
+		return nil, 0
+	}
+
+	ready := c.groups[0].due
+	if allowed := c.lastSent.Add(c.interval); allowed.After(ready) {
+		ready = allowed
+	}
+	if ready.After(now) {
+		return nil, ready.Sub(now)
+	}
+
+	group := c.groups[0].group
+	c.groups = c.groups[1:]
+	c.lastSent = now
+	return group, 0
+}
+
+// Dispatcher sends the alerts to every channel accepting them
+type Dispatcher struct {
+	channels   []*channel
+	muted      func(*models.Alert) bool
+	retryDelay time.Duration
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewDispatcher returns a dispatcher to the configured channels
+func NewDispatcher(configs []ChannelConfig) (*Dispatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		retryDelay: _defaultRetryDelay,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+	for _, config := range configs {
+		notifier, err := New(config)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		d.channels = append(d.channels, newChannel(config, notifier))
+	}
+	return d, nil
+}
+
+// Start sends the alerts received on alerts until Stop is called, except the ones muted
+// reports as muted, e.g. by a silence. A nil muted mutes nothing.
+func (d *Dispatcher) Start(alerts <-chan models.Alert, muted func(*models.Alert) bool) {
+	d.muted = muted
+	for _, c := range d.channels {
+		d.wg.Add(1)
+		go d.run(c)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-d.ctx.Done():
+				return
+			case alert := <-alerts:
+				d.dispatch(alert)
+			}
+		}
+	}()
+	logger.Info("Notifications started", zap.Int("channels", len(d.channels)))
+}
+
+// Stop sends the pending groups once, without waiting for them to be due, and stops
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) dispatch(alert models.Alert) {
+	if d.muted != nil && d.muted(&alert) {
+		return
+	}
+	for _, c := range d.channels {
+		if !c.config.accepts(&alert) {
+			continue
+		}
+		select {
+		case c.alerts <- alert:
+		default:
+			logger.Warn("Notification channel is too slow, dropping alert",
+				zap.String("channel", c.config.Name),
+				zap.String("rule", alert.RuleName))
+		}
+	}
+}
+
+func (d *Dispatcher) run(c *channel) {
+	defer d.wg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			c.drain(time.Now())
+			for _, pending := range c.groups {
+				d.send(context.Background(), c, pending.group, 1)
+			}
+			return
+		case alert := <-c.alerts:
+			c.add(alert, time.Now())
+		case <-timer.C:
+		}
+
+		for {
+			group, wait := c.next(time.Now())
+			if group == nil {
+				timer.Stop()
+				if wait > 0 {
+					timer.Reset(wait)
+				}
+				break
+			}
+			d.send(d.ctx, c, group, _maxAttempts)
+		}
+	}
+}
+
+// send sends the group, retrying failures that may be temporary with an exponential backoff
+func (d *Dispatcher) send(ctx context.Context, c *channel, group *Group, attempts int) {
+	delay := d.retryDelay
+	for attempt := 1; ; attempt++ {
+		sendCtx, cancel := context.WithTimeout(ctx, _sendTimeout)
+		err := c.notifier.Notify(sendCtx, group)
+		cancel()
+		if err == nil {
+			logger.Debug("Notification sent",
+				zap.String("channel", c.config.Name),
+				zap.String("rule", group.Rule),
+				zap.Int("alerts", len(group.Alerts)))
+			return
+		}
+
+		if IsPermanent(err) || attempt >= attempts {
+			logger.Error("Failed to send notification",
+				zap.String("channel", c.config.Name),
+				zap.String("rule", group.Rule),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return
+		}
+		logger.Warn("Failed to send notification, retrying",
+			zap.String("channel", c.config.Name),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,157 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/models"
+)
+
+// fakeNotifier records the groups it is asked to send, failing the first failures times
+type fakeNotifier struct {
+	mu       sync.Mutex
+	failures int
+	err      error
+	calls    int
+	groups   []*Group
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, group *Group) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls++
+	if n.calls <= n.failures {
+		return n.err
+	}
+	n.groups = append(n.groups, group)
+	return nil
+}
+
+func (n *fakeNotifier) sent() []*Group {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*Group(nil), n.groups...)
+}
+
+func TestChannel_Grouping(t *testing.T) {
+	c := newChannel(ChannelConfig{Name: "ops", GroupWait: time.Minute, RateLimit: 2}, nil)
+	now := time.Now()
+
+	c.add(testAlert("high-cpu", "web-1", models.AlertStateFiring), now)
+	c.add(testAlert("high-cpu", "web-2", models.AlertStateFiring), now.Add(10*time.Second))
+	c.add(testAlert("high-cpu", "web-1", models.AlertStateFiring), now.Add(20*time.Second))
+	c.add(testAlert("low-disk", "db-1", models.AlertStateFiring), now.Add(30*time.Second))
+	c.add(testAlert("high-cpu", "web-3", models.AlertStateResolved), now.Add(30*time.Second))
+
+	group, wait := c.next(now.Add(30 * time.Second))
+	assert.Nil(t, group)
+	assert.Equal(t, 30*time.Second, wait, "the first group waits for its group wait")
+
+	group, _ = c.next(now.Add(time.Minute))
+	require.NotNil(t, group)
+	assert.Equal(t, "high-cpu", group.Rule)
+	assert.Len(t, group.Alerts, 2, "alerts of the same rule and state are sent together")
+
+	group, wait = c.next(now.Add(80 * time.Second))
+	assert.Nil(t, group)
+	assert.Equal(t, 10*time.Second, wait, "the next group waits for its group wait")
+
+	group, _ = c.next(now.Add(90 * time.Second))
+	require.NotNil(t, group)
+	assert.Equal(t, "low-disk", group.Rule)
+
+	group, wait = c.next(now.Add(100 * time.Second))
+	assert.Nil(t, group)
+	assert.Equal(t, 20*time.Second, wait, "the rate limit delays a due group")
+
+	group, _ = c.next(now.Add(2 * time.Minute))
+	require.NotNil(t, group)
+	assert.Equal(t, models.AlertStateResolved, group.State)
+
+	group, wait = c.next(now.Add(time.Hour))
+	assert.Nil(t, group)
+	assert.Zero(t, wait)
+}
+
+func TestChannelConfig_Accepts(t *testing.T) {
+	noResolved := false
+	config := ChannelConfig{Severities: []string{"critical"}, SendResolved: &noResolved}
+
+	firing := testAlert("high-cpu", "web-1", models.AlertStateFiring)
+	assert.True(t, config.accepts(&firing))
+	resolved := testAlert("high-cpu", "web-1", models.AlertStateResolved)
+	assert.False(t, config.accepts(&resolved))
+	firing.Severity = "warning"
+	assert.False(t, config.accepts(&firing))
+}
+
+func TestDispatcher(t *testing.T) {
+	notifier := &fakeNotifier{failures: 2, err: errors.New("connection refused")}
+	d := &Dispatcher{retryDelay: time.Millisecond}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	d.channels = []*channel{newChannel(ChannelConfig{Name: "ops", GroupWait: 20 * time.Millisecond, RateLimit: 600}, notifier)}
+
+	alerts := make(chan models.Alert, 10)
+	d.Start(alerts, func(alert *models.Alert) bool { return alert.Hostname == "web-3" })
+	alerts <- testAlert("high-cpu", "web-1", models.AlertStateFiring)
+	alerts <- testAlert("high-cpu", "web-3", models.AlertStateFiring)
+	alerts <- testAlert("high-cpu", "web-2", models.AlertStateFiring)
+
+	require.Eventually(t, func() bool { return len(notifier.sent()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Len(t, notifier.sent()[0].Alerts, 2, "muted alerts are not sent")
+	assert.Equal(t, 3, notifier.calls, "temporary failures are retried")
+
+	// Pending groups are sent on stop
+	alerts <- testAlert("low-disk", "db-1", models.AlertStateFiring)
+	require.Eventually(t, func() bool { return len(d.channels[0].alerts) == 0 }, time.Second, 5*time.Millisecond)
+	d.Stop()
+	assert.Len(t, notifier.sent(), 2)
+}
+
+func TestDispatcher_PermanentErrors(t *testing.T) {
+	notifier := &fakeNotifier{failures: 1, err: &permanentError{err: errors.New("rejected")}}
+	d := &Dispatcher{retryDelay: time.Millisecond}
+	d.send(context.Background(), newChannel(ChannelConfig{Name: "ops"}, notifier), testGroup(models.AlertStateFiring, "web-1"), _maxAttempts)
+	assert.Equal(t, 1, notifier.calls)
+	assert.Empty(t, notifier.sent())
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+channels:
+  - name: ops
+    type: slack
+    url: http://localhost/hooks/ops
+    severities: [critical]
+    group_wait: 1m
+    rate_limit: 5
+  - name: oncall
+    type: smtp
+    send_resolved: false
+    smtp:
+      addr: localhost:25
+      from: g0s@example.com
+      to: [oncall@example.com]
+`), 0o600))
+
+	channels, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, time.Minute, channels[0].GroupWait)
+	assert.Equal(t, 5, channels[0].RateLimit)
+	assert.False(t, *channels[1].SendResolved)
+	assert.Equal(t, []string{"oncall@example.com"}, channels[1].SMTP.To)
+
+	_, err = NewDispatcher(channels)
+	assert.NoError(t, err)
+	_, err = NewDispatcher([]ChannelConfig{{Name: "pager", Type: "pager"}})
+	assert.Error(t, err)
+}
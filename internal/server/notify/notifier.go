@@ -0,0 +1,208 @@
+// Package notify sends the firing and resolved alerts out of the server, through webhooks,
+// Slack-compatible incoming webhooks and email
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Channel types
+const (
+	TypeWebhook = "webhook"
+	TypeSlack   = "slack"
+	TypeSMTP    = "smtp"
+)
+
+const (
+	// DefaultGroupWait is how long alerts of a rule are collected before being sent as one message
+	DefaultGroupWait = 30 * time.Second
+	// DefaultRateLimit is the number of messages a channel sends per minute at most
+	DefaultRateLimit = 10
+)
+
+// Notifier sends a group of alerts to a channel
+type Notifier interface {
+	Notify(ctx context.Context, group *Group) error
+}
+
+// permanentError is a failure retrying won't fix, e.g. a rejected request
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// IsPermanent reports whether err is a failure that retrying won't fix
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// Group is a set of alerts of the same rule in the same state, sent as one message
+type Group struct {
+	Rule        string
+	State       models.AlertState
+	Severity    string
+	Description string
+	Alerts      []models.Alert
+}
+
+// add adds the alert to the group, replacing a previous version of it
+func (g *Group) add(alert models.Alert) {
+	for i := range g.Alerts {
+		if g.Alerts[i].Fingerprint == alert.Fingerprint {
+			g.Alerts[i] = alert
+			return
+		}
+	}
+	g.Alerts = append(g.Alerts, alert)
+}
+
+// Title summarizes the group, e.g. "[FIRING:3] high-cpu"
+func (g *Group) Title() string {
+	return fmt.Sprintf("[%s:%d] %s", strings.ToUpper(string(g.State)), len(g.Alerts), g.Rule)
+}
+
+// Text describes the group, one line per alert
+func (g *Group) Text() string {
+	var b strings.Builder
+	if g.Description != "" {
+		b.WriteString(g.Description)
+		b.WriteString("\n")
+	}
+	for _, alert := range g.Alerts {
+		fmt.Fprintf(&b, "- %s: %s", alert.Hostname, strconv.FormatFloat(alert.Value, 'g', 6, 64))
+		if labels := formatLabels(alert.Labels); labels != "" {
+			fmt.Fprintf(&b, " (%s)", labels)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatLabels returns the labels other than host as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if key != "host" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// ChannelConfig configures a notification channel
+type ChannelConfig struct {
+	Name string `yaml:"name"`
+	// Type is webhook, slack or smtp
+	Type string `yaml:"type"`
+	// URL is the endpoint of webhook and slack channels
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	// Template is a text/template of the JSON body of webhook channels, see WebhookNotifier
+	Template string     `yaml:"template"`
+	SMTP     SMTPConfig `yaml:"smtp"`
+
+	// Severities limits the channel to alerts of these severities, every severity if empty
+	Severities []string `yaml:"severities"`
+	// SendResolved sends resolved alerts too, true by default
+	SendResolved *bool         `yaml:"send_resolved"`
+	GroupWait    time.Duration `yaml:"group_wait"`
+	// RateLimit is the number of messages sent per minute at most
+	RateLimit int `yaml:"rate_limit"`
+}
+
+// SMTPConfig configures an email channel
+type SMTPConfig struct {
+	// Addr is the host:port of the SMTP server
+	Addr     string   `yaml:"addr"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+}
+
+// accepts reports whether the channel sends the alert
+func (c *ChannelConfig) accepts(alert *models.Alert) bool {
+	if alert.State == models.AlertStateResolved && c.SendResolved != nil && !*c.SendResolved {
+		return false
+	}
+	if len(c.Severities) == 0 {
+		return true
+	}
+	for _, severity := range c.Severities {
+		if severity == alert.Severity {
+			return true
+		}
+	}
+	return false
+}
+
+// New returns the notifier of the channel
+func New(config ChannelConfig) (Notifier, error) {
+	switch config.Type {
+	case TypeWebhook:
+		return NewWebhookNotifier(config.URL, config.Headers, config.Template)
+	case TypeSlack:
+		return NewSlackNotifier(config.URL)
+	case TypeSMTP:
+		return NewSMTPNotifier(config.SMTP)
+	default:
+		return nil, fmt.Errorf("unknown channel type %q, expected webhook, slack or smtp", config.Type)
+	}
+}
+
+// configFile is the format of the notifications file of the server
+type configFile struct {
+	Channels []ChannelConfig `yaml:"channels"`
+}
+
+// LoadConfigFile reads the channels of a YAML file:
+//
+//	channels:
+//	  - name: ops
+//	    type: slack
+//	    url: https://hooks.slack.com/services/...
+//	    severities: [critical]
+//	    group_wait: 1m
+//	    rate_limit: 5
+func LoadConfigFile(path string) ([]ChannelConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file configFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	names := make(map[string]bool, len(file.Channels))
+	for i, channel := range file.Channels {
+		if channel.Name == "" {
+			return nil, fmt.Errorf("channel %d of %s: missing name", i+1, path)
+		}
+		if names[channel.Name] {
+			return nil, fmt.Errorf("channel %d of %s: duplicate channel name %q", i+1, path, channel.Name)
+		}
+		names[channel.Name] = true
+	}
+	return file.Channels, nil
+}
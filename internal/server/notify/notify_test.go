@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/server/models"
+)
+
+func testAlert(rule, hostname string, state models.AlertState) models.Alert {
+	return models.Alert{
+		RuleName:    rule,
+		Fingerprint: rule + "/" + hostname,
+		Hostname:    hostname,
+		Labels:      map[string]string{"host": hostname, "core_id": "0"},
+		State:       state,
+		Severity:    "critical",
+		Description: "CPU is saturated",
+		Value:       97.5,
+		StartedAt:   time.Unix(1700000000, 0).UTC(),
+	}
+}
+
+func testGroup(state models.AlertState, hostnames ...string) *Group {
+	group := &Group{Rule: "high-cpu", State: state, Severity: "critical", Description: "CPU is saturated"}
+	for _, hostname := range hostnames {
+		group.add(testAlert("high-cpu", hostname, state))
+	}
+	return group
+}
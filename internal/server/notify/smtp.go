@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier emails the groups. The connection is upgraded with STARTTLS when the server
+// supports it, and authenticated with PLAIN when a username is given.
+type SMTPNotifier struct {
+	config SMTPConfig
+}
+
+func NewSMTPNotifier(config SMTPConfig) (*SMTPNotifier, error) {
+	if _, _, err := net.SplitHostPort(config.Addr); err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q, expected host:port", config.Addr)
+	}
+	if config.From == "" || len(config.To) == 0 {
+		return nil, fmt.Errorf("SMTP channels require a sender and at least one recipient")
+	}
+	return &SMTPNotifier{config: config}, nil
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, group *Group) error {
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, _ := net.SplitHostPort(n.config.Addr)
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+
+	// smtp.SendMail has no context, bound it by closing the connection once ctx is done
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.config.Addr, auth, n.config.From, n.config.To, n.message(group))
+	}()
+	select {
+	case err := <-done:
+		if err != nil && isRejection(err) {
+			return &permanentError{err: err}
+		}
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *SMTPNotifier) message(group *Group) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(group.Title()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(group.Text(), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// isRejection reports whether the server rejected the message for good, i.e. replied with a 5xx code
+func isRejection(err error) bool {
+	var reply *textproto.Error
+	return errors.As(err, &reply) && reply.Code >= 500
+}
+
+// sanitizeHeader keeps rule names from injecting headers
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
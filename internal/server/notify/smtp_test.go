@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/models"
+)
+
+type fakeSMTP struct {
+	addr       string
+	mu         sync.Mutex
+	recipients []string
+	data       string
+}
+
+// newFakeSMTP returns a minimal SMTP stand-in accepting every message, or rejecting every
+// recipient with a 550 reply
+func newFakeSMTP(t *testing.T, reject bool) *fakeSMTP {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	server := &fakeSMTP{addr: lis.Addr().String()}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn, reject)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTP) serve(conn net.Conn, reject bool) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch {
+		case cmd == "EHLO" || cmd == "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case cmd == "RCPT" && reject:
+			_ = tp.PrintfLine("550 no such user")
+		case cmd == "RCPT":
+			s.mu.Lock()
+			s.recipients = append(s.recipients, line)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = strings.Join(data, "\n")
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	server := newFakeSMTP(t, false)
+	n, err := NewSMTPNotifier(SMTPConfig{Addr: server.addr, From: "g0s@example.com", To: []string{"ops@example.com", "oncall@example.com"}})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1", "web-2")))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Len(t, server.recipients, 2)
+	assert.Contains(t, server.data, "Subject: [FIRING:2] high-cpu")
+	assert.Contains(t, server.data, "- web-2: 97.5 (core_id=0)")
+}
+
+func TestSMTPNotifier_Errors(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{Addr: "localhost", From: "g0s@example.com", To: []string{"ops@example.com"}})
+	assert.Error(t, err)
+	_, err = NewSMTPNotifier(SMTPConfig{Addr: "localhost:25", From: "g0s@example.com"})
+	assert.Error(t, err)
+
+	server := newFakeSMTP(t, true)
+	n, err := NewSMTPNotifier(SMTPConfig{Addr: server.addr, From: "g0s@example.com", To: []string{"nobody@example.com"}})
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1"))
+	assert.True(t, IsPermanent(err))
+}
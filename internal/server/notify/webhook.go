@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// _httpTimeout bounds every webhook request
+const _httpTimeout = 10 * time.Second
+
+// WebhookNotifier posts a JSON body describing the group to a URL. The body is the JSON
+// encoding of webhookBody unless a template is given; templates are executed with the Group
+// and may use the json function to encode values, e.g.
+//
+//	{"summary": {{ json .Title }}, "details": {{ json .Text }}}
+type WebhookNotifier struct {
+	url      string
+	headers  map[string]string
+	template *template.Template
+	client   *http.Client
+}
+
+// NewWebhookNotifier returns a webhook notifier, tmpl may be empty
+func NewWebhookNotifier(endpoint string, headers map[string]string, tmpl string) (*WebhookNotifier, error) {
+	if err := validateURL(endpoint); err != nil {
+		return nil, err
+	}
+
+	n := &WebhookNotifier{
+		url:     endpoint,
+		headers: headers,
+		client:  &http.Client{Timeout: _httpTimeout},
+	}
+	if tmpl != "" {
+		t, err := template.New("webhook").Funcs(template.FuncMap{"json": jsonString}).Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template: %w", err)
+		}
+		n.template = t
+	}
+	return n, nil
+}
+
+// webhookBody is the default body of webhook notifications
+type webhookBody struct {
+	Rule        string         `json:"rule"`
+	State       string         `json:"state"`
+	Severity    string         `json:"severity"`
+	Title       string         `json:"title"`
+	Text        string         `json:"text"`
+	Description string         `json:"description,omitempty"`
+	Alerts      []webhookAlert `json:"alerts"`
+}
+
+type webhookAlert struct {
+	Hostname   string            `json:"hostname"`
+	Labels     map[string]string `json:"labels"`
+	Value      float64           `json:"value"`
+	StartedAt  time.Time         `json:"started_at"`
+	FiredAt    *time.Time        `json:"fired_at,omitempty"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty"`
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, group *Group) error {
+	body, err := n.body(group)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	return postJSON(ctx, n.client, n.url, n.headers, body)
+}
+
+func (n *WebhookNotifier) body(group *Group) ([]byte, error) {
+	if n.template == nil {
+		body := webhookBody{
+			Rule:        group.Rule,
+			State:       string(group.State),
+			Severity:    group.Severity,
+			Title:       group.Title(),
+			Text:        group.Text(),
+			Description: group.Description,
+			Alerts:      make([]webhookAlert, 0, len(group.Alerts)),
+		}
+		for _, alert := range group.Alerts {
+			body.Alerts = append(body.Alerts, webhookAlert{
+				Hostname:   alert.Hostname,
+				Labels:     alert.Labels,
+				Value:      alert.Value,
+				StartedAt:  alert.StartedAt,
+				FiredAt:    alert.FiredAt,
+				ResolvedAt: alert.ResolvedAt,
+			})
+		}
+		return json.Marshal(body)
+	}
+
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, group); err != nil {
+		return nil, fmt.Errorf("executing webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template did not produce valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// SlackNotifier posts to Slack and Mattermost incoming webhooks
+type SlackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewSlackNotifier(endpoint string) (*SlackNotifier, error) {
+	if err := validateURL(endpoint); err != nil {
+		return nil, err
+	}
+	return &SlackNotifier{
+		url:    endpoint,
+		client: &http.Client{Timeout: _httpTimeout},
+	}, nil
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, group *Group) error {
+	body, err := json.Marshal(map[string]string{
+		"text": "*" + group.Title() + "*\n" + group.Text(),
+	})
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	return postJSON(ctx, n.client, n.url, nil, body)
+}
+
+func validateURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q, expected an http or https URL", endpoint)
+	}
+	return nil
+}
+
+// postJSON posts the body. Rejected requests are permanent errors, unlike server errors
+// and rate limiting.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Read a bit of the body for the error and so the connection can be reused
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+// jsonString returns the JSON encoding of v, for templates
+func jsonString(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
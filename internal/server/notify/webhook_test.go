@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/models"
+)
+
+// newFakeEndpoint returns an HTTP stand-in recording the bodies it receives and answering with status
+func newFakeEndpoint(t *testing.T, status int) (*httptest.Server, *[]map[string]interface{}, *[]http.Header) {
+	var bodies []map[string]interface{}
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		bodies = append(bodies, body)
+		headers = append(headers, r.Header)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies, &headers
+}
+
+func TestWebhookNotifier_DefaultBody(t *testing.T) {
+	endpoint, bodies, headers := newFakeEndpoint(t, http.StatusOK)
+	n, err := NewWebhookNotifier(endpoint.URL, map[string]string{"Authorization": "Bearer secret"}, "")
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1", "web-2")))
+	require.Len(t, *bodies, 1)
+	body := (*bodies)[0]
+	assert.Equal(t, "high-cpu", body["rule"])
+	assert.Equal(t, "firing", body["state"])
+	assert.Equal(t, "[FIRING:2] high-cpu", body["title"])
+	assert.Len(t, body["alerts"], 2)
+	assert.Equal(t, "Bearer secret", (*headers)[0].Get("Authorization"))
+	assert.Equal(t, "application/json", (*headers)[0].Get("Content-Type"))
+}
+
+func TestWebhookNotifier_Template(t *testing.T) {
+	endpoint, bodies, _ := newFakeEndpoint(t, http.StatusOK)
+	n, err := NewWebhookNotifier(endpoint.URL, nil, `{"summary": {{ json .Title }}, "hosts": [{{ range $i, $a := .Alerts }}{{ if $i }},{{ end }}{{ json $a.Hostname }}{{ end }}]}`)
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testGroup(models.AlertStateResolved, "web-1", `we"b-2`)))
+	require.Len(t, *bodies, 1)
+	assert.Equal(t, "[RESOLVED:2] high-cpu", (*bodies)[0]["summary"])
+	assert.Equal(t, []interface{}{"web-1", `we"b-2`}, (*bodies)[0]["hosts"])
+
+	n, err = NewWebhookNotifier(endpoint.URL, nil, `{"summary": {{ .Title }}}`)
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1"))
+	assert.True(t, IsPermanent(err), "invalid JSON is not retried")
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	_, err := NewWebhookNotifier("ftp://example.com", nil, "")
+	assert.Error(t, err)
+	_, err = NewWebhookNotifier("http://example.com", nil, "{{ .Title")
+	assert.Error(t, err)
+
+	rejecting, _, _ := newFakeEndpoint(t, http.StatusBadRequest)
+	n, err := NewWebhookNotifier(rejecting.URL, nil, "")
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1"))
+	assert.True(t, IsPermanent(err))
+
+	failing, _, _ := newFakeEndpoint(t, http.StatusServiceUnavailable)
+	n, err = NewWebhookNotifier(failing.URL, nil, "")
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1"))
+	assert.Error(t, err)
+	assert.False(t, IsPermanent(err))
+}
+
+func TestSlackNotifier(t *testing.T) {
+	endpoint, bodies, _ := newFakeEndpoint(t, http.StatusOK)
+	n, err := NewSlackNotifier(endpoint.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), testGroup(models.AlertStateFiring, "web-1")))
+	require.Len(t, *bodies, 1)
+	assert.Equal(t, "*[FIRING:1] high-cpu*\nCPU is saturated\n- web-1: 97.5 (core_id=0)\n", (*bodies)[0]["text"])
+}
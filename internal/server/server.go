@@ -2,84 +2,248 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/theotruvelot/g0s/internal/server/alerting"
 	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/gateway"
 	"github.com/theotruvelot/g0s/internal/server/grpc"
 	"github.com/theotruvelot/g0s/internal/server/middleware"
+	"github.com/theotruvelot/g0s/internal/server/notify"
 	"github.com/theotruvelot/g0s/internal/server/service"
 	"github.com/theotruvelot/g0s/internal/server/storage/database"
 	"github.com/theotruvelot/g0s/internal/server/storage/metrics"
+	"github.com/theotruvelot/g0s/internal/server/telemetry"
 	"github.com/theotruvelot/g0s/pkg/logger"
+	"github.com/theotruvelot/g0s/pkg/utils"
+	"github.com/theotruvelot/g0s/pkg/wal"
 	"go.uber.org/zap"
 	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"net"
+	"net/http"
+	"time"
 )
 
 // Config holds server configuration
 type Config struct {
-	GRPCAddr         string
-	LogLevel         string
-	LogFormat        string
-	VMEndpoint       string
+	GRPCAddr string
+	// HTTPAddr is the address of the JSON gateway to the gRPC services, disabled if empty.
+	// It is served over TLS with the gRPC certificate when one is configured.
+	HTTPAddr string
+	// MetricsAddr is the address of the Prometheus /metrics endpoint of the server, disabled if
+	// empty. It is served without authentication, on its own address so it can stay internal.
+	MetricsAddr string
+	LogLevel    string
+	LogFormat   string
+	VMEndpoint  string
+	// WALDir is the directory metrics are buffered in before they are written to VictoriaMetrics,
+	// written right away if empty. The buffer is bounded by WALMaxBytes and WALMaxAge.
+	WALDir           string
+	WALMaxBytes      int64
+	WALMaxAge        time.Duration
 	JWTSecret        string
 	JWTRefreshSecret string
+	// TLSCertFile and TLSKeyFile enable TLS on the gRPC listener
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile is the CA agent certificates are verified against. When set, agents
+	// presenting a client certificate are authenticated with it instead of an enrollment credential.
+	TLSClientCAFile string
+	// AllowUnenrolledAgents accepts agent streams without any credential, for development only
+	AllowUnenrolledAgents bool
+	// HostOfflineAfter is the number of collection intervals without health watch nor metrics
+	// after which a host is offline
+	HostOfflineAfter int
+	// AlertRulesFile is a YAML file of alert rules evaluated along the rules managed through the API
+	AlertRulesFile string
+	// NotificationsFile is a YAML file of the channels firing and resolved alerts are sent to
+	NotificationsFile string
 }
 
 // Server represents the g0s server
 type Server struct {
-	cfg         Config
-	grpc        *grpclib.Server
-	store       *metrics.Manager
-	handler     *grpc.Handler
-	authService *service.AuthService
+	cfg            Config
+	grpc           *grpclib.Server
+	store          *metrics.Manager
+	handler        *grpc.Handler
+	authService    *service.AuthService
+	hostService    *service.HostService
+	alertService   *service.AlertService
+	silenceService *service.SilenceService
+	gateway        *gateway.Gateway
+	http           *http.Server
+	// metricsHTTP serves the /metrics endpoint, nil when disabled
+	metricsHTTP *http.Server
+	// dispatcher is nil when no notification channel is configured
+	dispatcher  *notify.Dispatcher
+	unsubscribe func()
 }
 
 // New creates a new server instance
 func New(cfg Config) (*Server, error) {
 	// Initialize dependencies
 	store := metrics.NewMetricsManager(cfg.VMEndpoint)
+	if cfg.WALDir != "" {
+		queue, err := wal.Open(cfg.WALDir, wal.Options{MaxBytes: cfg.WALMaxBytes, MaxAge: cfg.WALMaxAge})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open metrics buffer: %w", err)
+		}
+		store = metrics.NewBufferedMetricsManager(cfg.VMEndpoint, queue)
+		if cfg.MetricsAddr != "" {
+			telemetry.RegisterWALStats(queue)
+		}
+	}
 
 	// Create auth dependencies using the global database connection
 	db := database.GetDB()
+	if cfg.MetricsAddr != "" && db != nil {
+		if sqlDB, err := db.DB(); err == nil {
+			telemetry.RegisterDBStats(sqlDB)
+		}
+	}
 	userRepo := database.NewUserRepository(db)
+	apiTokenRepo := database.NewAPITokenRepository(db)
+	refreshTokenRepo := database.NewRefreshTokenRepository(db)
 	jwtService := auth.NewJWTService(cfg.JWTSecret, cfg.JWTRefreshSecret)
-	authService := service.NewAuthService(*userRepo, *jwtService)
+	authService := service.NewAuthService(*userRepo, *apiTokenRepo, *refreshTokenRepo, *jwtService)
+
+	hostGroupRepo := database.NewHostGroupRepository(db)
+	userService := service.NewUserService(userRepo, hostGroupRepo, refreshTokenRepo)
+	tokenService := service.NewTokenService(userRepo, apiTokenRepo, refreshTokenRepo)
+	hostGroupService := service.NewHostGroupService(hostGroupRepo)
+	hostRepo := database.NewHostRepository(db)
+	enrollmentService := service.NewEnrollmentService(database.NewJoinTokenRepository(db), hostRepo, jwtService)
+	hostService := service.NewHostService(hostRepo, cfg.HostOfflineAfter)
+	adminService := service.NewAdminService(userService, tokenService, hostGroupService, enrollmentService, hostService)
+	accessService := service.NewAccessService(userRepo, hostGroupRepo)
+
+	healthCheckService := service.NewHealthCheckService(hostService)
 
-	healthCheckService := service.NewHealthCheckService()
+	var fileRules []*alerting.Rule
+	if cfg.AlertRulesFile != "" {
+		var err error
+		fileRules, err = alerting.LoadRulesFile(cfg.AlertRulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load alert rules: %w", err)
+		}
+	}
+	alertService := service.NewAlertService(store, database.NewAlertRuleRepository(db), database.NewAlertRepository(db), hostRepo, fileRules)
+
+	silenceService := service.NewSilenceService(database.NewSilenceRepository(db), database.NewMaintenanceWindowRepository(db), hostRepo)
+
+	controlService := service.NewControlService(database.NewHostActionRepository(db), hostRepo)
+
+	var dispatcher *notify.Dispatcher
+	if cfg.NotificationsFile != "" {
+		channels, err := notify.LoadConfigFile(cfg.NotificationsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load notification channels: %w", err)
+		}
+		dispatcher, err = notify.NewDispatcher(channels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure notification channels: %w", err)
+		}
+	}
 
 	// Create the main handler orchestrator
-	handler := grpc.New(store, authService, healthCheckService)
+	handler := grpc.New(store, authService, enrollmentService, adminService, hostService, alertService, silenceService, controlService, healthCheckService)
+
+	serverOpts, err := transportOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup authentication config
-	authConfig := middleware.DefaultAuthConfig()
-
-	// Create gRPC server with middlewares
-	grpcServer := grpclib.NewServer(
-		grpclib.ChainUnaryInterceptor(
-			middleware.LoggingUnaryInterceptor(),
-			middleware.AuthUnaryInterceptor(authConfig),
-		),
-		grpclib.ChainStreamInterceptor(
-			middleware.LoggingStreamInterceptor(),
-			middleware.AuthStreamInterceptor(authConfig),
-		),
-	)
+	agentAuth := middleware.AgentAuth
+	if cfg.AllowUnenrolledAgents {
+		logger.Warn("Unenrolled agents are allowed, agent identities are not verified")
+		agentAuth = middleware.NoAuth
+	}
+	authConfig := middleware.DefaultAuthConfig(jwtService, accessService, enrollmentService, agentAuth)
+
+	// Create gRPC server with middlewares, the HTTP gateway runs the same ones
+	unaryInterceptors := []grpclib.UnaryServerInterceptor{
+		middleware.LoggingUnaryInterceptor(),
+		middleware.AuthUnaryInterceptor(authConfig),
+	}
+	streamInterceptors := []grpclib.StreamServerInterceptor{
+		middleware.LoggingStreamInterceptor(),
+		middleware.AuthStreamInterceptor(authConfig),
+	}
+	grpcServer := grpclib.NewServer(append(serverOpts,
+		grpclib.ChainUnaryInterceptor(unaryInterceptors...),
+		grpclib.ChainStreamInterceptor(streamInterceptors...),
+	)...)
 
 	s := &Server{
-		cfg:         cfg,
-		store:       store,
-		handler:     handler,
-		grpc:        grpcServer,
-		authService: authService,
+		cfg:            cfg,
+		store:          store,
+		handler:        handler,
+		grpc:           grpcServer,
+		authService:    authService,
+		hostService:    hostService,
+		alertService:   alertService,
+		silenceService: silenceService,
+		dispatcher:     dispatcher,
 	}
 
 	handler.RegisterServices(s.grpc)
+	if cfg.HTTPAddr != "" {
+		s.gateway = gateway.New(unaryInterceptors, streamInterceptors)
+		handler.RegisterServices(s.gateway)
+		// No write timeout, streaming responses last as long as the client follows them
+		s.http = &http.Server{
+			Handler:           s.gateway,
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		}
+	}
+
+	if cfg.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", telemetry.Default.Handler())
+		s.metricsHTTP = &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
 
 	return s, nil
 }
 
+// transportOptions returns the gRPC transport options matching the TLS configuration
+func transportOptions(cfg Config) ([]grpclib.ServerOption, error) {
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		if cfg.TLSClientCAFile != "" {
+			return nil, fmt.Errorf("a client CA requires a server certificate and key")
+		}
+		logger.Warn("TLS is disabled, credentials are sent in plaintext")
+		return nil, nil
+	}
+
+	tlsConfig, err := utils.LoadServerTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return []grpclib.ServerOption{grpclib.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
 // Start starts the server
 func (s *Server) Start() error {
+	s.store.Start()
+	if err := s.alertService.Start(); err != nil {
+		return fmt.Errorf("failed to start alerting: %w", err)
+	}
+	if err := s.silenceService.Start(); err != nil {
+		return fmt.Errorf("failed to start silences: %w", err)
+	}
+	if s.dispatcher != nil {
+		alerts, unsubscribe := s.alertService.Subscribe()
+		s.unsubscribe = unsubscribe
+		s.dispatcher.Start(alerts, s.silenceService.Muted)
+	}
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", s.cfg.GRPCAddr)
 	if err != nil {
@@ -92,6 +256,40 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	if s.http != nil {
+		httpLis, err := net.Listen("tcp", s.cfg.HTTPAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on HTTP address: %w", err)
+		}
+		go func() {
+			var err error
+			if s.cfg.TLSCertFile != "" {
+				err = s.http.ServeTLS(httpLis, s.cfg.TLSCertFile, s.cfg.TLSKeyFile)
+			} else {
+				err = s.http.Serve(httpLis)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Failed to serve HTTP gateway", zap.Error(err))
+			}
+		}()
+		logger.Info("HTTP gateway started", zap.String("http_addr", s.cfg.HTTPAddr))
+	}
+
+	if s.metricsHTTP != nil {
+		metricsLis, err := net.Listen("tcp", s.cfg.MetricsAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on metrics address: %w", err)
+		}
+		go func() {
+			if err := s.metricsHTTP.Serve(metricsLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+		logger.Info("Metrics endpoint started", zap.String("metrics_addr", s.cfg.MetricsAddr))
+	}
+
+	s.hostService.Start()
+
 	return nil
 }
 
@@ -101,6 +299,30 @@ func (s *Server) Stop(ctx context.Context) error {
 
 	s.grpc.GracefulStop()
 
+	if s.http != nil {
+		logger.Info("Stopping HTTP gateway")
+		s.gateway.Shutdown()
+		if err := s.http.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to stop HTTP gateway: %w", err)
+		}
+	}
+
+	if s.dispatcher != nil {
+		s.unsubscribe()
+		s.dispatcher.Stop()
+	}
+
+	// Once ingestion stopped, nothing is appended to the metrics buffer anymore
+	if err := s.store.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to close metrics buffer: %w", err)
+	}
+
+	if s.metricsHTTP != nil {
+		if err := s.metricsHTTP.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to stop metrics endpoint: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -5,14 +5,31 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap/zaptest"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
 )
 
+// setupDatabase connects to the PostgreSQL database of G0S_TEST_DATABASE_DSN, which starting
+// the server requires, and skips the test when it is not set
+func setupDatabase(t *testing.T) {
+	t.Helper()
+	dsn := os.Getenv("G0S_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("G0S_TEST_DATABASE_DSN is not set")
+	}
+	_, err := database.Init(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = database.Close()
+		database.DB = nil
+	})
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -38,31 +55,31 @@ func TestNew(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			logger := zaptest.NewLogger(t)
-			server, err := New(tt.config, logger)
+			server, err := New(tt.config)
 
 			require.NoError(t, err)
 			assert.NotNil(t, server)
 			assert.Equal(t, tt.config, server.cfg)
-			assert.Equal(t, logger, server.logger)
 			assert.NotNil(t, server.handler)
 		})
 	}
 }
 
 func TestServer_Start_Stop(t *testing.T) {
+	setupDatabase(t)
+
 	// Use available ports for testing
 	httpPort := getAvailablePort(t)
 	grpcPort := getAvailablePort(t)
 
 	config := Config{
 		GRPCAddr:  grpcPort,
+		HTTPAddr:  httpPort,
 		LogLevel:  "info",
 		LogFormat: "json",
 	}
 
-	logger := zaptest.NewLogger(t)
-	server, err := New(config, logger)
+	server, err := New(config)
 	require.NoError(t, err)
 
 	// Start server
@@ -72,10 +89,10 @@ func TestServer_Start_Stop(t *testing.T) {
 	// Give servers time to start
 	time.Sleep(100 * time.Millisecond)
 
-	// Test HTTP server is running
+	// Test HTTP gateway is running, paths outside of the API are not found
 	resp, err := http.Get("http://localhost" + httpPort + "/health")
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	resp.Body.Close()
 
 	// Test gRPC server is running (check if port is listening)
@@ -92,6 +109,8 @@ func TestServer_Start_Stop(t *testing.T) {
 }
 
 func TestServer_Start_GRPCPortError(t *testing.T) {
+	setupDatabase(t)
+
 	// Use an invalid gRPC address that will fail to listen
 	config := Config{
 		GRPCAddr:  "invalid-address:99999", // Invalid address
@@ -99,16 +118,12 @@ func TestServer_Start_GRPCPortError(t *testing.T) {
 		LogFormat: "json",
 	}
 
-	logger := zaptest.NewLogger(t)
-	server, err := New(config, logger)
+	server, err := New(config)
 	require.NoError(t, err)
 
-	// Start server - gRPC should fail but Start() should still return nil
+	// Start server - listening on the gRPC address fails
 	err = server.Start()
-	assert.NoError(t, err) // Start() doesn't return gRPC errors directly
-
-	// Give time for the goroutines to attempt startup
-	time.Sleep(200 * time.Millisecond)
+	assert.Error(t, err)
 
 	// Cleanup
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
@@ -117,6 +132,8 @@ func TestServer_Start_GRPCPortError(t *testing.T) {
 }
 
 func TestServer_Stop_WithError(t *testing.T) {
+	setupDatabase(t)
+
 	grpcPort := getAvailablePort(t)
 
 	config := Config{
@@ -125,8 +142,7 @@ func TestServer_Stop_WithError(t *testing.T) {
 		LogFormat: "json",
 	}
 
-	logger := zaptest.NewLogger(t)
-	server, err := New(config, logger)
+	server, err := New(config)
 	require.NoError(t, err)
 
 	// Start server
@@ -153,6 +169,8 @@ func TestServer_Stop_WithError(t *testing.T) {
 }
 
 func TestServer_Stop_Timeout(t *testing.T) {
+	setupDatabase(t)
+
 	// Use available ports for testing
 	grpcPort := getAvailablePort(t)
 
@@ -162,8 +180,7 @@ func TestServer_Stop_Timeout(t *testing.T) {
 		LogFormat: "json",
 	}
 
-	logger := zaptest.NewLogger(t)
-	server, err := New(config, logger)
+	server, err := New(config)
 	require.NoError(t, err)
 
 	// Start server
@@ -217,8 +234,7 @@ func TestServer_Configuration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			logger := zaptest.NewLogger(t)
-			server, err := New(tt.config, logger)
+			server, err := New(tt.config)
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.expectedGRPC, server.cfg.GRPCAddr)
@@ -235,8 +251,7 @@ func TestServer_GRPCServer_Setup(t *testing.T) {
 		LogFormat: "json",
 	}
 
-	logger := zaptest.NewLogger(t)
-	server, err := New(config, logger)
+	server, err := New(config)
 	require.NoError(t, err)
 
 	// Verify gRPC server is configured
@@ -259,11 +274,9 @@ func TestServer_Multiple_Instances(t *testing.T) {
 		},
 	}
 
-	logger := zaptest.NewLogger(t)
-
 	for i, config := range configs {
 		t.Run(fmt.Sprintf("instance_%d", i), func(t *testing.T) {
-			server, err := New(config, logger)
+			server, err := New(config)
 			require.NoError(t, err)
 			assert.NotNil(t, server)
 
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// AccessService resolves the role and host scope of authenticated users for the RBAC checks
+type AccessService struct {
+	userRepo      *database.UserRepository
+	hostGroupRepo *database.HostGroupRepository
+}
+
+func NewAccessService(userRepo *database.UserRepository, hostGroupRepo *database.HostGroupRepository) *AccessService {
+	return &AccessService{
+		userRepo:      userRepo,
+		hostGroupRepo: hostGroupRepo,
+	}
+}
+
+// ResolveAccess loads the access of the user. It returns auth.ErrAccessDenied if the user
+// was deleted or disabled since its token was issued.
+func (s *AccessService) ResolveAccess(_ context.Context, username string) (*auth.Access, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Disabled {
+		return nil, auth.ErrAccessDenied
+	}
+
+	role, err := auth.ParseRole(user.Role)
+	if err != nil {
+		logger.Error("User with an invalid role", zap.String("username", username), zap.Error(err))
+		return nil, auth.ErrAccessDenied
+	}
+
+	access := &auth.Access{
+		Username: user.Username,
+		Role:     role,
+		AllHosts: len(user.HostGroups) == 0,
+	}
+	if access.AllHosts {
+		return access, nil
+	}
+
+	groups, err := s.hostGroupRepo.ListByNames(user.HostGroups)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		access.HostPatterns = append(access.HostPatterns, group.HostPatterns...)
+		if len(group.HostTags) > 0 {
+			access.HostTags = append(access.HostTags, group.HostTags)
+		}
+	}
+	return access, nil
+}
+
+// hostChecker checks the access of a caller to hosts only known by their hostname, e.g. in
+// alerts and actions. When the host groups of the caller select hosts by tag, the tags of the
+// hosts are read from the inventory, once per host.
+type hostChecker struct {
+	access   *auth.Access
+	hostRepo *database.HostRepository
+	tags     map[string]map[string]string
+}
+
+func newHostChecker(access *auth.Access, hostRepo *database.HostRepository) *hostChecker {
+	return &hostChecker{
+		access:   access,
+		hostRepo: hostRepo,
+		tags:     make(map[string]map[string]string),
+	}
+}
+
+// allows reports whether the caller may see the host. Without an inventory, hosts are only
+// matched by their hostname.
+func (c *hostChecker) allows(hostname string) (bool, error) {
+	allowed := c.access.AllowsHost(hostname, nil)
+	if allowed || len(c.access.HostTags) == 0 || c.hostRepo == nil {
+		return allowed, nil
+	}
+
+	tags, ok := c.tags[hostname]
+	if !ok {
+		host, err := c.hostRepo.GetByHostname(hostname)
+		if err != nil {
+			return false, err
+		}
+		if host != nil {
+			tags = host.Tags
+		}
+		c.tags[hostname] = tags
+	}
+	return c.access.AllowsHost(hostname, tags), nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminService exposes user, token, host group, agent enrollment and host management. Callers
+// are checked to be admins by the RBAC check of the auth interceptors.
+type AdminService struct {
+	userService       *UserService
+	tokenService      *TokenService
+	hostGroupService  *HostGroupService
+	enrollmentService *EnrollmentService
+	hostService       *HostService
+}
+
+func NewAdminService(userService *UserService, tokenService *TokenService, hostGroupService *HostGroupService, enrollmentService *EnrollmentService, hostService *HostService) *AdminService {
+	return &AdminService{
+		userService:       userService,
+		tokenService:      tokenService,
+		hostGroupService:  hostGroupService,
+		enrollmentService: enrollmentService,
+		hostService:       hostService,
+	}
+}
+
+func (s *AdminService) CreateUser(_ context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	role := auth.RoleViewer
+	if req.Role != "" {
+		role = auth.Role(req.Role)
+	}
+
+	user, err := s.userService.CreateUser(req.Username, role, req.HostGroups)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *AdminService) ListUsers(_ context.Context, _ *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, err := s.userService.ListUsers()
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	res := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(users))}
+	for i := range users {
+		res.Users = append(res.Users, userToProto(&users[i]))
+	}
+	return res, nil
+}
+
+func (s *AdminService) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.User, error) {
+	if req.Username == callerName(ctx) {
+		return nil, status.Error(codes.FailedPrecondition, "admins can't disable themselves")
+	}
+
+	user, err := s.userService.DisableUser(req.Username)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *AdminService) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.User, error) {
+	if req.Username == callerName(ctx) {
+		return nil, status.Error(codes.FailedPrecondition, "admins can't change their own role")
+	}
+
+	user, err := s.userService.SetRole(req.Username, auth.Role(req.Role))
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *AdminService) SetUserHostGroups(_ context.Context, req *pb.SetUserHostGroupsRequest) (*pb.User, error) {
+	user, err := s.userService.SetHostGroups(req.Username, req.HostGroups)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *AdminService) IssueToken(_ context.Context, req *pb.IssueTokenRequest) (*pb.IssueTokenResponse, error) {
+	var ttl time.Duration
+	if req.Ttl != nil {
+		if err := req.Ttl.CheckValid(); err != nil || req.Ttl.AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl must be a positive duration")
+		}
+		ttl = req.Ttl.AsDuration()
+	}
+
+	value, token, err := s.tokenService.CreateToken(req.Username, req.Name, req.Scopes, ttl)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.IssueTokenResponse{Token: apiTokenToProto(token), Value: value}, nil
+}
+
+func (s *AdminService) ListTokens(_ context.Context, req *pb.ListTokensRequest) (*pb.ListTokensResponse, error) {
+	tokens, err := s.tokenService.ListTokens(req.Username)
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	res := &pb.ListTokensResponse{Tokens: make([]*pb.ApiToken, 0, len(tokens))}
+	for i := range tokens {
+		res.Tokens = append(res.Tokens, apiTokenToProto(&tokens[i]))
+	}
+	return res, nil
+}
+
+func (s *AdminService) RevokeToken(_ context.Context, req *pb.RevokeTokenRequest) (*pb.ApiToken, error) {
+	id, err := uuid.Parse(req.TokenId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid token id")
+	}
+
+	token, err := s.tokenService.RevokeToken(req.Username, id)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return apiTokenToProto(token), nil
+}
+
+func (s *AdminService) PutHostGroup(_ context.Context, req *pb.HostGroup) (*pb.HostGroup, error) {
+	group, err := s.hostGroupService.PutHostGroup(req.Name, req.HostPatterns, req.HostTags)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return hostGroupToProto(group), nil
+}
+
+func (s *AdminService) ListHostGroups(_ context.Context, _ *pb.ListHostGroupsRequest) (*pb.ListHostGroupsResponse, error) {
+	groups, err := s.hostGroupService.ListHostGroups()
+	if err != nil {
+		return nil, adminError(err)
+	}
+
+	res := &pb.ListHostGroupsResponse{HostGroups: make([]*pb.HostGroup, 0, len(groups))}
+	for i := range groups {
+		res.HostGroups = append(res.HostGroups, hostGroupToProto(&groups[i]))
+	}
+	return res, nil
+}
+
+func (s *AdminService) DeleteHostGroup(_ context.Context, req *pb.DeleteHostGroupRequest) (*pb.DeleteHostGroupResponse, error) {
+	if err := s.hostGroupService.DeleteHostGroup(req.Name); err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.DeleteHostGroupResponse{}, nil
+}
+
+func (s *AdminService) CreateJoinToken(ctx context.Context, req *pb.CreateJoinTokenRequest) (*pb.CreateJoinTokenResponse, error) {
+	var ttl time.Duration
+	if req.Ttl != nil {
+		if err := req.Ttl.CheckValid(); err != nil || req.Ttl.AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl must be a positive duration")
+		}
+		ttl = req.Ttl.AsDuration()
+	}
+
+	value, token, err := s.enrollmentService.CreateJoinToken(callerName(ctx), ttl)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.CreateJoinTokenResponse{
+		Id:        token.ID.String(),
+		Value:     value,
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+	}, nil
+}
+
+func (s *AdminService) RevokeAgent(_ context.Context, req *pb.RevokeAgentRequest) (*pb.Agent, error) {
+	host, err := s.enrollmentService.RevokeHost(req.Hostname)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return agentToProto(host), nil
+}
+
+func (s *AdminService) DecommissionHost(ctx context.Context, req *pb.DecommissionHostRequest) (*pb.DecommissionHostResponse, error) {
+	if err := s.hostService.Decommission(req.Hostname, callerName(ctx)); err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.DecommissionHostResponse{}, nil
+}
+
+// adminError maps user, token, host group, enrollment and host management errors to gRPC status errors
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrAPITokenNotFound), errors.Is(err, ErrHostGroupNotFound),
+		errors.Is(err, ErrHostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrUserExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrHostStateChanged):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, ErrInvalidUsername), errors.Is(err, ErrInvalidTokenName), errors.Is(err, ErrInvalidHostGroup),
+		errors.Is(err, ErrInvalidJoinTokenTTL),
+		errors.Is(err, auth.ErrInvalidScope), errors.Is(err, auth.ErrInvalidRole), errors.Is(err, auth.ErrInvalidHostPattern),
+		errors.Is(err, auth.ErrInvalidHostTag):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		logger.Error("Admin operation failed", zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func userToProto(user *models.User) *pb.User {
+	return &pb.User{
+		Id:         user.ID.String(),
+		Username:   user.Username,
+		Role:       user.Role,
+		HostGroups: user.HostGroups,
+		Disabled:   user.Disabled,
+		CreatedAt:  timestamppb.New(user.CreatedAt),
+	}
+}
+
+func hostGroupToProto(group *models.HostGroup) *pb.HostGroup {
+	return &pb.HostGroup{
+		Name:         group.Name,
+		HostPatterns: group.HostPatterns,
+		HostTags:     group.HostTags,
+	}
+}
+
+func agentToProto(host *models.Host) *pb.Agent {
+	res := &pb.Agent{
+		HostId:   host.ID.String(),
+		Hostname: host.Hostname,
+		Revoked:  host.Revoked,
+	}
+	if host.EnrolledAt != nil {
+		res.EnrolledAt = timestamppb.New(*host.EnrolledAt)
+	}
+	return res
+}
+
+func apiTokenToProto(token *models.APIToken) *pb.ApiToken {
+	res := &pb.ApiToken{
+		Id:        token.ID.String(),
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		Revoked:   token.Revoked,
+		CreatedAt: timestamppb.New(token.CreatedAt),
+	}
+	if token.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*token.ExpiresAt)
+	}
+	if token.LastUsedAt != nil {
+		res.LastUsedAt = timestamppb.New(*token.LastUsedAt)
+	}
+	return res
+}
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/alerting"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/internal/server/storage/metrics"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/alert"
+	pbmetric "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	_defaultAlertListLimit = 100
+	_maxAlertListLimit     = 1000
+	// _alertStaleAfter is how long an active alert is kept without any sample of its series,
+	// e.g. because its host went offline, before being resolved
+	_alertStaleAfter = 10 * time.Minute
+	// _alertSweepInterval is how often stale alerts are looked for
+	_alertSweepInterval = time.Minute
+	// _alertEventBuffer is the number of alerts buffered per subscriber before new alerts are
+	// dropped for that subscriber
+	_alertEventBuffer = 256
+)
+
+// Sources of the alert rules
+const (
+	AlertRuleSourceFile     = "file"
+	AlertRuleSourceDatabase = "database"
+)
+
+var (
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+	ErrAlertRuleReadOnly = errors.New("alert rule is defined in the rules file of the server")
+)
+
+// activeAlert is a pending or firing alert with when its series was last received
+type activeAlert struct {
+	alert    *models.Alert
+	lastSeen time.Time
+}
+
+// AlertService evaluates the alert rules inline on every metrics payload received from the
+// agents. Alerts move from pending to firing once their condition held for the duration of
+// their rule, then to resolved, and are persisted on every transition. Firing and resolved
+// alerts are published to the subscribers, e.g. to be notified.
+type AlertService struct {
+	store      *metrics.Manager
+	ruleRepo   *database.AlertRuleRepository
+	alertRepo  *database.AlertRepository
+	hostRepo   *database.HostRepository
+	fileRules  []*alerting.Rule
+	rules      []*alerting.Rule
+	rulesLock  sync.RWMutex
+	active     map[string]*activeAlert
+	activeLock sync.Mutex
+
+	subscribers     map[chan models.Alert]struct{}
+	subscribersLock sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewAlertService returns the alert service evaluating fileRules, which can't be changed
+// through the API, and the rules stored in the database. The tags of the hosts are read from
+// hostRepo to list the alerts of the hosts the caller can see.
+func NewAlertService(store *metrics.Manager, ruleRepo *database.AlertRuleRepository, alertRepo *database.AlertRepository, hostRepo *database.HostRepository, fileRules []*alerting.Rule) *AlertService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AlertService{
+		store:       store,
+		ruleRepo:    ruleRepo,
+		alertRepo:   alertRepo,
+		hostRepo:    hostRepo,
+		fileRules:   fileRules,
+		active:      make(map[string]*activeAlert),
+		subscribers: make(map[chan models.Alert]struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start loads the rules and the alerts still active from the database, then starts resolving
+// the alerts whose series are no longer received
+func (s *AlertService) Start() error {
+	if err := s.loadRules(); err != nil {
+		return err
+	}
+
+	alerts, err := s.alertRepo.ListActive()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	s.activeLock.Lock()
+	for i := range alerts {
+		s.active[alerts[i].Fingerprint] = &activeAlert{alert: &alerts[i], lastSeen: now}
+	}
+	s.activeLock.Unlock()
+
+	logger.Info("Alerting started", zap.Int("rules", len(s.currentRules())), zap.Int("active_alerts", len(alerts)))
+	go s.sweep()
+	return nil
+}
+
+func (s *AlertService) Shutdown() {
+	s.cancel()
+}
+
+// loadRules merges the rules of the file with the rules of the database, file rules taking
+// precedence over database rules of the same name
+func (s *AlertService) loadRules() error {
+	stored, err := s.ruleRepo.List()
+	if err != nil {
+		return err
+	}
+
+	rules := append([]*alerting.Rule{}, s.fileRules...)
+	for i := range stored {
+		if s.fileRule(stored[i].Name) != nil {
+			logger.Warn("Alert rule of the database shadowed by the rules file", zap.String("rule", stored[i].Name))
+			continue
+		}
+		rule, err := alerting.NewRule(stored[i].Name, stored[i].Expr, stored[i].Severity, stored[i].Description)
+		if err != nil {
+			logger.Error("Skipping invalid alert rule", zap.String("rule", stored[i].Name), zap.Error(err))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	s.rulesLock.Lock()
+	s.rules = rules
+	s.rulesLock.Unlock()
+	return nil
+}
+
+func (s *AlertService) currentRules() []*alerting.Rule {
+	s.rulesLock.RLock()
+	defer s.rulesLock.RUnlock()
+	return s.rules
+}
+
+func (s *AlertService) fileRule(name string) *alerting.Rule {
+	for _, rule := range s.fileRules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// nextAlertState returns the state of an alert whose condition holds, since held, or no
+// longer holds. An empty state means the alert is dropped.
+func nextAlertState(current models.AlertState, holds bool, held, forDuration time.Duration) models.AlertState {
+	switch {
+	case !holds && current == models.AlertStateFiring:
+		return models.AlertStateResolved
+	case !holds:
+		return ""
+	case current == models.AlertStateFiring || held >= forDuration:
+		return models.AlertStateFiring
+	default:
+		return models.AlertStatePending
+	}
+}
+
+// alertFingerprint identifies the alerts of a rule on a series
+func alertFingerprint(rule string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(rule))
+	for _, key := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(key + "=" + labels[key]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Evaluate evaluates every rule against the series of the payload. The active alerts of
+// the host whose series are missing from the payload no longer hold.
+func (s *AlertService) Evaluate(payload *pbmetric.MetricsPayload) {
+	hostname := payload.Host.GetHostname()
+	if hostname == "" {
+		return
+	}
+
+	rules := s.currentRules()
+	if len(rules) == 0 {
+		return
+	}
+	samples := s.store.Samples(payload)
+	now := time.Now()
+
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		for _, sample := range samples {
+			if !rule.Matches(sample.Name, sample.Labels) {
+				continue
+			}
+			fingerprint := alertFingerprint(rule.Name, sample.Labels)
+			seen[fingerprint] = true
+			s.step(rule, fingerprint, hostname, sample, now)
+		}
+	}
+
+	for fingerprint, active := range s.active {
+		if active.alert.Hostname == hostname && !seen[fingerprint] {
+			s.clear(fingerprint, active, now)
+		}
+	}
+}
+
+// step moves the alert of the rule on the series of the sample to its next state
+func (s *AlertService) step(rule *alerting.Rule, fingerprint, hostname string, sample metrics.Sample, now time.Time) {
+	active, ok := s.active[fingerprint]
+	holds := rule.Exceeds(sample.Value)
+	if !holds {
+		if ok {
+			s.clear(fingerprint, active, now)
+		}
+		return
+	}
+
+	if !ok {
+		active = &activeAlert{alert: &models.Alert{
+			ID:          uuid.New(),
+			RuleName:    rule.Name,
+			Fingerprint: fingerprint,
+			Hostname:    hostname,
+			Labels:      sample.Labels,
+			Severity:    rule.Severity,
+			Description: rule.Description,
+			StartedAt:   now,
+		}}
+		s.active[fingerprint] = active
+	}
+	active.lastSeen = now
+
+	alert := active.alert
+	state := nextAlertState(alert.State, true, now.Sub(alert.StartedAt), rule.For)
+	if state == alert.State {
+		return
+	}
+	alert.State = state
+	alert.Value = sample.Value
+	if state == models.AlertStateFiring {
+		alert.FiredAt = &now
+		logger.Warn("Alert firing",
+			zap.String("rule", alert.RuleName),
+			zap.String("hostname", alert.Hostname),
+			zap.Float64("value", alert.Value))
+	}
+	if err := s.alertRepo.Save(alert); err != nil {
+		logger.Error("Failed to save alert", zap.String("rule", alert.RuleName), zap.Error(err))
+	}
+	if state == models.AlertStateFiring {
+		s.publish(alert)
+	}
+}
+
+// clear ends an active alert whose condition no longer holds: firing alerts are resolved
+// and pending ones dropped
+func (s *AlertService) clear(fingerprint string, active *activeAlert, now time.Time) {
+	delete(s.active, fingerprint)
+
+	alert := active.alert
+	if nextAlertState(alert.State, false, 0, 0) == "" {
+		if err := s.alertRepo.Delete(alert); err != nil {
+			logger.Error("Failed to delete pending alert", zap.String("rule", alert.RuleName), zap.Error(err))
+		}
+		return
+	}
+
+	alert.State = models.AlertStateResolved
+	alert.ResolvedAt = &now
+	logger.Info("Alert resolved", zap.String("rule", alert.RuleName), zap.String("hostname", alert.Hostname))
+	if err := s.alertRepo.Save(alert); err != nil {
+		logger.Error("Failed to save alert", zap.String("rule", alert.RuleName), zap.Error(err))
+	}
+	s.publish(alert)
+}
+
+// Subscribe returns a channel receiving a copy of every alert that starts firing or is resolved,
+// until unsubscribe is called. Alerts are dropped for subscribers too slow to receive them.
+func (s *AlertService) Subscribe() (alerts <-chan models.Alert, unsubscribe func()) {
+	ch := make(chan models.Alert, _alertEventBuffer)
+
+	s.subscribersLock.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subscribersLock.Unlock()
+
+	return ch, func() {
+		s.subscribersLock.Lock()
+		delete(s.subscribers, ch)
+		s.subscribersLock.Unlock()
+	}
+}
+
+func (s *AlertService) publish(alert *models.Alert) {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- *alert:
+		default:
+			logger.Warn("Alert subscriber is too slow, dropping alert",
+				zap.String("rule", alert.RuleName),
+				zap.String("hostname", alert.Hostname))
+		}
+	}
+}
+
+// clearRule ends the active alerts of the rule, after it was changed or deleted
+func (s *AlertService) clearRule(name string) {
+	now := time.Now()
+	s.activeLock.Lock()
+	defer s.activeLock.Unlock()
+
+	for fingerprint, active := range s.active {
+		if active.alert.RuleName == name {
+			s.clear(fingerprint, active, now)
+		}
+	}
+}
+
+// sweep resolves the alerts whose series were not received for _alertStaleAfter
+func (s *AlertService) sweep() {
+	ticker := time.NewTicker(_alertSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.activeLock.Lock()
+			for fingerprint, active := range s.active {
+				if now.Sub(active.lastSeen) > _alertStaleAfter {
+					s.clear(fingerprint, active, now)
+				}
+			}
+			s.activeLock.Unlock()
+		}
+	}
+}
+
+// ListAlerts returns the alerts of the hosts the caller can see, most recent first
+func (s *AlertService) ListAlerts(ctx context.Context, req *pb.ListAlertsRequest) (*pb.ListAlertsResponse, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	hostFilter, err := metrics.CompileHostFilter(req.HostFilter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = _defaultAlertListLimit
+	}
+	limit = min(limit, _maxAlertListLimit)
+	var state models.AlertState
+	for modelState, protoState := range _alertStateToProto {
+		if protoState == req.State {
+			state = modelState
+		}
+	}
+
+	// Alerts of hosts the caller can't see are filtered out here, so read pages until
+	// the limit is reached
+	res := &pb.ListAlertsResponse{}
+	hosts := newHostChecker(access, s.hostRepo)
+	for offset := 0; len(res.Alerts) < limit; offset += limit {
+		alerts, err := s.alertRepo.List(state, offset, limit)
+		if err != nil {
+			logger.Error("Failed to list alerts", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to list alerts")
+		}
+		for i := range alerts {
+			if len(res.Alerts) == limit {
+				break
+			}
+			if !hostFilter.MatchString(alerts[i].Hostname) {
+				continue
+			}
+			allowed, err := hosts.allows(alerts[i].Hostname)
+			if err != nil {
+				logger.Error("Failed to check access to host", zap.String("hostname", alerts[i].Hostname), zap.Error(err))
+				return nil, status.Error(codes.Internal, "failed to list alerts")
+			}
+			if allowed {
+				res.Alerts = append(res.Alerts, alertToProto(&alerts[i]))
+			}
+		}
+		if len(alerts) < limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (s *AlertService) ListAlertRules(_ context.Context, _ *pb.ListAlertRulesRequest) (*pb.ListAlertRulesResponse, error) {
+	rules := s.currentRules()
+	res := &pb.ListAlertRulesResponse{Rules: make([]*pb.AlertRule, 0, len(rules))}
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, s.alertRuleToProto(rule))
+	}
+	return res, nil
+}
+
+// PutAlertRule creates or replaces a rule of the database. Replacing a rule resolves its alerts,
+// they are evaluated again from the next payloads.
+func (s *AlertService) PutAlertRule(_ context.Context, req *pb.AlertRule) (*pb.AlertRule, error) {
+	rule, err := alerting.NewRule(req.Name, req.Expr, req.Severity, req.Description)
+	if err != nil {
+		return nil, alertError(err)
+	}
+	if s.fileRule(rule.Name) != nil {
+		return nil, alertError(ErrAlertRuleReadOnly)
+	}
+
+	stored, err := s.ruleRepo.GetByName(rule.Name)
+	if err != nil {
+		return nil, alertError(err)
+	}
+	if stored == nil {
+		stored = &models.AlertRule{ID: uuid.New(), Name: rule.Name}
+	}
+	stored.Expr = rule.Expr
+	stored.Severity = rule.Severity
+	stored.Description = rule.Description
+	if err := s.ruleRepo.Save(stored); err != nil {
+		return nil, alertError(err)
+	}
+
+	s.clearRule(rule.Name)
+	if err := s.loadRules(); err != nil {
+		return nil, alertError(err)
+	}
+	logger.Info("Alert rule saved", zap.String("rule", rule.Name), zap.String("expr", rule.Expr))
+	return s.alertRuleToProto(rule), nil
+}
+
+// DeleteAlertRule deletes a rule of the database and resolves its alerts
+func (s *AlertService) DeleteAlertRule(_ context.Context, req *pb.DeleteAlertRuleRequest) (*pb.DeleteAlertRuleResponse, error) {
+	if s.fileRule(req.Name) != nil {
+		return nil, alertError(ErrAlertRuleReadOnly)
+	}
+
+	deleted, err := s.ruleRepo.Delete(req.Name)
+	if err != nil {
+		return nil, alertError(err)
+	}
+	if !deleted {
+		return nil, alertError(ErrAlertRuleNotFound)
+	}
+
+	s.clearRule(req.Name)
+	if err := s.loadRules(); err != nil {
+		return nil, alertError(err)
+	}
+	logger.Info("Alert rule deleted", zap.String("rule", req.Name))
+	return &pb.DeleteAlertRuleResponse{}, nil
+}
+
+// alertError maps alert rule management errors to gRPC status errors
+func alertError(err error) error {
+	switch {
+	case errors.Is(err, ErrAlertRuleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrAlertRuleReadOnly):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, alerting.ErrInvalidRuleName), errors.Is(err, alerting.ErrInvalidExpr),
+		errors.Is(err, alerting.ErrInvalidSeverity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		logger.Error("Alert operation failed", zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+var _alertStateToProto = map[models.AlertState]pb.AlertState{
+	models.AlertStatePending:  pb.AlertState_ALERT_STATE_PENDING,
+	models.AlertStateFiring:   pb.AlertState_ALERT_STATE_FIRING,
+	models.AlertStateResolved: pb.AlertState_ALERT_STATE_RESOLVED,
+}
+
+func (s *AlertService) alertRuleToProto(rule *alerting.Rule) *pb.AlertRule {
+	source := AlertRuleSourceDatabase
+	if s.fileRule(rule.Name) != nil {
+		source = AlertRuleSourceFile
+	}
+	return &pb.AlertRule{
+		Name:        rule.Name,
+		Expr:        rule.Expr,
+		Severity:    rule.Severity,
+		Description: rule.Description,
+		Source:      source,
+	}
+}
+
+func alertToProto(alert *models.Alert) *pb.Alert {
+	res := &pb.Alert{
+		Id:          alert.ID.String(),
+		Rule:        alert.RuleName,
+		Hostname:    alert.Hostname,
+		Labels:      alert.Labels,
+		State:       _alertStateToProto[alert.State],
+		Value:       alert.Value,
+		Severity:    alert.Severity,
+		Description: alert.Description,
+		StartedAt:   timestamppb.New(alert.StartedAt),
+	}
+	if alert.FiredAt != nil {
+		res.FiredAt = timestamppb.New(*alert.FiredAt)
+	}
+	if alert.ResolvedAt != nil {
+		res.ResolvedAt = timestamppb.New(*alert.ResolvedAt)
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/theotruvelot/g0s/internal/server/models"
+)
+
+func TestNextAlertState(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  models.AlertState
+		holds    bool
+		held     time.Duration
+		expected models.AlertState
+	}{
+		{name: "starts pending", holds: true, expected: models.AlertStatePending},
+		{name: "stays pending", current: models.AlertStatePending, holds: true, held: 4 * time.Minute, expected: models.AlertStatePending},
+		{name: "fires after its duration", current: models.AlertStatePending, holds: true, held: 5 * time.Minute, expected: models.AlertStateFiring},
+		{name: "keeps firing", current: models.AlertStateFiring, holds: true, held: time.Hour, expected: models.AlertStateFiring},
+		{name: "pending is dropped", current: models.AlertStatePending, expected: ""},
+		{name: "firing is resolved", current: models.AlertStateFiring, expected: models.AlertStateResolved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextAlertState(tt.current, tt.holds, tt.held, 5*time.Minute))
+		})
+	}
+
+	assert.Equal(t, models.AlertStateFiring, nextAlertState("", true, 0, 0), "rules without duration fire at once")
+}
+
+func TestAlertFingerprint(t *testing.T) {
+	labels := map[string]string{"host": "web-1", "core_id": "0"}
+	assert.Equal(t, alertFingerprint("high-cpu", labels), alertFingerprint("high-cpu", map[string]string{"core_id": "0", "host": "web-1"}))
+	assert.NotEqual(t, alertFingerprint("high-cpu", labels), alertFingerprint("hot-cpu", labels))
+	assert.NotEqual(t, alertFingerprint("high-cpu", labels), alertFingerprint("high-cpu", map[string]string{"host": "web-1", "core_id": "1"}))
+}
@@ -2,25 +2,33 @@ package service
 
 import (
 	"errors"
+	"github.com/google/uuid"
 	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
 	"github.com/theotruvelot/g0s/internal/server/storage/database"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	"go.uber.org/zap"
+	"time"
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
 )
 
 type AuthService struct {
-	UserRepo   *database.UserRepository
-	JWTService *auth.JWTService
+	UserRepo         *database.UserRepository
+	APITokenRepo     *database.APITokenRepository
+	RefreshTokenRepo *database.RefreshTokenRepository
+	JWTService       *auth.JWTService
 }
 
-func NewAuthService(userRepo database.UserRepository, jwtService auth.JWTService) *AuthService {
+func NewAuthService(userRepo database.UserRepository, apiTokenRepo database.APITokenRepository, refreshTokenRepo database.RefreshTokenRepository, jwtService auth.JWTService) *AuthService {
 	return &AuthService{
-		UserRepo:   &userRepo,
-		JWTService: &jwtService,
+		UserRepo:         &userRepo,
+		APITokenRepo:     &apiTokenRepo,
+		RefreshTokenRepo: &refreshTokenRepo,
+		JWTService:       &jwtService,
 	}
 }
 
@@ -31,10 +39,119 @@ func (a *AuthService) Authenticate(username, token string) (auth.Token, error) {
 		return auth.Token{}, err
 	}
 
-	if user == nil || user.Token != token {
+	if user == nil || user.Disabled {
 		logger.Info("Invalid credentials", zap.String("username", username))
 		return auth.Token{}, ErrInvalidCredentials
 	}
 
-	return a.JWTService.GenerateJWT(user.Username)
+	apiToken, err := a.matchAPIToken(user, token)
+	if err != nil {
+		logger.Error("Error Authentication", zap.Error(err))
+		return auth.Token{}, err
+	}
+	if apiToken == nil {
+		logger.Info("Invalid credentials", zap.String("username", username))
+		return auth.Token{}, ErrInvalidCredentials
+	}
+
+	if err := a.APITokenRepo.TouchLastUsed(apiToken.ID); err != nil {
+		logger.Warn("Failed to record API token usage", zap.Error(err))
+	}
+	if err := a.RefreshTokenRepo.DeleteExpired(); err != nil {
+		logger.Warn("Failed to delete expired refresh tokens", zap.Error(err))
+	}
+
+	return a.issueTokens(user.Username, apiToken)
+}
+
+// matchAPIToken returns the active API token of the user matching token, or nil if there is none.
+// Every active token is compared so the time taken doesn't tell which one matched.
+func (a *AuthService) matchAPIToken(user *models.User, token string) (*models.APIToken, error) {
+	apiTokens, err := a.APITokenRepo.ListActiveByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *models.APIToken
+	for i := range apiTokens {
+		if auth.MatchAPIToken(token, apiTokens[i].TokenHash) {
+			match = &apiTokens[i]
+		}
+	}
+	return match, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. Refresh tokens are rotated:
+// each one can be used once, and presenting an already used one revokes every refresh token
+// of the user since it means the token leaked.
+func (a *AuthService) RefreshToken(refreshToken string) (auth.Token, error) {
+	claims, err := a.JWTService.CheckJWT(refreshToken, true)
+	if err != nil {
+		logger.Info("Invalid refresh token", zap.Error(err))
+		return auth.Token{}, ErrInvalidRefreshToken
+	}
+
+	id, err := uuid.Parse(claims.ID)
+	if err != nil {
+		logger.Info("Refresh token without a valid id", zap.String("username", claims.Username))
+		return auth.Token{}, ErrInvalidRefreshToken
+	}
+
+	refresh, err := a.RefreshTokenRepo.Consume(id)
+	if err != nil {
+		logger.Error("Error consuming refresh token", zap.Error(err))
+		return auth.Token{}, err
+	}
+	if refresh == nil {
+		logger.Warn("Refresh token reused, revoking the sessions of the user", zap.String("username", claims.Username))
+		if err := a.RefreshTokenRepo.RevokeAllForUser(claims.Username); err != nil {
+			logger.Error("Error revoking refresh tokens", zap.Error(err))
+		}
+		return auth.Token{}, ErrInvalidRefreshToken
+	}
+
+	// The user may have been deleted or disabled since the token was issued
+	user, err := a.UserRepo.GetUserByUsername(claims.Username)
+	if err != nil {
+		logger.Error("Error loading user", zap.Error(err))
+		return auth.Token{}, err
+	}
+	if user == nil || user.Disabled {
+		return auth.Token{}, ErrInvalidRefreshToken
+	}
+
+	// So is the API token the session was opened with
+	apiToken, err := a.APITokenRepo.GetByID(user.ID, refresh.APITokenID)
+	if err != nil {
+		logger.Error("Error loading API token", zap.Error(err))
+		return auth.Token{}, err
+	}
+	if apiToken == nil || !apiToken.Active(time.Now()) {
+		logger.Info("Refresh token of a revoked or expired API token", zap.String("username", claims.Username))
+		return auth.Token{}, ErrInvalidRefreshToken
+	}
+
+	return a.issueTokens(user.Username, apiToken)
+}
+
+// issueTokens generates a token pair carrying the scopes of the API token and records the
+// refresh token so it can be consumed once
+func (a *AuthService) issueTokens(username string, apiToken *models.APIToken) (auth.Token, error) {
+	token, err := a.JWTService.GenerateJWT(username, apiToken.Scopes)
+	if err != nil {
+		return auth.Token{}, err
+	}
+
+	err = a.RefreshTokenRepo.Create(&models.RefreshToken{
+		ID:         uuid.MustParse(token.RefreshTokenID),
+		Username:   username,
+		APITokenID: apiToken.ID,
+		ExpiresAt:  token.RefreshExpiresAt,
+	})
+	if err != nil {
+		logger.Error("Error storing refresh token", zap.Error(err))
+		return auth.Token{}, err
+	}
+
+	return token, nil
 }
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/control"
+	"github.com/theotruvelot/g0s/pkg/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// _agentCommandTimeout is how long the agent has to answer a command. Agents act right
+	// away, so no answer in time means its stream is broken.
+	_agentCommandTimeout = 5 * time.Second
+
+	_defaultActionListLimit = 50
+	_maxActionListLimit     = 500
+)
+
+var (
+	ErrAgentNotConnected = errors.New("the agent of the host is not connected to this server")
+	ErrAgentReplaced     = errors.New("the agent opened another control stream")
+	ErrAgentTimeout      = errors.New("the agent did not answer in time")
+)
+
+var _signalNames = map[pb.Signal]string{
+	pb.Signal_SIGNAL_TERM: "TERM",
+	pb.Signal_SIGNAL_KILL: "KILL",
+	pb.Signal_SIGNAL_HUP:  "HUP",
+}
+
+var _unitOperationNames = map[pb.UnitOperation]string{
+	pb.UnitOperation_UNIT_OPERATION_START:   "start",
+	pb.UnitOperation_UNIT_OPERATION_STOP:    "stop",
+	pb.UnitOperation_UNIT_OPERATION_RESTART: "restart",
+}
+
+var _actionStatusToProto = map[models.HostActionStatus]pb.ActionStatus{
+	models.HostActionPending:   pb.ActionStatus_ACTION_STATUS_PENDING,
+	models.HostActionSucceeded: pb.ActionStatus_ACTION_STATUS_SUCCEEDED,
+	models.HostActionRefused:   pb.ActionStatus_ACTION_STATUS_REFUSED,
+	models.HostActionNotFound:  pb.ActionStatus_ACTION_STATUS_NOT_FOUND,
+	models.HostActionFailed:    pb.ActionStatus_ACTION_STATUS_FAILED,
+}
+
+// controlAgent is the control stream of a connected agent
+type controlAgent struct {
+	hostname string
+	// send is not safe for concurrent use, sendLock serializes the commands
+	send     func(*pb.Command) error
+	sendLock sync.Mutex
+	// pending are the commands waiting for their result, by id
+	pending map[string]chan *pb.CommandResult
+	lock    sync.Mutex
+	// done is closed once the stream is gone, err tells why
+	done chan struct{}
+	err  error
+}
+
+// close ends the stream of the agent, the commands waiting for a result fail with err
+func (a *controlAgent) close(err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	select {
+	case <-a.done:
+	default:
+		a.err = err
+		close(a.done)
+	}
+}
+
+// ControlService sends the actions requested by the operators to the agents of the hosts,
+// through the control streams the agents keep open, and keeps an audit record of every action.
+// Only the agents connected to this server can be reached.
+type ControlService struct {
+	actionRepo *database.HostActionRepository
+	hostRepo   *database.HostRepository
+	agents     map[string]*controlAgent
+	lock       sync.Mutex
+	timeout    time.Duration
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func NewControlService(actionRepo *database.HostActionRepository, hostRepo *database.HostRepository) *ControlService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ControlService{
+		actionRepo: actionRepo,
+		hostRepo:   hostRepo,
+		agents:     make(map[string]*controlAgent),
+		timeout:    _agentCommandTimeout,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Shutdown closes the control streams of the agents
+func (s *ControlService) Shutdown() {
+	s.cancel()
+}
+
+// AgentControl serves the control stream of the agent of hostname, whose identity was checked
+// by the caller, until the agent leaves, opens another stream or the server shuts down
+func (s *ControlService) AgentControl(ctx context.Context, hostname string, send func(*pb.Command) error, recv func() (*pb.AgentMessage, error)) error {
+	agent := &controlAgent{
+		hostname: hostname,
+		send:     send,
+		pending:  make(map[string]chan *pb.CommandResult),
+		done:     make(chan struct{}),
+	}
+
+	s.lock.Lock()
+	previous := s.agents[hostname]
+	s.agents[hostname] = agent
+	s.lock.Unlock()
+	if previous != nil {
+		previous.close(ErrAgentReplaced)
+	}
+	logger.Info("Agent control stream opened", zap.String("hostname", hostname))
+
+	defer func() {
+		s.lock.Lock()
+		if s.agents[hostname] == agent {
+			delete(s.agents, hostname)
+		}
+		s.lock.Unlock()
+		agent.close(ErrAgentNotConnected)
+		logger.Info("Agent control stream closed", zap.String("hostname", hostname))
+	}()
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			result := msg.GetResult()
+			if result == nil {
+				continue
+			}
+			agent.lock.Lock()
+			ch, ok := agent.pending[result.Id]
+			delete(agent.pending, result.Id)
+			agent.lock.Unlock()
+			if !ok {
+				logger.Warn("Dropping result of an unknown command",
+					zap.String("hostname", hostname),
+					zap.String("command_id", result.Id))
+				continue
+			}
+			ch <- result
+		}
+	}()
+
+	select {
+	case err := <-received:
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	case <-agent.done:
+		return status.Error(codes.Aborted, agent.err.Error())
+	case <-ctx.Done():
+		return nil
+	case <-s.ctx.Done():
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+}
+
+// dispatch sends a command to the agent of hostname and returns its result
+func (s *ControlService) dispatch(ctx context.Context, hostname string, cmd *pb.Command) (*pb.CommandResult, error) {
+	s.lock.Lock()
+	agent := s.agents[hostname]
+	s.lock.Unlock()
+	if agent == nil {
+		return nil, ErrAgentNotConnected
+	}
+
+	result := make(chan *pb.CommandResult, 1)
+	agent.lock.Lock()
+	agent.pending[cmd.Id] = result
+	agent.lock.Unlock()
+	defer func() {
+		agent.lock.Lock()
+		delete(agent.pending, cmd.Id)
+		agent.lock.Unlock()
+	}()
+
+	agent.sendLock.Lock()
+	err := agent.send(cmd)
+	agent.sendLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case res := <-result:
+		return res, nil
+	case <-agent.done:
+		return nil, agent.err
+	case <-timer.C:
+		return nil, ErrAgentTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SignalProcess sends a signal to the process with the pid, or the processes with the name, of
+// a host the caller can see. The action is recorded before it is sent to the agent, the record
+// is then completed with the result of the agent and returned.
+func (s *ControlService) SignalProcess(ctx context.Context, req *pb.SignalProcessRequest) (*pb.ActionResult, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	signal, ok := _signalNames[req.Signal]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "a signal is required: TERM, KILL or HUP")
+	}
+	if (req.Pid > 0) == (req.Name != "") {
+		return nil, status.Error(codes.InvalidArgument, "either a pid or a process name is required")
+	}
+	if req.Pid < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid pid")
+	}
+	if err := s.checkHost(access, req.Hostname); err != nil {
+		return nil, err
+	}
+
+	action := &models.HostAction{
+		Hostname:    req.Hostname,
+		Action:      "signal " + signal,
+		PID:         req.Pid,
+		ProcessName: req.Name,
+		RequestedBy: access.Username,
+		Comment:     req.Comment,
+	}
+	return s.run(ctx, action, &pb.Command{
+		Action: &pb.Command_SignalProcess{SignalProcess: &pb.SignalProcessCommand{
+			Pid:    req.Pid,
+			Name:   req.Name,
+			Signal: req.Signal,
+		}},
+	})
+}
+
+// checkHost returns a not found error if the caller can't see the host
+func (s *ControlService) checkHost(access *auth.Access, hostname string) error {
+	if hostname == "" {
+		return status.Error(codes.NotFound, ErrHostNotFound.Error())
+	}
+	allowed, err := newHostChecker(access, s.hostRepo).allows(hostname)
+	if err != nil {
+		logger.Error("Failed to check access to host", zap.String("hostname", hostname), zap.Error(err))
+		return status.Error(codes.Internal, "failed to get host")
+	}
+	if !allowed {
+		return status.Error(codes.NotFound, ErrHostNotFound.Error())
+	}
+	return nil
+}
+
+// ControlUnit starts, stops or restarts a systemd unit of a host the caller can see, the same
+// way as SignalProcess
+func (s *ControlService) ControlUnit(ctx context.Context, req *pb.ControlUnitRequest) (*pb.ActionResult, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	operation, ok := _unitOperationNames[req.Operation]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "an operation is required: start, stop or restart")
+	}
+	unit, err := utils.NormalizeUnitName(req.Unit)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkHost(access, req.Hostname); err != nil {
+		return nil, err
+	}
+
+	action := &models.HostAction{
+		Hostname:    req.Hostname,
+		Action:      operation,
+		Unit:        unit,
+		RequestedBy: access.Username,
+		Comment:     req.Comment,
+	}
+	return s.run(ctx, action, &pb.Command{
+		Action: &pb.Command_Unit{Unit: &pb.UnitCommand{
+			Unit:      unit,
+			Operation: req.Operation,
+		}},
+	})
+}
+
+// run records the action before sending the command to the agent of the host, then
+// completes the record with the result of the agent and returns it
+func (s *ControlService) run(ctx context.Context, action *models.HostAction, cmd *pb.Command) (*pb.ActionResult, error) {
+	action.ID = uuid.New()
+	action.Status = models.HostActionPending
+	action.RequestedAt = time.Now()
+	if err := s.actionRepo.Save(action); err != nil {
+		logger.Error("Failed to write audit record", zap.String("hostname", action.Hostname), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to write the audit record, the action was not sent")
+	}
+	logger.Info("Sending action to agent",
+		zap.String("action_id", action.ID.String()),
+		zap.String("hostname", action.Hostname),
+		zap.String("action", action.Action),
+		zap.Int32("pid", action.PID),
+		zap.String("process_name", action.ProcessName),
+		zap.String("unit", action.Unit),
+		zap.String("requested_by", action.RequestedBy))
+
+	cmd.Id = action.ID.String()
+	result, err := s.dispatch(ctx, action.Hostname, cmd)
+	completeAction(action, result, err, time.Now())
+	if err := s.actionRepo.Save(action); err != nil {
+		logger.Error("Failed to complete audit record",
+			zap.String("action_id", action.ID.String()),
+			zap.String("status", string(action.Status)),
+			zap.Error(err))
+	}
+	logger.Info("Action completed",
+		zap.String("action_id", action.ID.String()),
+		zap.String("hostname", action.Hostname),
+		zap.String("status", string(action.Status)),
+		zap.String("message", action.Message))
+	return actionToProto(action), nil
+}
+
+// completeAction records the result of the agent, or why there is none, in the action
+func completeAction(action *models.HostAction, result *pb.CommandResult, err error, now time.Time) {
+	action.CompletedAt = &now
+	if err != nil {
+		action.Status = models.HostActionFailed
+		action.Message = err.Error()
+		return
+	}
+	action.Status = models.HostActionFailed
+	for modelStatus, protoStatus := range _actionStatusToProto {
+		if protoStatus == result.Status && modelStatus != models.HostActionPending {
+			action.Status = modelStatus
+		}
+	}
+	action.Message = result.Message
+	action.PIDs = result.Pids
+}
+
+// ListActions returns the audit records of the actions on the hosts the caller can see
+func (s *ControlService) ListActions(ctx context.Context, req *pb.ListActionsRequest) (*pb.ListActionsResponse, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = _defaultActionListLimit
+	}
+	limit = min(limit, _maxActionListLimit)
+
+	// Actions on hosts the caller can't see are filtered out here, so read pages until
+	// the limit is reached
+	res := &pb.ListActionsResponse{}
+	hosts := newHostChecker(access, s.hostRepo)
+	for offset := 0; len(res.Actions) < limit; offset += limit {
+		actions, err := s.actionRepo.List(req.Hostname, offset, limit)
+		if err != nil {
+			logger.Error("Failed to list actions", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to list actions")
+		}
+		for i := range actions {
+			if len(res.Actions) == limit {
+				break
+			}
+			allowed, err := hosts.allows(actions[i].Hostname)
+			if err != nil {
+				logger.Error("Failed to check access to host", zap.String("hostname", actions[i].Hostname), zap.Error(err))
+				return nil, status.Error(codes.Internal, "failed to list actions")
+			}
+			if allowed {
+				res.Actions = append(res.Actions, actionToProto(&actions[i]))
+			}
+		}
+		if len(actions) < limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+func actionToProto(action *models.HostAction) *pb.ActionResult {
+	res := &pb.ActionResult{
+		Id:          action.ID.String(),
+		Hostname:    action.Hostname,
+		Action:      action.Action,
+		Pid:         action.PID,
+		ProcessName: action.ProcessName,
+		RequestedBy: action.RequestedBy,
+		Comment:     action.Comment,
+		Status:      _actionStatusToProto[action.Status],
+		Message:     action.Message,
+		Pids:        action.PIDs,
+		RequestedAt: timestamppb.New(action.RequestedAt),
+		Unit:        action.Unit,
+	}
+	if action.CompletedAt != nil {
+		res.CompletedAt = timestamppb.New(*action.CompletedAt)
+	}
+	return res
+}
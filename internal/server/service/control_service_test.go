@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	pb "github.com/theotruvelot/g0s/pkg/proto/control"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAgent is the control stream of an agent answering the commands with answer
+type fakeAgent struct {
+	commands chan *pb.Command
+	messages chan *pb.AgentMessage
+	done     chan error
+}
+
+func connectFakeAgent(t *testing.T, s *ControlService, ctx context.Context, hostname string, answer func(*pb.Command) *pb.CommandResult) *fakeAgent {
+	t.Helper()
+	agent := &fakeAgent{
+		commands: make(chan *pb.Command, 1),
+		messages: make(chan *pb.AgentMessage, 1),
+		done:     make(chan error, 1),
+	}
+	send := func(cmd *pb.Command) error {
+		if answer != nil {
+			agent.messages <- &pb.AgentMessage{Message: &pb.AgentMessage_Result{Result: answer(cmd)}}
+		}
+		agent.commands <- cmd
+		return nil
+	}
+	recv := func() (*pb.AgentMessage, error) {
+		select {
+		case msg := <-agent.messages:
+			return msg, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	go func() { agent.done <- s.AgentControl(ctx, hostname, send, recv) }()
+
+	require.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.agents[hostname] != nil
+	}, time.Second, 10*time.Millisecond)
+	return agent
+}
+
+func signalCommand(id string, name string) *pb.Command {
+	return &pb.Command{Id: id, Action: &pb.Command_SignalProcess{SignalProcess: &pb.SignalProcessCommand{
+		Name: name, Signal: pb.Signal_SIGNAL_HUP,
+	}}}
+}
+
+func TestControlService_Dispatch(t *testing.T) {
+	s := NewControlService(nil, nil)
+	defer s.Shutdown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	agent := connectFakeAgent(t, s, ctx, "web-1", func(cmd *pb.Command) *pb.CommandResult {
+		return &pb.CommandResult{Id: cmd.Id, Status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED, Pids: []int32{42}}
+	})
+
+	result, err := s.dispatch(ctx, "web-1", signalCommand("cmd-1", "nginx"))
+	require.NoError(t, err)
+	assert.Equal(t, "cmd-1", result.Id)
+	assert.Equal(t, []int32{42}, result.Pids)
+	assert.Equal(t, "nginx", (<-agent.commands).GetSignalProcess().GetName())
+
+	_, err = s.dispatch(ctx, "web-2", signalCommand("cmd-2", "nginx"))
+	assert.ErrorIs(t, err, ErrAgentNotConnected)
+
+	cancel()
+	assert.NoError(t, <-agent.done)
+	_, err = s.dispatch(context.Background(), "web-1", signalCommand("cmd-3", "nginx"))
+	assert.ErrorIs(t, err, ErrAgentNotConnected, "the agent left")
+}
+
+func TestControlService_DispatchWithoutAnswer(t *testing.T) {
+	s := NewControlService(nil, nil)
+	defer s.Shutdown()
+	s.timeout = 50 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	connectFakeAgent(t, s, ctx, "web-1", nil)
+	_, err := s.dispatch(ctx, "web-1", signalCommand("cmd-1", "nginx"))
+	assert.ErrorIs(t, err, ErrAgentTimeout)
+}
+
+func TestControlService_NewStreamReplacesPrevious(t *testing.T) {
+	s := NewControlService(nil, nil)
+	defer s.Shutdown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := connectFakeAgent(t, s, ctx, "web-1", nil)
+	s.lock.Lock()
+	previous := s.agents["web-1"]
+	s.lock.Unlock()
+
+	connectFakeAgent(t, s, ctx, "web-1", nil)
+	err := <-first.done
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	s.lock.Lock()
+	assert.NotSame(t, previous, s.agents["web-1"], "the new stream is kept when the previous one ends")
+	s.lock.Unlock()
+}
+
+func TestControlService_SignalProcessValidation(t *testing.T) {
+	s := NewControlService(nil, nil)
+	defer s.Shutdown()
+	ctx := auth.NewAccessContext(context.Background(), &auth.Access{
+		Username: "bob", Role: auth.RoleOperator, HostPatterns: []string{"web-*"},
+	})
+
+	tests := []struct {
+		name string
+		req  *pb.SignalProcessRequest
+		code codes.Code
+	}{
+		{
+			name: "no signal",
+			req:  &pb.SignalProcessRequest{Hostname: "web-1", Pid: 42},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "no process",
+			req:  &pb.SignalProcessRequest{Hostname: "web-1", Signal: pb.Signal_SIGNAL_TERM},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "pid and name",
+			req:  &pb.SignalProcessRequest{Hostname: "web-1", Pid: 42, Name: "nginx", Signal: pb.Signal_SIGNAL_TERM},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "host the caller can't see",
+			req:  &pb.SignalProcessRequest{Hostname: "db-1", Pid: 42, Signal: pb.Signal_SIGNAL_KILL},
+			code: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SignalProcess(ctx, tt.req)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	_, err := s.SignalProcess(context.Background(), &pb.SignalProcessRequest{Hostname: "web-1", Pid: 42, Signal: pb.Signal_SIGNAL_TERM})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestControlService_ControlUnitValidation(t *testing.T) {
+	s := NewControlService(nil, nil)
+	defer s.Shutdown()
+	ctx := auth.NewAccessContext(context.Background(), &auth.Access{
+		Username: "bob", Role: auth.RoleOperator, HostPatterns: []string{"web-*"},
+	})
+
+	tests := []struct {
+		name string
+		req  *pb.ControlUnitRequest
+		code codes.Code
+	}{
+		{
+			name: "no operation",
+			req:  &pb.ControlUnitRequest{Hostname: "web-1", Unit: "nginx"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "no unit",
+			req:  &pb.ControlUnitRequest{Hostname: "web-1", Operation: pb.UnitOperation_UNIT_OPERATION_RESTART},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid unit",
+			req:  &pb.ControlUnitRequest{Hostname: "web-1", Unit: "nginx; reboot", Operation: pb.UnitOperation_UNIT_OPERATION_RESTART},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "host the caller can't see",
+			req:  &pb.ControlUnitRequest{Hostname: "db-1", Unit: "postgresql", Operation: pb.UnitOperation_UNIT_OPERATION_STOP},
+			code: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ControlUnit(ctx, tt.req)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	_, err := s.ControlUnit(context.Background(), &pb.ControlUnitRequest{Hostname: "web-1", Unit: "nginx", Operation: pb.UnitOperation_UNIT_OPERATION_START})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCompleteAction(t *testing.T) {
+	now := time.Now()
+
+	action := &models.HostAction{Status: models.HostActionPending}
+	completeAction(action, &pb.CommandResult{
+		Status: pb.ActionStatus_ACTION_STATUS_REFUSED, Message: "nginx is not allow-listed",
+	}, nil, now)
+	assert.Equal(t, models.HostActionRefused, action.Status)
+	assert.Equal(t, "nginx is not allow-listed", action.Message)
+	assert.Equal(t, &now, action.CompletedAt)
+
+	action = &models.HostAction{Status: models.HostActionPending}
+	completeAction(action, &pb.CommandResult{Status: pb.ActionStatus_ACTION_STATUS_PENDING}, nil, now)
+	assert.Equal(t, models.HostActionFailed, action.Status, "a result is never pending")
+
+	action = &models.HostAction{Status: models.HostActionPending}
+	completeAction(action, nil, errors.New("the agent did not answer in time"), now)
+	assert.Equal(t, models.HostActionFailed, action.Status)
+	assert.Equal(t, "the agent did not answer in time", action.Message)
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_FAILED, actionToProto(action).Status)
+
+	action = &models.HostAction{Action: "restart", Unit: "nginx.service", Status: models.HostActionPending}
+	completeAction(action, &pb.CommandResult{Status: pb.ActionStatus_ACTION_STATUS_SUCCEEDED, Message: "nginx.service is active (running)"}, nil, now)
+	assert.Equal(t, "nginx.service", actionToProto(action).Unit)
+	assert.Equal(t, pb.ActionStatus_ACTION_STATUS_SUCCEEDED, actionToProto(action).Status)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+	"regexp"
+	"time"
+)
+
+const (
+	// DefaultJoinTokenTTL is the lifetime of a join token created without ttl
+	DefaultJoinTokenTTL = time.Hour
+	// MaxJoinTokenTTL keeps join tokens short-lived, they are meant for provisioning a host now
+	MaxJoinTokenTTL = 7 * 24 * time.Hour
+)
+
+// _hostnamePattern is the format of the hostnames agents enroll with
+var _hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,253}$`)
+
+var (
+	ErrInvalidJoinToken    = errors.New("invalid, expired or already used join token")
+	ErrInvalidJoinTokenTTL = errors.New("join token ttl must be positive and at most 7 days")
+	ErrInvalidHostname     = errors.New("hostname must be 1 to 253 letters, digits, dots, dashes or underscores")
+	ErrHostNotFound        = errors.New("host not found")
+	ErrHostStateChanged    = errors.New("host state changed concurrently, try again")
+)
+
+// EnrollmentService exchanges the single-use join tokens created by admins for per-host
+// agent credentials, and checks these credentials on every agent call
+type EnrollmentService struct {
+	joinTokenRepo *database.JoinTokenRepository
+	hostRepo      *database.HostRepository
+	jwtService    *auth.JWTService
+}
+
+func NewEnrollmentService(joinTokenRepo *database.JoinTokenRepository, hostRepo *database.HostRepository, jwtService *auth.JWTService) *EnrollmentService {
+	return &EnrollmentService{
+		joinTokenRepo: joinTokenRepo,
+		hostRepo:      hostRepo,
+		jwtService:    jwtService,
+	}
+}
+
+// CreateJoinToken creates a join token and returns it with its plaintext value, which is
+// not stored and can't be retrieved later. Without ttl the token expires after DefaultJoinTokenTTL.
+func (s *EnrollmentService) CreateJoinToken(createdBy string, ttl time.Duration) (string, *models.JoinToken, error) {
+	if ttl == 0 {
+		ttl = DefaultJoinTokenTTL
+	}
+	if ttl < 0 || ttl > MaxJoinTokenTTL {
+		return "", nil, ErrInvalidJoinTokenTTL
+	}
+
+	plaintext, hash, err := auth.GenerateJoinToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &models.JoinToken{
+		ID:        uuid.New(),
+		TokenHash: hash,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.joinTokenRepo.Create(token); err != nil {
+		logger.Error("Error storing join token", zap.Error(err))
+		return "", nil, err
+	}
+
+	logger.Info("Join token created",
+		zap.String("token_id", token.ID.String()),
+		zap.String("created_by", createdBy),
+		zap.Time("expires_at", token.ExpiresAt))
+	return plaintext, token, nil
+}
+
+// EnrollAgent consumes the join token, records the host and returns it with its credential.
+// Enrolling a known hostname again replaces its credential and lifts its revocation, the
+// join token being proof that an admin allowed it.
+func (s *EnrollmentService) EnrollAgent(joinToken, hostname string) (*models.Host, string, error) {
+	if !_hostnamePattern.MatchString(hostname) {
+		return nil, "", ErrInvalidHostname
+	}
+
+	token, err := s.joinTokenRepo.Consume(auth.HashAPIToken(joinToken))
+	if err != nil {
+		logger.Error("Error consuming join token", zap.Error(err))
+		return nil, "", err
+	}
+	if token == nil {
+		logger.Warn("Enrollment with an invalid join token", zap.String("hostname", hostname))
+		return nil, "", ErrInvalidJoinToken
+	}
+
+	host, err := s.hostRepo.GetByHostname(hostname)
+	if err != nil {
+		return nil, "", err
+	}
+	if host == nil {
+		host = &models.Host{ID: uuid.New(), Hostname: hostname}
+	}
+	credentialID := uuid.New()
+	enrolledAt := time.Now()
+	host.CredentialID = &credentialID
+	host.EnrolledAt = &enrolledAt
+	host.Revoked = false
+	host.RevokedAt = nil
+
+	if err := s.hostRepo.Save(host); err != nil {
+		logger.Error("Error saving host", zap.Error(err))
+		return nil, "", err
+	}
+	if err := s.joinTokenRepo.SetHost(token.ID, host.ID); err != nil {
+		logger.Error("Error recording enrolled host of join token", zap.Error(err))
+		return nil, "", err
+	}
+
+	credential, err := s.jwtService.GenerateAgentJWT(host.ID.String(), host.Hostname, credentialID.String())
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Info("Agent enrolled",
+		zap.String("hostname", host.Hostname),
+		zap.String("host_id", host.ID.String()),
+		zap.String("join_token_id", token.ID.String()))
+	return host, credential, nil
+}
+
+// RevokeHost revokes the credential of the host, which has to enroll again with a new join token
+func (s *EnrollmentService) RevokeHost(hostname string) (*models.Host, error) {
+	host, err := s.hostRepo.GetByHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+	if host == nil {
+		return nil, ErrHostNotFound
+	}
+
+	if _, err := s.hostRepo.Revoke(host.ID); err != nil {
+		logger.Error("Error revoking host", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Agent credential revoked", zap.String("hostname", host.Hostname))
+	return s.hostRepo.GetByID(host.ID)
+}
+
+// CheckAgent checks that the credential is the current one of a host that is not revoked.
+// It returns auth.ErrAgentRevoked otherwise.
+func (s *EnrollmentService) CheckAgent(_ context.Context, claims *auth.AgentClaims) error {
+	hostID, err := uuid.Parse(claims.HostID)
+	if err != nil {
+		return auth.ErrAgentRevoked
+	}
+
+	host, err := s.hostRepo.GetByID(hostID)
+	if err != nil {
+		return err
+	}
+	if host == nil || host.Revoked || host.CredentialID == nil || host.CredentialID.String() != claims.ID {
+		return auth.ErrAgentRevoked
+	}
+	return nil
+}
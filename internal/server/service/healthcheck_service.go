@@ -5,11 +5,18 @@ import (
 	"sync"
 	"time"
 
+	"github.com/theotruvelot/g0s/internal/server/auth"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	health "github.com/theotruvelot/g0s/pkg/proto/health"
+	"github.com/theotruvelot/g0s/pkg/version"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// _serverFeatures are the features the server supports, negotiated with every agent
+var _serverFeatures = []string{version.FeatureMetricsBatches, version.FeatureGzip}
+
 type ClientInfo struct {
 	ID          string
 	Hostname    string
@@ -18,18 +25,22 @@ type ClientInfo struct {
 }
 
 type HealthCheckService struct {
+	hostService *HostService
 	clients     map[string]ClientInfo
 	clientsLock sync.Mutex
 	ctx         context.Context
 	cancel      context.CancelFunc
 }
 
-func NewHealthCheckService() *HealthCheckService {
+// NewHealthCheckService returns the health service. Agents opening and closing a watch are
+// recorded in the inventory of hostService, if any.
+func NewHealthCheckService(hostService *HostService) *HealthCheckService {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &HealthCheckService{
-		clients: make(map[string]ClientInfo),
-		ctx:     ctx,
-		cancel:  cancel,
+		hostService: hostService,
+		clients:     make(map[string]ClientInfo),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
@@ -51,6 +62,9 @@ func (s *HealthCheckService) RegisterClient(id, hostname, ip string) {
 		ConnectedAt: time.Now(),
 	}
 	s.clientsLock.Unlock()
+	if s.hostService != nil {
+		s.hostService.RecordWatch(hostname, ip)
+	}
 	logger.Debug("Client connected",
 		zap.String("client_id", id),
 		zap.String("hostname", hostname),
@@ -59,8 +73,12 @@ func (s *HealthCheckService) RegisterClient(id, hostname, ip string) {
 
 func (s *HealthCheckService) UnregisterClient(id string) {
 	s.clientsLock.Lock()
+	client, ok := s.clients[id]
 	delete(s.clients, id)
 	s.clientsLock.Unlock()
+	if ok && s.hostService != nil {
+		s.hostService.RecordWatchEnd(client.Hostname)
+	}
 	logger.Debug("Client disconnected", zap.String("client_id", id))
 }
 
@@ -98,3 +116,51 @@ func (s *HealthCheckService) Watch(
 		return nil
 	}
 }
+
+// Hello checks that the server can work with the agent at addr, records its versions and
+// features in the inventory and answers the features both sides support
+func (s *HealthCheckService) Hello(ctx context.Context, req *health.HelloRequest, addr string) (*health.HelloResponse, error) {
+	if err := auth.VerifyAgentHostname(ctx, req.Hostname); err != nil {
+		logger.Warn("Rejecting handshake from agent with mismatching certificate",
+			zap.String("hostname", req.Hostname),
+			zap.Error(err))
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if req.ProtocolVersion < version.MinProtocol {
+		logger.Warn("Rejecting agent with an unsupported protocol version",
+			zap.String("hostname", req.Hostname),
+			zap.String("agent_version", req.AgentVersion),
+			zap.Uint32("protocol_version", req.ProtocolVersion))
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"agent %s speaks protocol version %d but server %s requires %d to %d, upgrade the agent",
+			req.AgentVersion, req.ProtocolVersion, version.Version, version.MinProtocol, version.Protocol)
+	}
+	if req.MinProtocolVersion > version.Protocol {
+		logger.Warn("Rejecting agent requiring a newer server",
+			zap.String("hostname", req.Hostname),
+			zap.String("agent_version", req.AgentVersion),
+			zap.Uint32("min_protocol_version", req.MinProtocolVersion))
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"agent %s requires protocol version %d or later but server %s speaks %d, upgrade the server",
+			req.AgentVersion, req.MinProtocolVersion, version.Version, version.Protocol)
+	}
+
+	features := version.CommonFeatures(req.Features, _serverFeatures)
+	if s.hostService != nil {
+		s.hostService.RecordHello(req, features, addr)
+	}
+	logger.Info("Agent connected",
+		zap.String("hostname", req.Hostname),
+		zap.String("agent_version", req.AgentVersion),
+		zap.Uint32("protocol_version", req.ProtocolVersion),
+		zap.Strings("features", features),
+		zap.Strings("collectors", req.Collectors))
+
+	return &health.HelloResponse{
+		ServerVersion:      version.Version,
+		ProtocolVersion:    version.Protocol,
+		MinProtocolVersion: version.MinProtocol,
+		Features:           features,
+	}, nil
+}
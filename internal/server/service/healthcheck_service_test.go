@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	health "github.com/theotruvelot/g0s/pkg/proto/health"
+	"github.com/theotruvelot/g0s/pkg/version"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthCheckService_Hello(t *testing.T) {
+	s := NewHealthCheckService(nil)
+	ctx := context.Background()
+
+	resp, err := s.Hello(ctx, &health.HelloRequest{
+		Hostname:        "web-1",
+		AgentVersion:    "v1.5.0",
+		ProtocolVersion: version.Protocol + 1,
+		Features:        []string{"future-feature", version.FeatureGzip},
+	}, "192.0.2.10:51234")
+	require.NoError(t, err)
+	assert.Equal(t, version.Protocol, resp.ProtocolVersion)
+	assert.Equal(t, []string{version.FeatureGzip}, resp.Features, "only the features both sides support")
+
+	_, err = s.Hello(ctx, &health.HelloRequest{Hostname: "web-1", AgentVersion: "v0.1.0"}, "")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "agent too old")
+	assert.ErrorContains(t, err, "upgrade the agent")
+
+	_, err = s.Hello(ctx, &health.HelloRequest{
+		Hostname:           "web-1",
+		ProtocolVersion:    version.Protocol + 2,
+		MinProtocolVersion: version.Protocol + 1,
+	}, "")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "server too old")
+	assert.ErrorContains(t, err, "upgrade the server")
+
+	agentCtx := auth.NewAgentClaimsContext(ctx, &auth.AgentClaims{Hostname: "db-1"})
+	_, err = s.Hello(agentCtx, &health.HelloRequest{Hostname: "web-1", ProtocolVersion: version.Protocol}, "")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidHostGroup = errors.New("host group name must be 1 to 64 letters, digits, dots, dashes or underscores")
+
+// HostGroupService manages the host groups users can be limited to
+type HostGroupService struct {
+	hostGroupRepo *database.HostGroupRepository
+}
+
+func NewHostGroupService(hostGroupRepo *database.HostGroupRepository) *HostGroupService {
+	return &HostGroupService{
+		hostGroupRepo: hostGroupRepo,
+	}
+}
+
+// PutHostGroup creates the group or replaces its patterns and tags
+func (s *HostGroupService) PutHostGroup(name string, hostPatterns []string, hostTags map[string]string) (*models.HostGroup, error) {
+	if !_namePattern.MatchString(name) {
+		return nil, ErrInvalidHostGroup
+	}
+	for _, pattern := range hostPatterns {
+		if err := auth.ValidateHostPattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+	if err := auth.ValidateHostTags(hostTags); err != nil {
+		return nil, err
+	}
+
+	group, err := s.hostGroupRepo.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		group = &models.HostGroup{ID: uuid.New(), Name: name}
+	}
+	group.HostPatterns = hostPatterns
+	if group.HostPatterns == nil {
+		group.HostPatterns = []string{}
+	}
+	group.HostTags = hostTags
+
+	if err := s.hostGroupRepo.Save(group); err != nil {
+		logger.Error("Error saving host group", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Host group saved", zap.String("name", name), zap.Strings("host_patterns", hostPatterns), zap.Any("host_tags", hostTags))
+	return group, nil
+}
+
+func (s *HostGroupService) ListHostGroups() ([]models.HostGroup, error) {
+	return s.hostGroupRepo.List()
+}
+
+// DeleteHostGroup removes the group. Users limited to it no longer see its hosts.
+func (s *HostGroupService) DeleteHostGroup(name string) error {
+	deleted, err := s.hostGroupRepo.Delete(name)
+	if err != nil {
+		logger.Error("Error deleting host group", zap.Error(err))
+		return err
+	}
+	if !deleted {
+		return ErrHostGroupNotFound
+	}
+
+	logger.Info("Host group deleted", zap.String("name", name))
+	return nil
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/internal/server/storage/metrics"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pbhealth "github.com/theotruvelot/g0s/pkg/proto/health"
+	pb "github.com/theotruvelot/g0s/pkg/proto/host"
+	pbmetric "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// _reportColumns are the host columns refreshed by every metrics payload
+var _reportColumns = []string{
+	"last_seen_at", "os", "platform", "platform_family", "platform_version",
+	"kernel_version", "kernel_arch", "virtualization_system", "virtualization_role",
+	"agent_version", "ip_address", "tags", "last_metrics_at", "collection_interval",
+}
+
+// _helloColumns are the host columns refreshed by the handshake of an agent
+var _helloColumns = []string{
+	"last_seen_at", "ip_address", "agent_version", "protocol_version", "agent_features", "collectors",
+}
+
+// _watchColumns are the host columns refreshed when an agent opens its health watch
+var _watchColumns = []string{"last_seen_at", "ip_address"}
+
+// HostService keeps the inventory of the hosts reporting to the server and exposes it
+// to the users, limited to the hosts they can see. It also tracks the state of every host
+// from its health watch and metrics, and publishes the state transitions.
+type HostService struct {
+	hostRepo        *database.HostRepository
+	offlineAfter    int
+	watches         map[string]int
+	watchesLock     sync.Mutex
+	subscribers     map[chan HostEvent]struct{}
+	subscribersLock sync.Mutex
+	ctx             context.Context
+	cancel          context.CancelFunc
+}
+
+// NewHostService returns the host service. Hosts are offline once they have neither a health
+// watch open nor sent metrics for offlineAfter collection intervals, DefaultOfflineAfter if zero.
+func NewHostService(hostRepo *database.HostRepository, offlineAfter int) *HostService {
+	if offlineAfter <= 0 {
+		offlineAfter = DefaultOfflineAfter
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HostService{
+		hostRepo:     hostRepo,
+		offlineAfter: offlineAfter,
+		watches:      make(map[string]int),
+		subscribers:  make(map[chan HostEvent]struct{}),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start starts evaluating the state of every host periodically
+func (s *HostService) Start() {
+	go s.monitor()
+}
+
+// Shutdown stops the state evaluation and ends the host event streams
+func (s *HostService) Shutdown() {
+	s.cancel()
+}
+
+// RecordReport refreshes the inventory of the host from its metrics. Failures are logged
+// and not returned so the inventory never blocks the ingestion.
+func (s *HostService) RecordReport(report *pbmetric.HostMetrics, addr string) {
+	if report.GetHostname() == "" {
+		return
+	}
+
+	now := time.Now()
+	host := &models.Host{
+		ID:                   uuid.New(),
+		Hostname:             report.Hostname,
+		FirstSeenAt:          &now,
+		LastSeenAt:           &now,
+		OS:                   report.Os,
+		Platform:             report.Platform,
+		PlatformFamily:       report.PlatformFamily,
+		PlatformVersion:      report.PlatformVersion,
+		KernelVersion:        report.KernelVersion,
+		KernelArch:           report.KernelArch,
+		VirtualizationSystem: report.VirtualizationSystem,
+		VirtualizationRole:   report.VirtualizationRole,
+		AgentVersion:         report.AgentVersion,
+		IPAddress:            peerIP(addr),
+		Tags:                 report.Tags,
+		LastMetricsAt:        &now,
+		CollectionInterval:   int(report.CollectionIntervalSeconds),
+	}
+	if err := s.hostRepo.Upsert(host, _reportColumns); err != nil {
+		logger.Error("Failed to record host report", zap.String("hostname", report.Hostname), zap.Error(err))
+		return
+	}
+	s.evaluateHostname(report.Hostname)
+}
+
+// RecordHello records the versions and features an agent announced in its handshake. Failures
+// are logged and not returned, as for the reports.
+func (s *HostService) RecordHello(hello *pbhealth.HelloRequest, features []string, addr string) {
+	if hello.GetHostname() == "" {
+		return
+	}
+
+	now := time.Now()
+	host := &models.Host{
+		ID:              uuid.New(),
+		Hostname:        hello.Hostname,
+		FirstSeenAt:     &now,
+		LastSeenAt:      &now,
+		IPAddress:       peerIP(addr),
+		AgentVersion:    hello.AgentVersion,
+		ProtocolVersion: int(hello.ProtocolVersion),
+		AgentFeatures:   features,
+		Collectors:      hello.Collectors,
+	}
+	if err := s.hostRepo.Upsert(host, _helloColumns); err != nil {
+		logger.Error("Failed to record host handshake", zap.String("hostname", hello.Hostname), zap.Error(err))
+	}
+}
+
+// RecordWatch records that the agent of the host opened its health watch
+func (s *HostService) RecordWatch(hostname, addr string) {
+	if hostname == "" {
+		return
+	}
+
+	now := time.Now()
+	host := &models.Host{
+		ID:          uuid.New(),
+		Hostname:    hostname,
+		FirstSeenAt: &now,
+		LastSeenAt:  &now,
+		IPAddress:   peerIP(addr),
+	}
+	s.watchesLock.Lock()
+	s.watches[hostname]++
+	s.watchesLock.Unlock()
+
+	if err := s.hostRepo.Upsert(host, _watchColumns); err != nil {
+		logger.Error("Failed to record host watch", zap.String("hostname", hostname), zap.Error(err))
+		return
+	}
+	s.evaluateHostname(hostname)
+}
+
+// RecordWatchEnd records that a health watch of the agent of the host ended
+func (s *HostService) RecordWatchEnd(hostname string) {
+	if hostname == "" {
+		return
+	}
+
+	s.watchesLock.Lock()
+	if s.watches[hostname] > 1 {
+		s.watches[hostname]--
+	} else {
+		delete(s.watches, hostname)
+	}
+	s.watchesLock.Unlock()
+
+	s.evaluateHostname(hostname)
+}
+
+// Decommission marks the host as decommissioned, so it is no longer reported offline
+func (s *HostService) Decommission(hostname, by string) error {
+	host, err := s.hostRepo.GetByHostname(hostname)
+	if err != nil {
+		return err
+	}
+	if host == nil {
+		return ErrHostNotFound
+	}
+	if host.State == models.HostStateDecommissioned {
+		return nil
+	}
+
+	changed, err := s.transition(host, models.HostStateDecommissioned, "decommissioned by "+by, time.Now())
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return ErrHostStateChanged
+	}
+	return nil
+}
+
+func (s *HostService) ListHosts(ctx context.Context, req *pb.ListHostsRequest) (*pb.ListHostsResponse, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	hosts, err := s.hostRepo.List(req.Tags)
+	if err != nil {
+		logger.Error("Failed to list hosts", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list hosts")
+	}
+
+	res := &pb.ListHostsResponse{Hosts: make([]*pb.Host, 0, len(hosts))}
+	for i := range hosts {
+		if access.AllowsHost(hosts[i].Hostname, hosts[i].Tags) {
+			res.Hosts = append(res.Hosts, hostToProto(&hosts[i]))
+		}
+	}
+	return res, nil
+}
+
+// GetHost returns the host, hosts the caller can't see are reported as not found
+func (s *HostService) GetHost(ctx context.Context, req *pb.GetHostRequest) (*pb.Host, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	host, err := s.hostRepo.GetByHostname(req.Hostname)
+	if err != nil {
+		logger.Error("Failed to get host", zap.String("hostname", req.Hostname), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get host")
+	}
+	if host == nil || !access.AllowsHost(host.Hostname, host.Tags) {
+		return nil, status.Error(codes.NotFound, ErrHostNotFound.Error())
+	}
+	return hostToProto(host), nil
+}
+
+// WatchHostEvents streams the state transitions of the hosts matching the host filter that
+// the caller can see, until the caller leaves or the server shuts down
+func (s *HostService) WatchHostEvents(ctx context.Context, req *pb.WatchHostEventsRequest, send func(*pb.HostEvent) error) error {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "access denied")
+	}
+	hostFilter, err := metrics.CompileHostFilter(req.HostFilter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	events, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.ctx.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event := <-events:
+			if !hostFilter.MatchString(event.Hostname) || !access.AllowsHost(event.Hostname, event.Tags) {
+				continue
+			}
+			if err := send(hostEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// peerIP returns the IP of a peer address, without its port
+func peerIP(addr string) string {
+	if ip, _, err := net.SplitHostPort(addr); err == nil {
+		return ip
+	}
+	return addr
+}
+
+func hostToProto(host *models.Host) *pb.Host {
+	res := &pb.Host{
+		Id:                   host.ID.String(),
+		Hostname:             host.Hostname,
+		Os:                   host.OS,
+		Platform:             host.Platform,
+		PlatformFamily:       host.PlatformFamily,
+		PlatformVersion:      host.PlatformVersion,
+		KernelVersion:        host.KernelVersion,
+		KernelArch:           host.KernelArch,
+		VirtualizationSystem: host.VirtualizationSystem,
+		VirtualizationRole:   host.VirtualizationRole,
+		AgentVersion:         host.AgentVersion,
+		IpAddress:            host.IPAddress,
+		Tags:                 host.Tags,
+		Revoked:              host.Revoked,
+		State:                _hostStateToProto[host.State],
+		InMaintenance:        host.InMaintenance,
+		ProtocolVersion:      uint32(host.ProtocolVersion),
+		AgentFeatures:        host.AgentFeatures,
+		Collectors:           host.Collectors,
+	}
+	if host.FirstSeenAt != nil {
+		res.FirstSeenAt = timestamppb.New(*host.FirstSeenAt)
+	}
+	if host.LastSeenAt != nil {
+		res.LastSeenAt = timestamppb.New(*host.LastSeenAt)
+	}
+	if host.EnrolledAt != nil {
+		res.EnrolledAt = timestamppb.New(*host.EnrolledAt)
+	}
+	if host.StateChangedAt != nil {
+		res.StateChangedAt = timestamppb.New(*host.StateChangedAt)
+	}
+	if host.LastMetricsAt != nil {
+		res.LastMetricsAt = timestamppb.New(*host.LastMetricsAt)
+	}
+	return res
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerIP(t *testing.T) {
+	assert.Equal(t, "192.0.2.10", peerIP("192.0.2.10:51234"))
+	assert.Equal(t, "2001:db8::1", peerIP("[2001:db8::1]:51234"))
+	assert.Equal(t, "192.0.2.10", peerIP("192.0.2.10"))
+	assert.Equal(t, "", peerIP(""))
+}
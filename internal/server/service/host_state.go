@@ -0,0 +1,201 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/host"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultOfflineAfter is the number of collection intervals without health watch nor
+	// metrics after which a host is offline
+	DefaultOfflineAfter = 3
+	// _defaultCollectionInterval is assumed for agents that don't report their interval
+	_defaultCollectionInterval = 180 * time.Second
+	// _hostStateCheckInterval is how often the state of every host is evaluated
+	_hostStateCheckInterval = 15 * time.Second
+	// _hostEventBuffer is the number of events buffered per subscriber before new events are
+	// dropped for that subscriber
+	_hostEventBuffer = 64
+)
+
+// HostEvent is a state transition of a host
+type HostEvent struct {
+	Hostname string
+	// Tags are the tags of the host, to check the access of the subscribers
+	Tags     map[string]string
+	Previous models.HostState
+	State    models.HostState
+	Reason   string
+	At       time.Time
+}
+
+// hostState returns the state of the host and why. Metrics are late after one and a half
+// collection interval, and the host is offline once it has neither a health watch open nor
+// sent metrics for offlineAfter intervals. A decommissioned host stays so until it sends
+// metrics again.
+func hostState(host *models.Host, watched bool, now time.Time, offlineAfter int) (models.HostState, string) {
+	if host.State == models.HostStateDecommissioned &&
+		(host.LastMetricsAt == nil || host.StateChangedAt == nil || !host.LastMetricsAt.After(*host.StateChangedAt)) {
+		return models.HostStateDecommissioned, ""
+	}
+
+	interval := time.Duration(host.CollectionInterval) * time.Second
+	if interval <= 0 {
+		interval = _defaultCollectionInterval
+	}
+
+	if host.LastMetricsAt == nil {
+		switch {
+		case watched:
+			return models.HostStateDegraded, "health watch open but no metrics received yet"
+		case host.LastSeenAt != nil:
+			return models.HostStateOffline, "no health watch and no metrics received yet"
+		default:
+			return models.HostStateUnknown, ""
+		}
+	}
+
+	since := now.Sub(*host.LastMetricsAt)
+	switch {
+	case since <= interval*3/2:
+		return models.HostStateOnline, "metrics received on time"
+	case watched || since <= interval*time.Duration(offlineAfter):
+		return models.HostStateDegraded, fmt.Sprintf("no metrics for %s", since.Round(time.Second))
+	default:
+		return models.HostStateOffline, fmt.Sprintf("no health watch nor metrics for %s", since.Round(time.Second))
+	}
+}
+
+// monitor evaluates the state of every host periodically, so hosts that went silent are
+// noticed without any event from them
+func (s *HostService) monitor() {
+	ticker := time.NewTicker(_hostStateCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			hosts, err := s.hostRepo.List(nil)
+			if err != nil {
+				logger.Error("Failed to list hosts for state evaluation", zap.Error(err))
+				continue
+			}
+			for i := range hosts {
+				s.evaluate(&hosts[i])
+			}
+		}
+	}
+}
+
+// evaluateHostname evaluates the state of the host after an event of its agent
+func (s *HostService) evaluateHostname(hostname string) {
+	host, err := s.hostRepo.GetByHostname(hostname)
+	if err != nil {
+		logger.Error("Failed to get host for state evaluation", zap.String("hostname", hostname), zap.Error(err))
+		return
+	}
+	if host != nil {
+		s.evaluate(host)
+	}
+}
+
+// evaluate records and publishes the new state of the host, if it changed
+func (s *HostService) evaluate(host *models.Host) {
+	now := time.Now()
+	state, reason := hostState(host, s.watched(host.Hostname), now, s.offlineAfter)
+	if state == host.State {
+		return
+	}
+	if _, err := s.transition(host, state, reason, now); err != nil {
+		logger.Error("Failed to record host state", zap.String("hostname", host.Hostname), zap.Error(err))
+	}
+}
+
+// transition moves the host to state. The update is conditional on the previous state so
+// concurrent evaluations publish a transition only once, it returns false for the losers.
+func (s *HostService) transition(host *models.Host, state models.HostState, reason string, now time.Time) (bool, error) {
+	changed, err := s.hostRepo.SetState(host.ID, host.State, state, now)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	event := HostEvent{
+		Hostname: host.Hostname,
+		Tags:     host.Tags,
+		Previous: host.State,
+		State:    state,
+		Reason:   reason,
+		At:       now,
+	}
+	host.State = state
+	host.StateChangedAt = &now
+
+	logger.Info("Host state changed",
+		zap.String("hostname", event.Hostname),
+		zap.String("previous_state", string(event.Previous)),
+		zap.String("state", string(event.State)),
+		zap.String("reason", event.Reason))
+	s.publish(event)
+	return true, nil
+}
+
+func (s *HostService) watched(hostname string) bool {
+	s.watchesLock.Lock()
+	defer s.watchesLock.Unlock()
+	return s.watches[hostname] > 0
+}
+
+// Subscribe returns a channel receiving every host state transition until unsubscribe is called.
+// Events are dropped for subscribers too slow to receive them.
+func (s *HostService) Subscribe() (events <-chan HostEvent, unsubscribe func()) {
+	ch := make(chan HostEvent, _hostEventBuffer)
+
+	s.subscribersLock.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subscribersLock.Unlock()
+
+	return ch, func() {
+		s.subscribersLock.Lock()
+		delete(s.subscribers, ch)
+		s.subscribersLock.Unlock()
+	}
+}
+
+func (s *HostService) publish(event HostEvent) {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logger.Warn("Host event subscriber is too slow, dropping event", zap.String("hostname", event.Hostname))
+		}
+	}
+}
+
+var _hostStateToProto = map[models.HostState]pb.HostState{
+	models.HostStateUnknown:        pb.HostState_HOST_STATE_UNKNOWN,
+	models.HostStateOnline:         pb.HostState_HOST_STATE_ONLINE,
+	models.HostStateDegraded:       pb.HostState_HOST_STATE_DEGRADED,
+	models.HostStateOffline:        pb.HostState_HOST_STATE_OFFLINE,
+	models.HostStateDecommissioned: pb.HostState_HOST_STATE_DECOMMISSIONED,
+}
+
+func hostEventToProto(event HostEvent) *pb.HostEvent {
+	return &pb.HostEvent{
+		Hostname:      event.Hostname,
+		PreviousState: _hostStateToProto[event.Previous],
+		State:         _hostStateToProto[event.State],
+		Reason:        event.Reason,
+		Timestamp:     timestamppb.New(event.At),
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	pb "github.com/theotruvelot/g0s/pkg/proto/host"
+)
+
+func TestHostState(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	tests := []struct {
+		name     string
+		host     models.Host
+		watched  bool
+		expected models.HostState
+	}{
+		{
+			name:     "never seen",
+			host:     models.Host{State: models.HostStateUnknown},
+			expected: models.HostStateUnknown,
+		},
+		{
+			name:     "watch without metrics",
+			host:     models.Host{LastSeenAt: ago(time.Second)},
+			watched:  true,
+			expected: models.HostStateDegraded,
+		},
+		{
+			name:     "seen once without metrics",
+			host:     models.Host{LastSeenAt: ago(time.Hour)},
+			expected: models.HostStateOffline,
+		},
+		{
+			name:     "metrics on time",
+			host:     models.Host{LastMetricsAt: ago(80 * time.Second), CollectionInterval: 60},
+			expected: models.HostStateOnline,
+		},
+		{
+			name:     "metrics late",
+			host:     models.Host{LastMetricsAt: ago(100 * time.Second), CollectionInterval: 60},
+			expected: models.HostStateDegraded,
+		},
+		{
+			name:     "metrics missing with watch open",
+			host:     models.Host{LastMetricsAt: ago(time.Hour), CollectionInterval: 60},
+			watched:  true,
+			expected: models.HostStateDegraded,
+		},
+		{
+			name:     "metrics missing without watch",
+			host:     models.Host{LastMetricsAt: ago(181 * time.Second), CollectionInterval: 60},
+			expected: models.HostStateOffline,
+		},
+		{
+			name:     "default collection interval",
+			host:     models.Host{LastMetricsAt: ago(200 * time.Second)},
+			expected: models.HostStateOnline,
+		},
+		{
+			name:     "decommissioned",
+			host:     models.Host{State: models.HostStateDecommissioned, StateChangedAt: ago(time.Minute), LastMetricsAt: ago(time.Hour)},
+			watched:  true,
+			expected: models.HostStateDecommissioned,
+		},
+		{
+			name:     "decommissioned host reporting again",
+			host:     models.Host{State: models.HostStateDecommissioned, StateChangedAt: ago(time.Minute), LastMetricsAt: ago(time.Second)},
+			expected: models.HostStateOnline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, _ := hostState(&tt.host, tt.watched, now, DefaultOfflineAfter)
+			assert.Equal(t, tt.expected, state)
+		})
+	}
+}
+
+func TestHostService_Subscribe(t *testing.T) {
+	s := NewHostService(nil, 0)
+	events, unsubscribe := s.Subscribe()
+
+	s.publish(HostEvent{Hostname: "web-1", Previous: models.HostStateOnline, State: models.HostStateOffline})
+	event := <-events
+	assert.Equal(t, "web-1", event.Hostname)
+	assert.Equal(t, pb.HostState_HOST_STATE_OFFLINE, hostEventToProto(event).State)
+
+	unsubscribe()
+	s.publish(HostEvent{Hostname: "web-2"})
+	assert.Empty(t, events)
+}
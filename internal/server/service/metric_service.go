@@ -2,27 +2,59 @@ package service
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"sync"
 
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
 	"github.com/theotruvelot/g0s/internal/server/storage/metrics"
+	"github.com/theotruvelot/g0s/internal/server/telemetry"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// BatchesHeader is the header the server sends when a StreamMetricsBatches stream opens
+const BatchesHeader = "g0s-metrics-batches"
+
+// _subscriberBuffer is the number of payloads buffered per GetMetricsStream subscriber
+// before new payloads are dropped for that subscriber
+const _subscriberBuffer = 64
+
+type subscriber struct {
+	hostFilter *regexp.Regexp
+	access     *auth.Access
+	metricType string
+	payloads   chan *pb.MetricsPayload
+}
+
 type MetricService struct {
-	store  *metrics.Manager
-	ctx    context.Context
-	cancel context.CancelFunc
+	store           *metrics.Manager
+	hostService     *HostService
+	alertService    *AlertService
+	subscribers     map[*subscriber]struct{}
+	subscribersLock sync.Mutex
+	ctx             context.Context
+	cancel          context.CancelFunc
 }
 
-func NewMetricService(store *metrics.Manager) *MetricService {
+// NewMetricService returns the metric service. Received payloads are recorded in the inventory
+// of hostService and evaluated by alertService, if any.
+func NewMetricService(store *metrics.Manager, hostService *HostService, alertService *AlertService) *MetricService {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &MetricService{
-		store:  store,
-		ctx:    ctx,
-		cancel: cancel,
+		store:        store,
+		hostService:  hostService,
+		alertService: alertService,
+		subscribers:  make(map[*subscriber]struct{}),
+		ctx:          ctx,
+		cancel:       cancel,
 	}
 }
 
@@ -41,6 +73,11 @@ func (s *MetricService) SendStreamMetrics(stream pb.MetricService_StreamMetricsS
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 
+	addr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
 	go func() {
 		select {
 		case <-ctx.Done():
@@ -69,23 +106,14 @@ func (s *MetricService) SendStreamMetrics(stream pb.MetricService_StreamMetricsS
 				return status.Error(codes.Internal, "failed to receive metrics")
 			}
 
-			logger.Debug("Received metrics",
-				zap.String("hostname", metrics.Host.Hostname),
-				zap.Time("timestamp", metrics.Timestamp.AsTime()),
-				zap.Int("cpu_count", len(metrics.Cpu)),
-				zap.Int("disk_count", len(metrics.Disk)),
-				zap.Int("network_count", len(metrics.Network)),
-				zap.Int("docker_count", len(metrics.Docker)))
-
-			// Store metrics in VictoriaMetrics
-			if err := s.store.StoreAllMetrics(metrics); err != nil {
-				logger.Error("Failed to store metrics", zap.Error(err))
-				return status.Error(codes.Internal, "failed to store metrics")
+			if err := s.ingest(ctx, metrics, addr); err != nil {
+				return err
 			}
 
 			if err := stream.Send(&pb.MetricsResponse{
-				Status:  "ok",
-				Message: "Metrics received and stored successfully",
+				Status:        "ok",
+				Message:       "Metrics received and stored successfully",
+				AckedSequence: metrics.Sequence,
 			}); err != nil {
 				logger.Error("Error sending response", zap.Error(err))
 				return status.Error(codes.Internal, "failed to send response")
@@ -94,10 +122,279 @@ func (s *MetricService) SendStreamMetrics(stream pb.MetricService_StreamMetricsS
 	}
 }
 
-func (s *MetricService) GetMetrics(ctx context.Context, req *pb.MetricsRequest) (*pb.MetricsPayload, error) {
+// SendMetricsBatches stores the batches of payloads of an agent, acknowledging every batch once
+// stored while the agent keeps sending the next ones
+func (s *MetricService) SendMetricsBatches(stream pb.MetricService_StreamMetricsBatchesServer) error {
+	logger.Info("New metrics batches stream started")
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	addr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	// Tell the agent batches are supported before it sends any
+	if err := stream.SendHeader(metadata.Pairs(BatchesHeader, "1")); err != nil {
+		return status.Error(codes.Internal, "failed to send header")
+	}
+
+	var sendLock sync.Mutex
+	send := func(ack *pb.MetricsAck) error {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		return stream.Send(ack)
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ctx.Done():
+			if err := send(&pb.MetricsAck{Status: "shutdown", Message: "Server is shutting down"}); err != nil {
+				logger.Error("Failed to send shutdown notification", zap.Error(err))
+			}
+			cancel()
+		}
+	}()
+
+	for {
+		batch, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("Stream terminated", zap.Error(ctx.Err()))
+				return status.Error(codes.Canceled, "stream terminated")
+			}
+			logger.Error("Error receiving metrics", zap.Error(err))
+			return status.Error(codes.Internal, "failed to receive metrics")
+		}
+		if len(batch.Payloads) == 0 {
+			continue
+		}
+
+		for _, metrics := range batch.Payloads {
+			if err := s.ingest(ctx, metrics, addr); err != nil {
+				return err
+			}
+		}
+
+		if err := send(&pb.MetricsAck{
+			Status:        "ok",
+			FirstSequence: batch.Payloads[0].Sequence,
+			LastSequence:  batch.Payloads[len(batch.Payloads)-1].Sequence,
+		}); err != nil {
+			logger.Error("Error sending acknowledgment", zap.Error(err))
+			return status.Error(codes.Internal, "failed to send acknowledgment")
+		}
+	}
+}
+
+// ingest stores a payload received from the agent at addr. Unless it is replayed from the spool
+// of the agent, it is also recorded in the inventory, evaluated and forwarded to the subscribers.
+func (s *MetricService) ingest(ctx context.Context, metrics *pb.MetricsPayload, addr string) error {
+	if err := auth.VerifyAgentHostname(ctx, metrics.Host.GetHostname()); err != nil {
+		logger.Warn("Rejecting metrics from agent with mismatching certificate",
+			zap.String("hostname", metrics.Host.GetHostname()),
+			zap.Error(err))
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	hostname := metrics.Host.GetHostname()
+	telemetry.IngestedPayloads.With(hostname).Inc()
+	telemetry.IngestedBytes.With(hostname).Add(float64(proto.Size(metrics)))
+
+	logger.Debug("Received metrics",
+		zap.String("hostname", metrics.Host.Hostname),
+		zap.Time("timestamp", metrics.Timestamp.AsTime()),
+		zap.Uint64("sequence", metrics.Sequence),
+		zap.Bool("replayed", metrics.Replayed),
+		zap.Int("cpu_count", len(metrics.Cpu)),
+		zap.Int("disk_count", len(metrics.Disk)),
+		zap.Int("network_count", len(metrics.Network)),
+		zap.Int("docker_count", len(metrics.Docker)),
+		zap.Int("process_count", len(metrics.Processes)))
+
+	// Store metrics in VictoriaMetrics
+	if err := s.store.StoreAllMetrics(metrics); err != nil {
+		logger.Error("Failed to store metrics", zap.Error(err))
+		return status.Error(codes.Internal, "failed to store metrics")
+	}
+
+	// Replayed payloads describe the past, only the latest one refreshes the inventory, so a
+	// host replaying its spool isn't seen as reporting on time, and is evaluated and forwarded
+	if metrics.Replayed {
+		return nil
+	}
+	if s.hostService != nil {
+		s.hostService.RecordReport(metrics.Host, addr)
+	}
+	if s.alertService != nil {
+		s.alertService.Evaluate(metrics)
+	}
+	s.publish(metrics)
+	return nil
+}
+
+func (s *MetricService) GetMetrics(ctx context.Context, req *pb.MetricsRequest) (*pb.MetricsList, error) {
 	logger.Info("GetMetrics called",
+		zap.String("username", callerName(ctx)),
+		zap.String("host_filter", req.HostFilter),
+		zap.String("metric_type", req.MetricType))
+
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	payloads, err := s.store.QueryMetrics(ctx, req.HostFilter, req.MetricType)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	visible, err := s.visiblePayloads(access, payloads)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MetricsList{Payloads: visible}, nil
+}
+
+// GetMetricsStream sends the latest stored payload of every matching host, then forwards
+// every new payload received from the agents until the client goes away
+func (s *MetricService) GetMetricsStream(req *pb.MetricsRequest, stream pb.MetricService_GetMetricsStreamServer) error {
+	logger.Info("GetMetricsStream called",
+		zap.String("username", callerName(stream.Context())),
 		zap.String("host_filter", req.HostFilter),
 		zap.String("metric_type", req.MetricType))
 
-	return nil, status.Error(codes.Unimplemented, "method GetMetrics not implemented")
+	access, ok := auth.AccessFromContext(stream.Context())
+	if !ok {
+		return status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	hostFilter, err := metrics.CompileHostFilter(req.HostFilter)
+	if err != nil {
+		return queryError(err)
+	}
+	if err := s.store.ValidateMetricType(req.MetricType); err != nil {
+		return queryError(err)
+	}
+
+	// Subscribe before reading the snapshot so no payload is lost in between
+	sub := s.subscribe(hostFilter, access, req.MetricType)
+	defer s.unsubscribe(sub)
+
+	ctx := stream.Context()
+	snapshot, err := s.store.QueryMetrics(ctx, req.HostFilter, req.MetricType)
+	if err != nil {
+		return queryError(err)
+	}
+	visible, err := s.visiblePayloads(access, snapshot)
+	if err != nil {
+		return err
+	}
+	for _, payload := range visible {
+		if err := stream.Send(payload); err != nil {
+			logger.Error("Error sending metrics", zap.Error(err))
+			return status.Error(codes.Internal, "failed to send metrics")
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.ctx.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case payload := <-sub.payloads:
+			if err := stream.Send(payload); err != nil {
+				logger.Error("Error sending metrics", zap.Error(err))
+				return status.Error(codes.Internal, "failed to send metrics")
+			}
+		}
+	}
+}
+
+func (s *MetricService) subscribe(hostFilter *regexp.Regexp, access *auth.Access, metricType string) *subscriber {
+	sub := &subscriber{
+		hostFilter: hostFilter,
+		access:     access,
+		metricType: metricType,
+		payloads:   make(chan *pb.MetricsPayload, _subscriberBuffer),
+	}
+
+	s.subscribersLock.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.subscribersLock.Unlock()
+
+	return sub
+}
+
+func (s *MetricService) unsubscribe(sub *subscriber) {
+	s.subscribersLock.Lock()
+	delete(s.subscribers, sub)
+	s.subscribersLock.Unlock()
+}
+
+// publish forwards a freshly received payload to every matching subscriber without blocking the ingestion
+func (s *MetricService) publish(payload *pb.MetricsPayload) {
+	s.subscribersLock.Lock()
+	defer s.subscribersLock.Unlock()
+
+	for sub := range s.subscribers {
+		hostname := payload.Host.GetHostname()
+		if !sub.hostFilter.MatchString(hostname) || !sub.access.AllowsHost(hostname, payload.Host.GetTags()) {
+			continue
+		}
+		select {
+		case sub.payloads <- metrics.FilterPayload(payload, sub.metricType):
+		default:
+			logger.Warn("Metrics subscriber is too slow, dropping payload",
+				zap.String("hostname", payload.Host.GetHostname()))
+		}
+	}
+}
+
+// visiblePayloads returns the payloads of the hosts the caller may see. The stored payloads
+// have no tags, those of the hosts are read from the inventory.
+func (s *MetricService) visiblePayloads(access *auth.Access, payloads []*pb.MetricsPayload) ([]*pb.MetricsPayload, error) {
+	var hostRepo *database.HostRepository
+	if s.hostService != nil {
+		hostRepo = s.hostService.hostRepo
+	}
+	hosts := newHostChecker(access, hostRepo)
+
+	visible := make([]*pb.MetricsPayload, 0, len(payloads))
+	for _, payload := range payloads {
+		allowed, err := hosts.allows(payload.Host.GetHostname())
+		if err != nil {
+			logger.Error("Failed to check access to host", zap.String("hostname", payload.Host.GetHostname()), zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to get metrics")
+		}
+		if allowed {
+			visible = append(visible, payload)
+		}
+	}
+	return visible, nil
+}
+
+// callerName returns the username of the authenticated caller, or an empty string
+func callerName(ctx context.Context) string {
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		return claims.Username
+	}
+	return ""
+}
+
+// queryError maps storage query errors to gRPC status errors
+func queryError(err error) error {
+	switch {
+	case errors.Is(err, metrics.ErrInvalidHostFilter), errors.Is(err, metrics.ErrUnknownMetricType):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		logger.Error("Failed to query metrics", zap.Error(err))
+		return status.Error(codes.Internal, "failed to query metrics")
+	}
 }
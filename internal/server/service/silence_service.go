@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/alerting"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/alert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// _maintenanceCheckInterval is how often the hosts in maintenance are updated, and the
+// silences and windows reloaded so the ones changed by another server are picked up
+const _maintenanceCheckInterval = 30 * time.Second
+
+var (
+	ErrSilenceNotFound           = errors.New("silence not found")
+	ErrSilenceNotOwned           = errors.New("silence was created by another user")
+	ErrInvalidSilenceTime        = errors.New("silence must end in the future, after it starts")
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+)
+
+// silence is a silence with its compiled selector and the access of its creator
+type silence struct {
+	model    *models.Silence
+	selector *alerting.Selector
+	access   *auth.Access
+}
+
+func (s *silence) active(now time.Time) bool {
+	return !now.Before(s.model.StartsAt) && now.Before(s.model.EndsAt)
+}
+
+// maintenanceWindow is a maintenance window with its parsed schedule
+type maintenanceWindow struct {
+	model  *models.MaintenanceWindow
+	window *alerting.Window
+}
+
+// SilenceService manages the silences and maintenance windows, which suppress the notifications
+// of the alerts they match. It also marks the hosts of the open maintenance windows as in
+// maintenance in the host inventory.
+type SilenceService struct {
+	silenceRepo *database.SilenceRepository
+	windowRepo  *database.MaintenanceWindowRepository
+	hostRepo    *database.HostRepository
+	silences    []*silence
+	windows     []*maintenanceWindow
+	lock        sync.RWMutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+func NewSilenceService(silenceRepo *database.SilenceRepository, windowRepo *database.MaintenanceWindowRepository, hostRepo *database.HostRepository) *SilenceService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SilenceService{
+		silenceRepo: silenceRepo,
+		windowRepo:  windowRepo,
+		hostRepo:    hostRepo,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start loads the silences and maintenance windows, then starts updating the hosts in maintenance
+func (s *SilenceService) Start() error {
+	if err := s.reload(); err != nil {
+		return err
+	}
+	s.updateMaintenance(time.Now())
+	go s.monitor()
+	return nil
+}
+
+func (s *SilenceService) Shutdown() {
+	s.cancel()
+}
+
+// reload loads the silences not ended yet and every maintenance window
+func (s *SilenceService) reload() error {
+	storedSilences, err := s.silenceRepo.List(time.Now(), false)
+	if err != nil {
+		return err
+	}
+	storedWindows, err := s.windowRepo.List()
+	if err != nil {
+		return err
+	}
+
+	silences := make([]*silence, 0, len(storedSilences))
+	for i := range storedSilences {
+		model := &storedSilences[i]
+		selector, err := alerting.NewSelector(model.HostFilter, model.Tags, model.RuleName)
+		if err != nil {
+			logger.Error("Skipping invalid silence", zap.String("silence_id", model.ID.String()), zap.Error(err))
+			continue
+		}
+		silences = append(silences, &silence{model: model, selector: selector, access: silenceAccess(model)})
+	}
+
+	windows := make([]*maintenanceWindow, 0, len(storedWindows))
+	for i := range storedWindows {
+		model := &storedWindows[i]
+		window, err := alerting.NewWindow(model.Name, model.Schedule, model.Timezone, model.Duration, model.HostFilter, model.Tags)
+		if err != nil {
+			logger.Error("Skipping invalid maintenance window", zap.String("window", model.Name), zap.Error(err))
+			continue
+		}
+		windows = append(windows, &maintenanceWindow{model: model, window: window})
+	}
+
+	s.lock.Lock()
+	s.silences = silences
+	s.windows = windows
+	s.lock.Unlock()
+	return nil
+}
+
+// silenceAccess returns the hosts the creator of the silence could see
+func silenceAccess(model *models.Silence) *auth.Access {
+	return &auth.Access{
+		Username:     model.CreatedBy,
+		AllHosts:     model.AllHosts,
+		HostPatterns: model.HostPatterns,
+		HostTags:     model.HostTags,
+	}
+}
+
+// monitor periodically reloads the silences and windows and updates the hosts in maintenance,
+// as windows open and close with time
+func (s *SilenceService) monitor() {
+	ticker := time.NewTicker(_maintenanceCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.reload(); err != nil {
+				logger.Error("Failed to reload silences and maintenance windows", zap.Error(err))
+			}
+			s.updateMaintenance(now)
+		}
+	}
+}
+
+// updateMaintenance records which hosts are in an open maintenance window
+func (s *SilenceService) updateMaintenance(now time.Time) {
+	hosts, err := s.hostRepo.List(nil)
+	if err != nil {
+		logger.Error("Failed to list hosts for maintenance", zap.Error(err))
+		return
+	}
+
+	for i := range hosts {
+		host := &hosts[i]
+		window := s.maintenanceWindow(host.Hostname, host.Tags, now)
+		if (window != "") == host.InMaintenance {
+			continue
+		}
+		if err := s.hostRepo.SetMaintenance(host.ID, window != ""); err != nil {
+			logger.Error("Failed to record host maintenance", zap.String("hostname", host.Hostname), zap.Error(err))
+			continue
+		}
+		if window != "" {
+			logger.Info("Host maintenance started", zap.String("hostname", host.Hostname), zap.String("window", window))
+		} else {
+			logger.Info("Host maintenance ended", zap.String("hostname", host.Hostname))
+		}
+	}
+}
+
+// maintenanceWindow returns the name of an open maintenance window of the host, if any
+func (s *SilenceService) maintenanceWindow(hostname string, tags map[string]string, now time.Time) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, w := range s.windows {
+		if w.window.Active(now) && w.window.Selector.MatchesHost(hostname, tags) {
+			return w.model.Name
+		}
+	}
+	return ""
+}
+
+// muted returns why the alert of the rule on the host is muted: the ID of a matching silence or
+// the name of an open maintenance window. It returns an empty string if the alert is not muted.
+func (s *SilenceService) muted(rule, hostname string, tags map[string]string, now time.Time) string {
+	s.lock.RLock()
+	for _, silence := range s.silences {
+		if silence.active(now) && silence.selector.Matches(rule, hostname, tags) && silence.access.AllowsHost(hostname, tags) {
+			s.lock.RUnlock()
+			return "silence " + silence.model.ID.String()
+		}
+	}
+	s.lock.RUnlock()
+
+	if window := s.maintenanceWindow(hostname, tags, now); window != "" {
+		return "maintenance window " + window
+	}
+	return ""
+}
+
+// Muted reports whether the notifications of the alert are suppressed by a silence or an open
+// maintenance window. The tags of the host are read from the inventory.
+func (s *SilenceService) Muted(alert *models.Alert) bool {
+	var tags map[string]string
+	host, err := s.hostRepo.GetByHostname(alert.Hostname)
+	if err != nil {
+		logger.Error("Failed to get host of alert", zap.String("hostname", alert.Hostname), zap.Error(err))
+	} else if host != nil {
+		tags = host.Tags
+	}
+
+	reason := s.muted(alert.RuleName, alert.Hostname, tags, time.Now())
+	if reason == "" {
+		return false
+	}
+	logger.Info("Alert notification muted",
+		zap.String("rule", alert.RuleName),
+		zap.String("hostname", alert.Hostname),
+		zap.String("state", string(alert.State)),
+		zap.String("by", reason))
+	return true
+}
+
+// CreateSilence creates a silence starting now unless a start is given. It only applies to the
+// hosts the caller can see.
+func (s *SilenceService) CreateSilence(ctx context.Context, req *pb.Silence) (*pb.Silence, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	selector, err := alerting.NewSelector(req.HostFilter, req.Tags, req.Rule)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt.AsTime()
+	}
+	if req.EndsAt == nil || !req.EndsAt.AsTime().After(startsAt) || !req.EndsAt.AsTime().After(now) {
+		return nil, silenceError(ErrInvalidSilenceTime)
+	}
+
+	model := &models.Silence{
+		ID:           uuid.New(),
+		HostFilter:   req.HostFilter,
+		Tags:         req.Tags,
+		RuleName:     req.Rule,
+		StartsAt:     startsAt,
+		EndsAt:       req.EndsAt.AsTime(),
+		CreatedBy:    access.Username,
+		Comment:      req.Comment,
+		AllHosts:     access.AllHosts,
+		HostPatterns: access.HostPatterns,
+		HostTags:     access.HostTags,
+	}
+	if err := s.silenceRepo.Create(model); err != nil {
+		return nil, silenceError(err)
+	}
+
+	s.lock.Lock()
+	s.silences = append(s.silences, &silence{model: model, selector: selector, access: silenceAccess(model)})
+	s.lock.Unlock()
+
+	logger.Info("Silence created",
+		zap.String("silence_id", model.ID.String()),
+		zap.String("created_by", model.CreatedBy),
+		zap.Time("starts_at", model.StartsAt),
+		zap.Time("ends_at", model.EndsAt))
+	return silenceToProto(model), nil
+}
+
+// ListSilences returns the silences not ended yet, or every silence if requested, the latest ending first
+func (s *SilenceService) ListSilences(_ context.Context, req *pb.ListSilencesRequest) (*pb.ListSilencesResponse, error) {
+	silences, err := s.silenceRepo.List(time.Now(), req.IncludeExpired)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+
+	res := &pb.ListSilencesResponse{Silences: make([]*pb.Silence, 0, len(silences))}
+	for i := range silences {
+		res.Silences = append(res.Silences, silenceToProto(&silences[i]))
+	}
+	return res, nil
+}
+
+// ExpireSilence ends a silence now. Users limited to host groups can only expire their own silences.
+func (s *SilenceService) ExpireSilence(ctx context.Context, req *pb.ExpireSilenceRequest) (*pb.Silence, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, silenceError(ErrSilenceNotFound)
+	}
+
+	model, err := s.silenceRepo.GetByID(id)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+	if model == nil {
+		return nil, silenceError(ErrSilenceNotFound)
+	}
+	if !access.AllHosts && model.CreatedBy != access.Username {
+		return nil, silenceError(ErrSilenceNotOwned)
+	}
+
+	now := time.Now()
+	expired, err := s.silenceRepo.Expire(id, now)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+	if !expired {
+		return silenceToProto(model), nil
+	}
+	model.EndsAt = now
+
+	s.lock.Lock()
+	for _, silence := range s.silences {
+		if silence.model.ID == id {
+			silence.model.EndsAt = now
+		}
+	}
+	s.lock.Unlock()
+
+	logger.Info("Silence expired", zap.String("silence_id", model.ID.String()), zap.String("by", access.Username))
+	return silenceToProto(model), nil
+}
+
+// PutMaintenanceWindow creates or replaces a maintenance window and updates the hosts in maintenance
+func (s *SilenceService) PutMaintenanceWindow(ctx context.Context, req *pb.MaintenanceWindow) (*pb.MaintenanceWindow, error) {
+	access, ok := auth.AccessFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+	window, err := alerting.NewWindow(req.Name, req.Schedule, req.Timezone, req.Duration.AsDuration(), req.HostFilter, req.Tags)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+
+	stored, err := s.windowRepo.GetByName(window.Name)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+	if stored == nil {
+		stored = &models.MaintenanceWindow{ID: uuid.New(), Name: window.Name, CreatedBy: access.Username}
+	}
+	stored.Schedule = window.Schedule.Spec
+	stored.Timezone = req.Timezone
+	stored.Duration = window.Duration
+	stored.HostFilter = req.HostFilter
+	stored.Tags = req.Tags
+	stored.Comment = req.Comment
+	if err := s.windowRepo.Save(stored); err != nil {
+		return nil, silenceError(err)
+	}
+
+	if err := s.reload(); err != nil {
+		return nil, silenceError(err)
+	}
+	now := time.Now()
+	s.updateMaintenance(now)
+	logger.Info("Maintenance window saved",
+		zap.String("window", stored.Name),
+		zap.String("schedule", stored.Schedule),
+		zap.Duration("duration", stored.Duration))
+	return maintenanceWindowToProto(&maintenanceWindow{model: stored, window: window}, now), nil
+}
+
+func (s *SilenceService) ListMaintenanceWindows(_ context.Context, _ *pb.ListMaintenanceWindowsRequest) (*pb.ListMaintenanceWindowsResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	now := time.Now()
+	res := &pb.ListMaintenanceWindowsResponse{Windows: make([]*pb.MaintenanceWindow, 0, len(s.windows))}
+	for _, w := range s.windows {
+		res.Windows = append(res.Windows, maintenanceWindowToProto(w, now))
+	}
+	return res, nil
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window, ending the maintenance of its hosts
+func (s *SilenceService) DeleteMaintenanceWindow(_ context.Context, req *pb.DeleteMaintenanceWindowRequest) (*pb.DeleteMaintenanceWindowResponse, error) {
+	deleted, err := s.windowRepo.Delete(req.Name)
+	if err != nil {
+		return nil, silenceError(err)
+	}
+	if !deleted {
+		return nil, silenceError(ErrMaintenanceWindowNotFound)
+	}
+
+	if err := s.reload(); err != nil {
+		return nil, silenceError(err)
+	}
+	s.updateMaintenance(time.Now())
+	logger.Info("Maintenance window deleted", zap.String("window", req.Name))
+	return &pb.DeleteMaintenanceWindowResponse{}, nil
+}
+
+// silenceError maps silence and maintenance window errors to gRPC status errors
+func silenceError(err error) error {
+	switch {
+	case errors.Is(err, ErrSilenceNotFound), errors.Is(err, ErrMaintenanceWindowNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrSilenceNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrInvalidSilenceTime), errors.Is(err, alerting.ErrEmptySelector),
+		errors.Is(err, alerting.ErrInvalidHostFilter), errors.Is(err, alerting.ErrInvalidRuleName),
+		errors.Is(err, alerting.ErrInvalidSchedule), errors.Is(err, alerting.ErrInvalidWindowName),
+		errors.Is(err, alerting.ErrInvalidWindowDuration), errors.Is(err, alerting.ErrInvalidTimezone):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		logger.Error("Silence operation failed", zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func silenceToProto(model *models.Silence) *pb.Silence {
+	return &pb.Silence{
+		Id:         model.ID.String(),
+		HostFilter: model.HostFilter,
+		Tags:       model.Tags,
+		Rule:       model.RuleName,
+		StartsAt:   timestamppb.New(model.StartsAt),
+		EndsAt:     timestamppb.New(model.EndsAt),
+		CreatedBy:  model.CreatedBy,
+		Comment:    model.Comment,
+	}
+}
+
+func maintenanceWindowToProto(w *maintenanceWindow, now time.Time) *pb.MaintenanceWindow {
+	return &pb.MaintenanceWindow{
+		Name:       w.model.Name,
+		Schedule:   w.model.Schedule,
+		Timezone:   w.model.Timezone,
+		Duration:   durationpb.New(w.model.Duration),
+		HostFilter: w.model.HostFilter,
+		Tags:       w.model.Tags,
+		CreatedBy:  w.model.CreatedBy,
+		Comment:    w.model.Comment,
+		Active:     w.window.Active(now),
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theotruvelot/g0s/internal/server/alerting"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	pb "github.com/theotruvelot/g0s/pkg/proto/alert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestSilence(t *testing.T, model *models.Silence) *silence {
+	selector, err := alerting.NewSelector(model.HostFilter, model.Tags, model.RuleName)
+	require.NoError(t, err)
+	return &silence{model: model, selector: selector, access: silenceAccess(model)}
+}
+
+func TestSilenceService_Muted(t *testing.T) {
+	now := time.Date(2024, time.June, 2, 22, 30, 0, 0, time.UTC)
+	window, err := alerting.NewWindow("sunday-patching", "0 22 * * 0", "", 4*time.Hour, "", map[string]string{"env": "staging"})
+	require.NoError(t, err)
+
+	s := NewSilenceService(nil, nil, nil)
+	s.silences = []*silence{
+		newTestSilence(t, &models.Silence{
+			ID: uuid.New(), HostFilter: "web-.*", RuleName: "high-cpu", AllHosts: true,
+			StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour),
+		}),
+		newTestSilence(t, &models.Silence{
+			ID: uuid.New(), Tags: map[string]string{"env": "prod"},
+			StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour), AllHosts: true,
+		}),
+		newTestSilence(t, &models.Silence{
+			ID: uuid.New(), HostFilter: ".*", RuleName: "low-disk", HostPatterns: []string{"db-*"},
+			StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour),
+		}),
+		newTestSilence(t, &models.Silence{
+			ID: uuid.New(), HostFilter: ".*", RuleName: "high-memory", HostTags: []map[string]string{{"team": "api"}},
+			StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour),
+		}),
+	}
+	s.windows = []*maintenanceWindow{{model: &models.MaintenanceWindow{Name: "sunday-patching"}, window: window}}
+
+	prod := map[string]string{"env": "prod"}
+	assert.NotEmpty(t, s.muted("high-cpu", "web-1", prod, now))
+	assert.Empty(t, s.muted("high-cpu", "web-1", prod, now.Add(2*time.Hour)), "the silence ended")
+	assert.Empty(t, s.muted("low-disk", "web-1", prod, now), "the silence only applies to the hosts its creator sees")
+	assert.NotEmpty(t, s.muted("low-disk", "db-1", prod, now))
+	assert.Empty(t, s.muted("high-cpu", "api-1", prod, now), "expired silences don't apply")
+	assert.NotEmpty(t, s.muted("high-memory", "api-1", map[string]string{"team": "api"}, now))
+	assert.Empty(t, s.muted("high-memory", "api-1", prod, now), "the creator only sees the hosts having the tags of its group")
+
+	assert.Equal(t, "maintenance window sunday-patching", s.muted("high-cpu", "api-1", map[string]string{"env": "staging"}, now))
+	assert.Empty(t, s.muted("high-cpu", "api-1", map[string]string{"env": "staging"}, now.Add(4*time.Hour)))
+	assert.Equal(t, "sunday-patching", s.maintenanceWindow("api-1", map[string]string{"env": "staging"}, now))
+	assert.Empty(t, s.maintenanceWindow("api-1", prod, now))
+}
+
+func TestSilenceService_CreateSilence_Invalid(t *testing.T) {
+	s := NewSilenceService(nil, nil, nil)
+	ctx := auth.NewAccessContext(context.Background(), &auth.Access{Username: "bob", Role: auth.RoleOperator, AllHosts: true})
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		req  *pb.Silence
+	}{
+		{name: "empty selector", req: &pb.Silence{EndsAt: timestamppb.New(now.Add(time.Hour))}},
+		{name: "invalid host filter", req: &pb.Silence{HostFilter: "web-(", EndsAt: timestamppb.New(now.Add(time.Hour))}},
+		{name: "no end", req: &pb.Silence{HostFilter: "web-.*"}},
+		{name: "ended", req: &pb.Silence{HostFilter: "web-.*", EndsAt: timestamppb.New(now.Add(-time.Minute))}},
+		{name: "ends before it starts", req: &pb.Silence{
+			HostFilter: "web-.*",
+			StartsAt:   timestamppb.New(now.Add(2 * time.Hour)),
+			EndsAt:     timestamppb.New(now.Add(time.Hour)),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateSilence(ctx, tt.req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	_, err := s.CreateSilence(context.Background(), &pb.Silence{HostFilter: "web-.*"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrAPITokenNotFound = errors.New("api token not found")
+	ErrInvalidTokenName = errors.New("token name is required")
+)
+
+// TokenService manages the lifecycle of the API tokens users log in with
+type TokenService struct {
+	userRepo         *database.UserRepository
+	apiTokenRepo     *database.APITokenRepository
+	refreshTokenRepo *database.RefreshTokenRepository
+}
+
+func NewTokenService(userRepo *database.UserRepository, apiTokenRepo *database.APITokenRepository, refreshTokenRepo *database.RefreshTokenRepository) *TokenService {
+	return &TokenService{
+		userRepo:         userRepo,
+		apiTokenRepo:     apiTokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// CreateToken issues a new API token for the user and returns it with its plaintext value,
+// which is not stored and can't be retrieved later. Without scopes the token gets every
+// scope, without ttl it never expires.
+func (s *TokenService) CreateToken(username, name string, scopes []string, ttl time.Duration) (string, *models.APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, ErrInvalidTokenName
+	}
+	if len(scopes) == 0 {
+		scopes = auth.Scopes
+	}
+	if err := auth.ValidateScopes(scopes); err != nil {
+		return "", nil, err
+	}
+
+	user, err := s.getUser(username)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plaintext, hash, err := auth.GenerateAPIToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &models.APIToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Name:      name,
+		TokenHash: hash,
+		Scopes:    scopes,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.apiTokenRepo.Create(token); err != nil {
+		logger.Error("Error storing API token", zap.Error(err))
+		return "", nil, err
+	}
+
+	logger.Info("API token created",
+		zap.String("username", user.Username),
+		zap.String("token_id", token.ID.String()),
+		zap.String("name", token.Name))
+	return plaintext, token, nil
+}
+
+// ListTokens returns every token of the user, revoked and expired ones included
+func (s *TokenService) ListTokens(username string) ([]models.APIToken, error) {
+	user, err := s.getUser(username)
+	if err != nil {
+		return nil, err
+	}
+	return s.apiTokenRepo.ListByUser(user.ID)
+}
+
+// RevokeToken revokes a token of the user, ends the sessions opened with it and returns it.
+// Revoking an already revoked token is not an error.
+func (s *TokenService) RevokeToken(username string, id uuid.UUID) (*models.APIToken, error) {
+	user, err := s.getUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.apiTokenRepo.GetByID(user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrAPITokenNotFound
+	}
+
+	if _, err := s.apiTokenRepo.Revoke(token.ID); err != nil {
+		logger.Error("Error revoking API token", zap.Error(err))
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.RevokeAllForAPIToken(token.ID); err != nil {
+		logger.Error("Error revoking refresh tokens", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("API token revoked",
+		zap.String("username", user.Username),
+		zap.String("token_id", token.ID.String()))
+	return s.apiTokenRepo.GetByID(user.ID, id)
+}
+
+func (s *TokenService) getUser(username string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
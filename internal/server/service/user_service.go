@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"github.com/theotruvelot/g0s/internal/server/storage/database"
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+	"regexp"
+	"slices"
+)
+
+var (
+	ErrUserExists        = errors.New("user already exists")
+	ErrInvalidUsername   = errors.New("username must be 1 to 64 letters, digits, dots, dashes or underscores")
+	ErrHostGroupNotFound = errors.New("host group not found")
+)
+
+// _namePattern is the format of usernames and host group names
+var _namePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// UserService manages the users allowed to log in
+type UserService struct {
+	userRepo         *database.UserRepository
+	hostGroupRepo    *database.HostGroupRepository
+	refreshTokenRepo *database.RefreshTokenRepository
+}
+
+func NewUserService(userRepo *database.UserRepository, hostGroupRepo *database.HostGroupRepository, refreshTokenRepo *database.RefreshTokenRepository) *UserService {
+	return &UserService{
+		userRepo:         userRepo,
+		hostGroupRepo:    hostGroupRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// CreateUser creates a user without any token, see TokenService.CreateToken to issue one.
+// Without host groups the user sees every host.
+func (s *UserService) CreateUser(username string, role auth.Role, hostGroups []string) (*models.User, error) {
+	if !_namePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	if _, err := auth.ParseRole(string(role)); err != nil {
+		return nil, err
+	}
+	if err := s.checkHostGroups(hostGroups); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrUserExists
+	}
+
+	user := &models.User{
+		ID:         uuid.New(),
+		Username:   username,
+		Role:       string(role),
+		HostGroups: hostGroups,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		logger.Error("Error creating user", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("User created",
+		zap.String("username", username),
+		zap.String("role", user.Role),
+		zap.Strings("host_groups", hostGroups))
+	return user, nil
+}
+
+func (s *UserService) ListUsers() ([]models.User, error) {
+	return s.userRepo.List()
+}
+
+// DisableUser prevents the user from logging in and ends its sessions. Its access tokens
+// are rejected from then on since the access of the caller is checked on every call.
+func (s *UserService) DisableUser(username string) (*models.User, error) {
+	user, err := s.getUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Disabled = true
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Error disabling user", zap.Error(err))
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.RevokeAllForUser(user.Username); err != nil {
+		logger.Error("Error revoking refresh tokens", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("User disabled", zap.String("username", username))
+	return user, nil
+}
+
+func (s *UserService) SetRole(username string, role auth.Role) (*models.User, error) {
+	if _, err := auth.ParseRole(string(role)); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = string(role)
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Error updating user role", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("User role changed", zap.String("username", username), zap.String("role", user.Role))
+	return user, nil
+}
+
+// SetHostGroups replaces the host groups of the user, an empty list gives access to every host
+func (s *UserService) SetHostGroups(username string, hostGroups []string) (*models.User, error) {
+	if err := s.checkHostGroups(hostGroups); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	user.HostGroups = hostGroups
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Error updating user host groups", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("User host groups changed", zap.String("username", username), zap.Strings("host_groups", hostGroups))
+	return user, nil
+}
+
+// checkHostGroups returns ErrHostGroupNotFound if one of the groups doesn't exist
+func (s *UserService) checkHostGroups(names []string) error {
+	groups, err := s.hostGroupRepo.ListByNames(names)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(groups, func(group models.HostGroup) bool { return group.Name == name }) {
+			return fmt.Errorf("%w: %q", ErrHostGroupNotFound, name)
+		}
+	}
+	return nil
+}
+
+func (s *UserService) getUser(username string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
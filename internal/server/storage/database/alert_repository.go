@@ -0,0 +1,83 @@
+package database
+
+import (
+	"errors"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+)
+
+type AlertRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRuleRepository(db *gorm.DB) *AlertRuleRepository {
+	return &AlertRuleRepository{db: db}
+}
+
+func (r *AlertRuleRepository) GetByName(name string) (*models.AlertRule, error) {
+	rule := &models.AlertRule{}
+	result := r.db.Where("name = ?", name).First(rule)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return rule, nil
+}
+
+func (r *AlertRuleRepository) List() ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	err := r.db.Order("name").Find(&rules).Error
+	return rules, err
+}
+
+func (r *AlertRuleRepository) Save(rule *models.AlertRule) error {
+	return r.db.Save(rule).Error
+}
+
+// Delete removes the rule. It returns false if there was no such rule.
+func (r *AlertRuleRepository) Delete(name string) (bool, error) {
+	result := r.db.Where("name = ?", name).Delete(&models.AlertRule{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+type AlertRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+// ListActive returns the pending and firing alerts
+func (r *AlertRepository) ListActive() ([]models.Alert, error) {
+	var alerts []models.Alert
+	err := r.db.Where("state IN ?", []models.AlertState{models.AlertStatePending, models.AlertStateFiring}).
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// List returns a page of the alerts in state, every state if empty, most recent first
+func (r *AlertRepository) List(state models.AlertState, offset, limit int) ([]models.Alert, error) {
+	var alerts []models.Alert
+	query := r.db.Order("started_at DESC").Offset(offset).Limit(limit)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+	err := query.Find(&alerts).Error
+	return alerts, err
+}
+
+func (r *AlertRepository) Save(alert *models.Alert) error {
+	return r.db.Save(alert).Error
+}
+
+func (r *AlertRepository) Delete(alert *models.Alert) error {
+	return r.db.Delete(alert).Error
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByID returns the token of the user with the given id, or nil if there is none
+func (r *APITokenRepository) GetByID(userID, id uuid.UUID) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(token)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return token, nil
+}
+
+// ListByUser returns every token of the user, revoked and expired ones included
+func (r *APITokenRepository) ListByUser(userID uuid.UUID) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&tokens).Error
+	return tokens, err
+}
+
+// ListActiveByUser returns the tokens of the user that can still be used to log in
+func (r *APITokenRepository) ListActiveByUser(userID uuid.UUID) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.
+		Where("user_id = ? AND revoked = ? AND (expires_at IS NULL OR expires_at > ?)", userID, false, time.Now()).
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke marks the token as revoked. It returns false if the token was already revoked.
+func (r *APITokenRepository) Revoke(id uuid.UUID) (bool, error) {
+	result := r.db.Model(&models.APIToken{}).
+		Where("id = ? AND revoked = ?", id, false).
+		Updates(map[string]interface{}{"revoked": true, "revoked_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TouchLastUsed records that the token was just used to log in
+func (r *APITokenRepository) TouchLastUsed(id uuid.UUID) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
+}
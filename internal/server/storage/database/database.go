@@ -1,6 +1,8 @@
 package database
 
 import (
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/auth"
 	"github.com/theotruvelot/g0s/internal/server/models"
 	"github.com/theotruvelot/g0s/pkg/logger"
 	"go.uber.org/zap"
@@ -31,16 +33,57 @@ func Init(dsn string) (*gorm.DB, error) {
 	sqlDB.SetConnMaxLifetime(3600) // 1 hour
 
 	// Perform migration with proper error handling
-	err = DB.AutoMigrate(&models.User{})
+	err = DB.AutoMigrate(&models.User{}, &models.APIToken{}, &models.RefreshToken{}, &models.HostGroup{},
+		&models.Host{}, &models.JoinToken{}, &models.AlertRule{}, &models.Alert{},
+		&models.Silence{}, &models.MaintenanceWindow{}, &models.HostAction{})
 	if err != nil {
 		logger.Error("Failed to migrate models", zap.Error(err))
 		return nil, err
 	}
 
+	if err := migrateLegacyUserTokens(DB); err != nil {
+		logger.Error("Failed to migrate legacy user tokens", zap.Error(err))
+		return nil, err
+	}
+
 	logger.Info("Database connection established and models migrated successfully")
 	return DB, nil
 }
 
+// migrateLegacyUserTokens moves the plaintext tokens of the users table to hashed API
+// tokens named "default" with every scope, then drops the column
+func migrateLegacyUserTokens(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.User{}, "token") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var legacyUsers []struct {
+			ID    uuid.UUID
+			Token string
+		}
+		if err := tx.Table("users").Select("id, token").Where("token <> ''").Scan(&legacyUsers).Error; err != nil {
+			return err
+		}
+
+		for _, user := range legacyUsers {
+			token := &models.APIToken{
+				ID:        uuid.New(),
+				UserID:    user.ID,
+				Name:      "default",
+				TokenHash: auth.HashAPIToken(user.Token),
+				Scopes:    auth.Scopes,
+			}
+			if err := tx.Create(token).Error; err != nil {
+				return err
+			}
+		}
+
+		logger.Info("Migrated legacy user tokens", zap.Int("count", len(legacyUsers)))
+		return tx.Migrator().DropColumn(&models.User{}, "token")
+	})
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+)
+
+type HostActionRepository struct {
+	db *gorm.DB
+}
+
+func NewHostActionRepository(db *gorm.DB) *HostActionRepository {
+	return &HostActionRepository{db: db}
+}
+
+// List returns a page of the actions on hostname, on every host if empty, most recent first
+func (r *HostActionRepository) List(hostname string, offset, limit int) ([]models.HostAction, error) {
+	var actions []models.HostAction
+	query := r.db.Order("requested_at DESC").Offset(offset).Limit(limit)
+	if hostname != "" {
+		query = query.Where("hostname = ?", hostname)
+	}
+	err := query.Find(&actions).Error
+	return actions, err
+}
+
+func (r *HostActionRepository) Save(action *models.HostAction) error {
+	return r.db.Save(action).Error
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"errors"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+)
+
+type HostGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewHostGroupRepository(db *gorm.DB) *HostGroupRepository {
+	return &HostGroupRepository{db: db}
+}
+
+func (r *HostGroupRepository) GetByName(name string) (*models.HostGroup, error) {
+	group := &models.HostGroup{}
+	result := r.db.Where("name = ?", name).First(group)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return group, nil
+}
+
+// ListByNames returns the groups among names that exist
+func (r *HostGroupRepository) ListByNames(names []string) ([]models.HostGroup, error) {
+	var groups []models.HostGroup
+	if len(names) == 0 {
+		return groups, nil
+	}
+	err := r.db.Where("name IN ?", names).Find(&groups).Error
+	return groups, err
+}
+
+func (r *HostGroupRepository) List() ([]models.HostGroup, error) {
+	var groups []models.HostGroup
+	err := r.db.Order("name").Find(&groups).Error
+	return groups, err
+}
+
+func (r *HostGroupRepository) Save(group *models.HostGroup) error {
+	return r.db.Save(group).Error
+}
+
+// Delete removes the group. It returns false if there was no such group.
+func (r *HostGroupRepository) Delete(name string) (bool, error) {
+	result := r.db.Where("name = ?", name).Delete(&models.HostGroup{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type HostRepository struct {
+	db *gorm.DB
+}
+
+func NewHostRepository(db *gorm.DB) *HostRepository {
+	return &HostRepository{db: db}
+}
+
+func (r *HostRepository) GetByID(id uuid.UUID) (*models.Host, error) {
+	host := &models.Host{}
+	result := r.db.Where("id = ?", id).First(host)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return host, nil
+}
+
+func (r *HostRepository) GetByHostname(hostname string) (*models.Host, error) {
+	host := &models.Host{}
+	result := r.db.Where("hostname = ?", hostname).First(host)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return host, nil
+}
+
+// List returns the hosts having all the given tags, every host if tags is empty
+func (r *HostRepository) List(tags map[string]string) ([]models.Host, error) {
+	var hosts []models.Host
+	query := r.db.Order("hostname")
+	if len(tags) > 0 {
+		filter, err := json.Marshal(tags)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("tags @> ?", string(filter))
+	}
+	err := query.Find(&hosts).Error
+	return hosts, err
+}
+
+// Upsert inserts the host, or updates the given columns of the host with the same hostname.
+// The ID and first seen time of an existing host are kept.
+func (r *HostRepository) Upsert(host *models.Host, columns []string) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hostname"}},
+		DoUpdates: clause.AssignmentColumns(append(columns, "updated_at")),
+	}).Create(host).Error
+}
+
+// SetState moves the host from the previous state to the new one. It returns false if the
+// state of the host changed in between, e.g. by another server.
+func (r *HostRepository) SetState(id uuid.UUID, previous, state models.HostState, changedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.Host{}).
+		Where("id = ? AND state = ?", id, previous).
+		Updates(map[string]interface{}{"state": state, "state_changed_at": changedAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SetMaintenance records whether a maintenance window of the host is open
+func (r *HostRepository) SetMaintenance(id uuid.UUID, inMaintenance bool) error {
+	return r.db.Model(&models.Host{}).Where("id = ?", id).Update("in_maintenance", inMaintenance).Error
+}
+
+func (r *HostRepository) Save(host *models.Host) error {
+	return r.db.Save(host).Error
+}
+
+// Revoke marks the host as revoked so its credential is refused. It returns false if the host was already revoked.
+func (r *HostRepository) Revoke(id uuid.UUID) (bool, error) {
+	result := r.db.Model(&models.Host{}).
+		Where("id = ? AND revoked = ?", id, false).
+		Updates(map[string]interface{}{"revoked": true, "revoked_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type JoinTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewJoinTokenRepository(db *gorm.DB) *JoinTokenRepository {
+	return &JoinTokenRepository{db: db}
+}
+
+func (r *JoinTokenRepository) Create(token *models.JoinToken) error {
+	return r.db.Create(token).Error
+}
+
+// Consume marks the unused, unexpired token with the given hash as used and returns it,
+// or nil if there is no such token. The update is atomic so a token enrolls a single host.
+func (r *JoinTokenRepository) Consume(tokenHash string) (*models.JoinToken, error) {
+	var consumed []models.JoinToken
+	now := time.Now()
+	result := r.db.Model(&consumed).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(consumed) != 1 {
+		return nil, nil
+	}
+	return &consumed[0], nil
+}
+
+// SetHost records the host enrolled with the token
+func (r *JoinTokenRepository) SetHost(id, hostID uuid.UUID) error {
+	result := r.db.Model(&models.JoinToken{}).Where("id = ?", id).Update("host_id", hostID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
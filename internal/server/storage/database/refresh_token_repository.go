@@ -0,0 +1,59 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// Consume marks the token as used and returns it. It returns nil if the token is unknown,
+// expired or was already used. The check and the update are a single statement so two
+// concurrent refreshes with the same token can't both succeed.
+func (r *RefreshTokenRepository) Consume(id uuid.UUID) (*models.RefreshToken, error) {
+	var consumed []models.RefreshToken
+	now := time.Now()
+	result := r.db.Model(&consumed).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(consumed) != 1 {
+		return nil, nil
+	}
+	return &consumed[0], nil
+}
+
+// RevokeAllForUser marks every unused token of the user as used
+func (r *RefreshTokenRepository) RevokeAllForUser(username string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("username = ? AND used_at IS NULL", username).
+		Update("used_at", time.Now()).Error
+}
+
+// DeleteExpired removes the tokens that can no longer be used
+func (r *RefreshTokenRepository) DeleteExpired() error {
+	return r.db.Where("expires_at <= ?", time.Now()).Delete(&models.RefreshToken{}).Error
+}
+
+// RevokeAllForAPIToken marks every unused token opened with the API token as used
+func (r *RefreshTokenRepository) RevokeAllForAPIToken(apiTokenID uuid.UUID) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("api_token_id = ? AND used_at IS NULL", apiTokenID).
+		Update("used_at", time.Now()).Error
+}
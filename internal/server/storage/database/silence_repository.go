@@ -0,0 +1,99 @@
+package database
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/theotruvelot/g0s/internal/server/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type SilenceRepository struct {
+	db *gorm.DB
+}
+
+func NewSilenceRepository(db *gorm.DB) *SilenceRepository {
+	return &SilenceRepository{db: db}
+}
+
+func (r *SilenceRepository) GetByID(id uuid.UUID) (*models.Silence, error) {
+	silence := &models.Silence{}
+	result := r.db.First(silence, "id = ?", id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return silence, nil
+}
+
+// List returns the silences not ended at now, or every silence if includeExpired is set,
+// the latest ending first
+func (r *SilenceRepository) List(now time.Time, includeExpired bool) ([]models.Silence, error) {
+	var silences []models.Silence
+	query := r.db.Order("ends_at DESC")
+	if !includeExpired {
+		query = query.Where("ends_at > ?", now)
+	}
+	err := query.Find(&silences).Error
+	return silences, err
+}
+
+func (r *SilenceRepository) Create(silence *models.Silence) error {
+	return r.db.Create(silence).Error
+}
+
+// Expire ends the silence at now. It returns false if the silence already ended.
+func (r *SilenceRepository) Expire(id uuid.UUID, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Silence{}).
+		Where("id = ? AND ends_at > ?", id, now).
+		Update("ends_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+type MaintenanceWindowRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceWindowRepository(db *gorm.DB) *MaintenanceWindowRepository {
+	return &MaintenanceWindowRepository{db: db}
+}
+
+func (r *MaintenanceWindowRepository) GetByName(name string) (*models.MaintenanceWindow, error) {
+	window := &models.MaintenanceWindow{}
+	result := r.db.Where("name = ?", name).First(window)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return window, nil
+}
+
+func (r *MaintenanceWindowRepository) List() ([]models.MaintenanceWindow, error) {
+	var windows []models.MaintenanceWindow
+	err := r.db.Order("name").Find(&windows).Error
+	return windows, err
+}
+
+func (r *MaintenanceWindowRepository) Save(window *models.MaintenanceWindow) error {
+	return r.db.Save(window).Error
+}
+
+// Delete removes the window. It returns false if there was no such window.
+func (r *MaintenanceWindowRepository) Delete(name string) (bool, error) {
+	result := r.db.Where("name = ?", name).Delete(&models.MaintenanceWindow{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -27,3 +27,17 @@ func (r *UserRepository) GetUserByUsername(username string) (*models.User, error
 
 	return user, nil
 }
+
+func (r *UserRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *UserRepository) List() ([]models.User, error) {
+	var users []models.User
+	err := r.db.Order("username").Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) Update(user *models.User) error {
+	return r.db.Save(user).Error
+}
@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/theotruvelot/g0s/pkg/logger"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"github.com/theotruvelot/g0s/pkg/wal"
+	"go.uber.org/zap"
+)
+
+const (
+	// _drainMinBackoff is the delay before writing again to VictoriaMetrics after a failure,
+	// doubled after every other failure up to _drainMaxBackoff
+	_drainMinBackoff = time.Second
+	_drainMaxBackoff = time.Minute
+)
+
+// NewBufferedMetricsManager returns a manager appending the series to the write-ahead queue
+// instead of writing them to VictoriaMetrics, Start writing them from the queue in the
+// background. Series received while VictoriaMetrics is unreachable are written once it is back.
+func NewBufferedMetricsManager(vmEndpoint string, queue *wal.Queue) *Manager {
+	m := NewMetricsManager(vmEndpoint)
+	m.queue = queue
+	return m
+}
+
+// Start writes the buffered series to VictoriaMetrics until Stop, if the manager is buffered
+func (m *Manager) Start() {
+	if m.queue == nil {
+		return
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.drained = make(chan struct{})
+	go m.drain()
+}
+
+// Stop stops writing the buffered series, waiting for the write in progress until ctx is done,
+// and closes the queue. The series left are written on the next start.
+func (m *Manager) Stop(ctx context.Context) error {
+	if m.queue == nil {
+		return nil
+	}
+	if m.cancel != nil {
+		m.cancel()
+		select {
+		case <-m.drained:
+		case <-ctx.Done():
+			logger.Warn("Write to VictoriaMetrics still in progress, it is retried on the next start")
+		}
+	}
+	return m.queue.Close()
+}
+
+// appendAll appends the series of every store to the queue, one record per store so a store
+// failing doesn't make the others write twice
+func (m *Manager) appendAll(metrics *pb.MetricsPayload, timestamp int64) error {
+	for _, store := range m.stores {
+		lines := store.Format(metrics, timestamp)
+		if len(lines) == 0 {
+			continue
+		}
+		if err := m.queue.Append(encodeRecord(store.Type(), lines)); err != nil {
+			return fmt.Errorf("failed to buffer %s metrics: %w", store.Type(), err)
+		}
+	}
+	return nil
+}
+
+// drain writes the records of the queue to VictoriaMetrics in order, retrying the oldest with
+// backoff until it is written
+func (m *Manager) drain() {
+	defer close(m.drained)
+
+	var backoff time.Duration
+	for {
+		if backoff > 0 {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+
+		data, ok, err := m.queue.Peek()
+		if errors.Is(err, wal.ErrClosed) {
+			return
+		}
+		if err != nil {
+			logger.Error("Failed to read buffered metrics", zap.Error(err))
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		if !ok {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-m.queue.Notify():
+			}
+			continue
+		}
+
+		metricType, lines := decodeRecord(data)
+		store := m.store(metricType)
+		if store == nil {
+			logger.Error("Dropping buffered metrics of unknown type", zap.String("metric_type", metricType))
+		} else if err := store.Store(lines); err != nil {
+			backoff = nextBackoff(backoff)
+			logger.Warn("Failed to write buffered metrics to VictoriaMetrics, retrying",
+				zap.String("metric_type", metricType),
+				zap.Int64("pending_bytes", m.queue.Stats().PendingBytes),
+				zap.Duration("backoff", backoff),
+				zap.Error(err))
+			continue
+		}
+
+		if backoff > 0 {
+			logger.Info("Writing buffered metrics to VictoriaMetrics again",
+				zap.Int64("pending_bytes", m.queue.Stats().PendingBytes))
+			backoff = 0
+		}
+		if err := m.queue.Ack(); err != nil && !errors.Is(err, wal.ErrClosed) {
+			logger.Error("Failed to acknowledge buffered metrics", zap.Error(err))
+		}
+	}
+}
+
+func (m *Manager) store(metricType string) MetricStore {
+	for _, store := range m.stores {
+		if store.Type() == metricType {
+			return store
+		}
+	}
+	return nil
+}
+
+func nextBackoff(backoff time.Duration) time.Duration {
+	return min(max(2*backoff, _drainMinBackoff), _drainMaxBackoff)
+}
+
+// encodeRecord encodes the series of a store as a record: its type on the first line, then
+// the series in the Prometheus text format
+func encodeRecord(metricType string, lines []string) []byte {
+	return []byte(metricType + "\n" + strings.Join(lines, ""))
+}
+
+func decodeRecord(data []byte) (string, []string) {
+	metricType, series, _ := strings.Cut(string(data), "\n")
+	return metricType, []string{series}
+}
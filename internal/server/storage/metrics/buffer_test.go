@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"github.com/theotruvelot/g0s/pkg/wal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBufferedManager_WritesOnceVictoriaMetricsIsBack(t *testing.T) {
+	var mu sync.Mutex
+	var failures int
+	var imported []string
+	vm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/import/prometheus", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		// Down for longer than the retries of a single write
+		if failures < 4 {
+			failures++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		imported = append(imported, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer vm.Close()
+
+	queue, err := wal.Open(t.TempDir(), wal.Options{})
+	require.NoError(t, err)
+	manager := NewBufferedMetricsManager(vm.URL, queue)
+
+	for _, hostname := range []string{"web-1", "web-2"} {
+		require.NoError(t, manager.StoreAllMetrics(&pb.MetricsPayload{
+			Timestamp: timestamppb.New(time.UnixMilli(1700000000000)),
+			Host:      &pb.HostMetrics{Hostname: hostname},
+			Ram:       &pb.RAMMetrics{TotalOctets: 1024},
+		}))
+	}
+	assert.Greater(t, queue.Stats().PendingBytes, int64(0), "metrics are buffered until written")
+
+	manager.Start()
+	require.Eventually(t, func() bool { return queue.Stats().PendingBytes == 0 }, 10*time.Second, 50*time.Millisecond)
+	require.NoError(t, manager.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, imported, 4, "host and RAM series of both hosts")
+	assert.True(t, strings.HasPrefix(imported[0], "# TYPE host_info gauge\nhost_info{host=\"web-1\""), imported[0])
+	assert.Contains(t, imported[1], "ram_total_octets{host=\"web-1\"} 1024 1700000000000")
+	assert.True(t, strings.HasPrefix(imported[2], "# TYPE host_info gauge\nhost_info{host=\"web-2\""), imported[2])
+	assert.Contains(t, imported[3], "ram_total_octets{host=\"web-2\"} 1024 1700000000000")
+}
+
+func TestBufferedManager_KeepsMetricsAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	queue, err := wal.Open(dir, wal.Options{})
+	require.NoError(t, err)
+	manager := NewBufferedMetricsManager("http://127.0.0.1:1", queue)
+	require.NoError(t, manager.StoreAllMetrics(&pb.MetricsPayload{
+		Timestamp: timestamppb.Now(),
+		Host:      &pb.HostMetrics{Hostname: "web-1"},
+		Ram:       &pb.RAMMetrics{},
+	}))
+	pending := queue.Stats().PendingBytes
+	require.NoError(t, manager.Stop(context.Background()))
+
+	queue, err = wal.Open(dir, wal.Options{})
+	require.NoError(t, err)
+	defer queue.Close()
+	assert.Equal(t, pending, queue.Stats().PendingBytes)
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
@@ -17,54 +18,69 @@ func NewCPUStore(vmEndpoint string) *CPUStore {
 	}
 }
 
+func (s *CPUStore) Type() string {
+	return TypeCPU
+}
+
+func (s *CPUStore) Selector() string {
+	return "cpu_.*"
+}
+
 func (s *CPUStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
-	var lines []string
+	e := NewEncoder(timestamp)
+	host := Label{"host", metrics.Host.Hostname}
 
 	for _, cpu := range metrics.Cpu {
 		if cpu.IsTotal {
-			lines = append(lines, fmt.Sprintf(
-				"cpu_usage_percent_avg{host=\"%s\"} %f %d\n",
-				metrics.Host.Hostname,
-				cpu.UsagePercent,
-				timestamp,
-			))
-		} else {
-			lines = append(lines, fmt.Sprintf(
-				"cpu_usage_percent{host=\"%s\",model=\"%s\",core_id=\"%d\"} %f %d\n",
-				metrics.Host.Hostname,
-				cpu.Model,
-				cpu.CoreId,
-				cpu.UsagePercent,
-				timestamp,
-			))
-			lines = append(lines, fmt.Sprintf(
-				"cpu_user_time{host=\"%s\",model=\"%s\",core_id=\"%d\"} %f %d\n",
-				metrics.Host.Hostname,
-				cpu.Model,
-				cpu.CoreId,
-				cpu.UserTime,
-				timestamp,
-			))
-			lines = append(lines, fmt.Sprintf(
-				"cpu_system_time{host=\"%s\",model=\"%s\",core_id=\"%d\"} %f %d\n",
-				metrics.Host.Hostname,
-				cpu.Model,
-				cpu.CoreId,
-				cpu.SystemTime,
-				timestamp,
-			))
-			lines = append(lines, fmt.Sprintf(
-				"cpu_idle_time{host=\"%s\",model=\"%s\",core_id=\"%d\"} %f %d\n",
-				metrics.Host.Hostname,
-				cpu.Model,
-				cpu.CoreId,
-				cpu.IdleTime,
-				timestamp,
-			))
+			e.Gauge("cpu_usage_percent_avg", cpu.UsagePercent, host)
+			continue
 		}
+		labels := []Label{host, {"model", cpu.Model}, {"core_id", strconv.Itoa(int(cpu.CoreId))}}
+		e.Gauge("cpu_usage_percent", cpu.UsagePercent, labels...)
+		e.Counter("cpu_user_time", cpu.UserTime, labels...)
+		e.Counter("cpu_system_time", cpu.SystemTime, labels...)
+		e.Counter("cpu_idle_time", cpu.IdleTime, labels...)
+	}
+
+	return e.Lines()
+}
+
+func (s *CPUStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	if sample.Name == "cpu_usage_percent_avg" {
+		total := findCPU(payload, 0, true)
+		total.UsagePercent = sample.Value
+		return
 	}
 
-	return lines
+	coreID, err := strconv.Atoi(sample.Labels["core_id"])
+	if err != nil {
+		return
+	}
+	core := findCPU(payload, int32(coreID), false)
+	core.Model = sample.Labels["model"]
+
+	switch sample.Name {
+	case "cpu_usage_percent":
+		core.UsagePercent = sample.Value
+	case "cpu_user_time":
+		core.UserTime = sample.Value
+	case "cpu_system_time":
+		core.SystemTime = sample.Value
+	case "cpu_idle_time":
+		core.IdleTime = sample.Value
+	}
+}
+
+// findCPU returns the CPU entry of the payload matching coreID, creating it if needed
+func findCPU(payload *pb.MetricsPayload, coreID int32, isTotal bool) *pb.CPUMetrics {
+	for _, cpu := range payload.Cpu {
+		if cpu.IsTotal == isTotal && (isTotal || cpu.CoreId == coreID) {
+			return cpu
+		}
+	}
+	cpu := &pb.CPUMetrics{CoreId: coreID, IsTotal: isTotal}
+	payload.Cpu = append(payload.Cpu, cpu)
+	return cpu
 }
 
 func (s *CPUStore) Store(data []string) error {
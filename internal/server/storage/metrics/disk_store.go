@@ -17,40 +17,56 @@ func NewDiskStore(vmEndpoint string) *DiskStore {
 	}
 }
 
+func (s *DiskStore) Type() string {
+	return TypeDisk
+}
+
+func (s *DiskStore) Selector() string {
+	return "disk_.*"
+}
+
 func (s *DiskStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
-	var lines []string
+	e := NewEncoder(timestamp)
 
 	for _, disk := range metrics.Disk {
-		lines = append(lines, fmt.Sprintf(
-			"disk_total{host=\"%s\",device=\"%s\",path=\"%s\",fstype=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			disk.Device,
-			disk.Path,
-			disk.Fstype,
-			disk.Total,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"disk_used{host=\"%s\",device=\"%s\",path=\"%s\",fstype=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			disk.Device,
-			disk.Path,
-			disk.Fstype,
-			disk.Used,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"disk_used_percent{host=\"%s\",device=\"%s\",path=\"%s\",fstype=\"%s\"} %f %d\n",
-			metrics.Host.Hostname,
-			disk.Device,
-			disk.Path,
-			disk.Fstype,
-			disk.UsedPercent,
-			timestamp,
-		))
+		labels := []Label{
+			{"host", metrics.Host.Hostname},
+			{"device", disk.Device},
+			{"path", disk.Path},
+			{"fstype", disk.Fstype},
+		}
+		e.Gauge("disk_total", float64(disk.Total), labels...)
+		e.Gauge("disk_used", float64(disk.Used), labels...)
+		e.Gauge("disk_used_percent", disk.UsedPercent, labels...)
 	}
 
-	return lines
+	return e.Lines()
+}
+
+func (s *DiskStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	device := sample.Labels["device"]
+	path := sample.Labels["path"]
+
+	var disk *pb.DiskMetrics
+	for _, d := range payload.Disk {
+		if d.Device == device && d.Path == path {
+			disk = d
+			break
+		}
+	}
+	if disk == nil {
+		disk = &pb.DiskMetrics{Device: device, Path: path, Fstype: sample.Labels["fstype"]}
+		payload.Disk = append(payload.Disk, disk)
+	}
+
+	switch sample.Name {
+	case "disk_total":
+		disk.Total = uint64(sample.Value)
+	case "disk_used":
+		disk.Used = uint64(sample.Value)
+	case "disk_used_percent":
+		disk.UsedPercent = sample.Value
+	}
 }
 
 func (s *DiskStore) Store(data []string) error {
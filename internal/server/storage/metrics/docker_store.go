@@ -17,40 +17,62 @@ func NewDockerStore(vmEndpoint string) *DockerStore {
 	}
 }
 
+func (s *DockerStore) Type() string {
+	return TypeDocker
+}
+
+func (s *DockerStore) Selector() string {
+	return "docker_.*"
+}
+
 func (s *DockerStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
-	var lines []string
+	e := NewEncoder(timestamp)
 
 	for _, docker := range metrics.Docker {
-		lines = append(lines, fmt.Sprintf(
-			"docker_cpu_usage_percent{host=\"%s\",container_id=\"%s\",container_name=\"%s\",image=\"%s\"} %f %d\n",
-			metrics.Host.Hostname,
-			docker.ContainerId,
-			docker.ContainerName,
-			docker.Image,
-			docker.CpuMetrics.UsagePercent,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"docker_memory_used_percent{host=\"%s\",container_id=\"%s\",container_name=\"%s\",image=\"%s\"} %f %d\n",
-			metrics.Host.Hostname,
-			docker.ContainerId,
-			docker.ContainerName,
-			docker.Image,
-			docker.RamMetrics.UsedPercent,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"docker_network_bytes_sent{host=\"%s\",container_id=\"%s\",container_name=\"%s\",image=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			docker.ContainerId,
-			docker.ContainerName,
-			docker.Image,
-			docker.NetworkMetrics.BytesSent,
-			timestamp,
-		))
+		labels := []Label{
+			{"host", metrics.Host.Hostname},
+			{"container_id", docker.ContainerId},
+			{"container_name", docker.ContainerName},
+			{"image", docker.Image},
+		}
+		e.Gauge("docker_cpu_usage_percent", docker.CpuMetrics.GetUsagePercent(), labels...)
+		e.Gauge("docker_memory_used_percent", docker.RamMetrics.GetUsedPercent(), labels...)
+		e.Counter("docker_network_bytes_sent", float64(docker.NetworkMetrics.GetBytesSent()), labels...)
 	}
 
-	return lines
+	return e.Lines()
+}
+
+func (s *DockerStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	id := sample.Labels["container_id"]
+
+	var docker *pb.DockerMetrics
+	for _, d := range payload.Docker {
+		if d.ContainerId == id {
+			docker = d
+			break
+		}
+	}
+	if docker == nil {
+		docker = &pb.DockerMetrics{
+			ContainerId:    id,
+			ContainerName:  sample.Labels["container_name"],
+			Image:          sample.Labels["image"],
+			CpuMetrics:     &pb.CPUMetrics{},
+			RamMetrics:     &pb.RAMMetrics{},
+			NetworkMetrics: &pb.NetworkMetrics{},
+		}
+		payload.Docker = append(payload.Docker, docker)
+	}
+
+	switch sample.Name {
+	case "docker_cpu_usage_percent":
+		docker.CpuMetrics.UsagePercent = sample.Value
+	case "docker_memory_used_percent":
+		docker.RamMetrics.UsedPercent = sample.Value
+	case "docker_network_bytes_sent":
+		docker.NetworkMetrics.BytesSent = uint64(sample.Value)
+	}
 }
 
 func (s *DockerStore) Store(data []string) error {
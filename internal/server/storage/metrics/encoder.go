@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/theotruvelot/g0s/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	_typeCounter = "counter"
+	_typeGauge   = "gauge"
+)
+
+var (
+	_metricNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	_labelNameRe  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	_labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// Label is a label of a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Encoder writes the samples of the stores in the Prometheus text format imported by
+// VictoriaMetrics. Label values are escaped, as they come from the agents and may hold any
+// character, and the # TYPE of every metric is written before its first sample.
+type Encoder struct {
+	timestamp int64
+	lines     []string
+	types     map[string]string
+}
+
+// NewEncoder returns an encoder writing samples at timestamp, in milliseconds
+func NewEncoder(timestamp int64) *Encoder {
+	return &Encoder{timestamp: timestamp, types: make(map[string]string)}
+}
+
+// Gauge writes a sample of a value that goes up and down
+func (e *Encoder) Gauge(name string, value float64, labels ...Label) {
+	e.sample(name, _typeGauge, value, labels)
+}
+
+// Counter writes a sample of a value that only goes up, until the agent or host restarts
+func (e *Encoder) Counter(name string, value float64, labels ...Label) {
+	e.sample(name, _typeCounter, value, labels)
+}
+
+// Lines returns the lines written, each ending with a newline
+func (e *Encoder) Lines() []string {
+	return e.lines
+}
+
+// sample writes a sample, dropping it if a name is invalid as it would corrupt the whole import
+func (e *Encoder) sample(name, kind string, value float64, labels []Label) {
+	if !_metricNameRe.MatchString(name) {
+		logger.Error("Dropping sample with an invalid metric name", zap.String("name", name))
+		return
+	}
+	for _, label := range labels {
+		if !_labelNameRe.MatchString(label.Name) || strings.HasPrefix(label.Name, "__") {
+			logger.Error("Dropping sample with an invalid label name",
+				zap.String("name", name),
+				zap.String("label", label.Name))
+			return
+		}
+	}
+
+	if previous, ok := e.types[name]; !ok {
+		e.types[name] = kind
+		e.lines = append(e.lines, "# TYPE "+name+" "+kind+"\n")
+	} else if previous != kind {
+		logger.Error("Dropping sample of a metric written with another type",
+			zap.String("name", name),
+			zap.String("type", kind),
+			zap.String("previous_type", previous))
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(label.Name)
+			b.WriteString(`="`)
+			b.WriteString(escapeLabelValue(label.Value))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatInt(e.timestamp, 10))
+	b.WriteByte('\n')
+	e.lines = append(e.lines, b.String())
+}
+
+// escapeLabelValue escapes the backslashes, quotes and newlines of a label value, replacing
+// invalid UTF-8 as the format requires it
+func escapeLabelValue(s string) string {
+	return _labelValueEscaper.Replace(strings.ToValidUTF8(s, "\uFFFD"))
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
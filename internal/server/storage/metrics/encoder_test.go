@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncoder(t *testing.T) {
+	e := NewEncoder(1700000000000)
+	e.Counter("network_bytes_sent", 1.5e9, Label{"host", "web-1"}, Label{"interface", "eth0"})
+	e.Counter("network_bytes_sent", 42, Label{"host", "web-1"}, Label{"interface", "eth1"})
+	e.Gauge("host_procs", 12)
+
+	assert.Equal(t, []string{
+		"# TYPE network_bytes_sent counter\n",
+		`network_bytes_sent{host="web-1",interface="eth0"} 1.5e+09 1700000000000` + "\n",
+		`network_bytes_sent{host="web-1",interface="eth1"} 42 1700000000000` + "\n",
+		"# TYPE host_procs gauge\n",
+		"host_procs 12 1700000000000\n",
+	}, e.Lines())
+}
+
+func TestEncoder_EscapesLabelValues(t *testing.T) {
+	e := NewEncoder(0)
+	e.Gauge("docker_cpu_usage_percent", 1, Label{"container_name", "evil\"} 1\ninjected{host=\"x\\"}, Label{"image", "bad\xffutf8"})
+
+	lines := e.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, `docker_cpu_usage_percent{container_name="evil\"} 1\ninjected{host=\"x\\",image="bad`+"\uFFFD"+`utf8"} 1 0`+"\n", lines[1])
+	assert.Equal(t, 1, strings.Count(lines[1], "\n"))
+}
+
+func TestEncoder_DropsInvalidSamples(t *testing.T) {
+	e := NewEncoder(0)
+	e.Gauge("cpu usage", 1)
+	e.Gauge("cpu_usage", 1, Label{"core-id", "0"})
+	e.Gauge("cpu_usage", 1, Label{"__name__", "other"})
+	assert.Empty(t, e.Lines())
+
+	e.Gauge("cpu_usage", 1)
+	e.Counter("cpu_usage", 2)
+	assert.Len(t, e.Lines(), 2, "a metric keeps its first type")
+}
+
+// checkRoundTrip checks that the store writes the expected number of samples, one per line,
+// whose labels are parsed back as the values sent by the agent, empty if not in labels
+func checkRoundTrip(t *testing.T, store MetricStore, payload *pb.MetricsPayload, want int, labels map[string]string) {
+	samples := 0
+	for _, line := range store.Format(payload, 1700000000000) {
+		require.Equal(t, 1, strings.Count(line, "\n"), "a line is a single sample: %q", line)
+		require.True(t, strings.HasSuffix(line, "\n"))
+		if strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		samples++
+
+		sample, err := parseLine(line)
+		require.NoError(t, err, line)
+		require.Contains(t, sample.Labels, "host")
+		for name, value := range sample.Labels {
+			assert.Equal(t, strings.ToValidUTF8(labels[name], "\uFFFD"), value, "label %s of %q", name, line)
+		}
+		assert.Equal(t, float64(1700000000000), float64(sample.Timestamp.UnixMilli()))
+	}
+	assert.Equal(t, want, samples)
+}
+
+func FuzzEncoder_HostLabels(f *testing.F) {
+	f.Add("web-1", "Ubuntu", "6.1.0")
+	f.Add(`web"} 1`+"\n"+`evil{host="x`, `\`, "\xff\xfe")
+	f.Fuzz(func(t *testing.T, hostname, platform, kernel string) {
+		payload := &pb.MetricsPayload{Host: &pb.HostMetrics{Hostname: hostname, Platform: platform, KernelVersion: kernel}}
+		checkRoundTrip(t, NewHostStore(""), payload, 3, map[string]string{
+			"host": hostname, "platform": platform, "kernel_version": kernel,
+		})
+	})
+}
+
+func FuzzEncoder_ContainerLabels(f *testing.F) {
+	f.Add("web-1", "abc123", "nginx", "nginx:1.27", "eth0")
+	f.Add(`h\`, `id"`, "name\n", `img"} 1`+"\n"+`x{y="`, "\x00\xc3")
+	f.Fuzz(func(t *testing.T, hostname, id, name, image, iface string) {
+		if !utf8.ValidString(hostname) || !utf8.ValidString(id) {
+			// Containers and interfaces are matched by their label, which holds valid UTF-8
+			t.Skip()
+		}
+		payload := &pb.MetricsPayload{
+			Host: &pb.HostMetrics{Hostname: hostname},
+			Docker: []*pb.DockerMetrics{{
+				ContainerId:    id,
+				ContainerName:  name,
+				Image:          image,
+				CpuMetrics:     &pb.CPUMetrics{UsagePercent: 12.5},
+				RamMetrics:     &pb.RAMMetrics{UsedPercent: 40},
+				NetworkMetrics: &pb.NetworkMetrics{BytesSent: 1 << 40},
+			}},
+			Network: []*pb.NetworkMetrics{{InterfaceName: iface, BytesSent: 1}},
+		}
+		checkRoundTrip(t, NewDockerStore(""), payload, 3, map[string]string{
+			"host": hostname, "container_id": id, "container_name": name, "image": image,
+		})
+		checkRoundTrip(t, NewNetworkStore(""), payload, 15, map[string]string{"host": hostname, "interface": iface, "addresses": ""})
+	})
+}
+
+func TestSystemdStore_FailedUnits(t *testing.T) {
+	store := NewSystemdStore("")
+	assert.Empty(t, store.Format(&pb.MetricsPayload{Host: &pb.HostMetrics{Hostname: "web-1"}}, 1700000000000),
+		"nothing is written for hosts without systemd")
+
+	payload := &pb.MetricsPayload{
+		Host: &pb.HostMetrics{Hostname: "web-1"},
+		SystemdUnits: []*pb.SystemdUnitMetrics{
+			{Name: "nginx.service", ActiveState: "active", SubState: "running", MemoryBytes: 1 << 20, CpuSeconds: 12.5},
+			{Name: "backup.service", ActiveState: "failed", SubState: "failed", Result: "exit-code", LastExitCode: 3},
+		},
+	}
+	values := make(map[string]float64)
+	for _, line := range store.Format(payload, 1700000000000) {
+		if strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		sample, err := parseLine(line)
+		require.NoError(t, err, line)
+		values[sample.Name+"/"+sample.Labels["unit"]] = sample.Value
+	}
+
+	assert.Equal(t, map[string]float64{
+		"systemd_unit_info/nginx.service":              1,
+		"systemd_unit_failed/nginx.service":            0,
+		"systemd_unit_restarts_total/nginx.service":    0,
+		"systemd_unit_last_exit_code/nginx.service":    0,
+		"systemd_unit_memory_bytes/nginx.service":      1 << 20,
+		"systemd_unit_cpu_seconds_total/nginx.service": 12.5,
+		"systemd_unit_info/backup.service":             1,
+		"systemd_unit_failed/backup.service":           1,
+		"systemd_unit_restarts_total/backup.service":   0,
+		"systemd_unit_last_exit_code/backup.service":   3,
+		"systemd_failed_units/":                        1,
+	}, values)
+}
+
+func TestLoadStore_RoundTrip(t *testing.T) {
+	store := NewLoadStore("")
+	assert.Empty(t, store.Format(&pb.MetricsPayload{Host: &pb.HostMetrics{Hostname: "web-1"}}, 1700000000000),
+		"nothing is written for agents without the load collector")
+
+	payload := &pb.MetricsPayload{
+		Host: &pb.HostMetrics{Hostname: "web-1"},
+		Load: &pb.SystemLoadMetrics{
+			Load1: 2.5, Load5: 1.75, Load15: 0.5,
+			Pressure: []*pb.PressureMetrics{
+				{Resource: "cpu", Kind: "some", Avg10: 1.38, Avg60: 2.71, Avg300: 1.91, TotalMicroseconds: 134957371},
+				{Resource: "io", Kind: "full", Avg10: 0.1, TotalMicroseconds: 4567890},
+			},
+		},
+	}
+	loaded := &pb.MetricsPayload{}
+	for _, line := range store.Format(payload, 1700000000000) {
+		if strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		sample, err := parseLine(line)
+		require.NoError(t, err, line)
+		store.Load(loaded, sample)
+	}
+	assert.True(t, proto.Equal(payload.Load, loaded.Load), "got %v", loaded.Load)
+}
+
+func TestNetworkStore_RoundTrip(t *testing.T) {
+	store := NewNetworkStore("")
+	payload := &pb.MetricsPayload{
+		Host: &pb.HostMetrics{Hostname: "web-1"},
+		Network: []*pb.NetworkMetrics{
+			{
+				InterfaceName: "eth0", BytesSent: 1 << 30, BytesRecv: 1 << 31, PacketsSent: 1000, PacketsRecv: 2000,
+				ErrIn: 1, ErrOut: 2, DropIn: 3, DropOut: 4,
+				BytesSentPerSec: 1250.5, BytesRecvPerSec: 4096, PacketsSentPerSec: 10, PacketsRecvPerSec: 20.25,
+				Addresses: []string{"192.168.1.10/24", "fe80::1/64"}, Mtu: 1500, Up: true, SpeedMbps: 1000,
+			},
+			{InterfaceName: "wg0", Mtu: 1420},
+		},
+	}
+	loaded := &pb.MetricsPayload{}
+	for _, line := range store.Format(payload, 1700000000000) {
+		if strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+		sample, err := parseLine(line)
+		require.NoError(t, err, line)
+		store.Load(loaded, sample)
+	}
+	require.Len(t, loaded.Network, 2)
+	for i := range payload.Network {
+		assert.True(t, proto.Equal(payload.Network[i], loaded.Network[i]), "got %v", loaded.Network[i])
+	}
+}
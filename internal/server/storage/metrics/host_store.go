@@ -1 +1,78 @@
 package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+type HostStore struct {
+	vmEndpoint string
+}
+
+func NewHostStore(vmEndpoint string) *HostStore {
+	return &HostStore{
+		vmEndpoint: vmEndpoint,
+	}
+}
+
+func (s *HostStore) Type() string {
+	return TypeHost
+}
+
+func (s *HostStore) Selector() string {
+	return "host_.*"
+}
+
+func (s *HostStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
+	e := NewEncoder(timestamp)
+	host := Label{"host", metrics.Host.Hostname}
+
+	e.Gauge("host_info", 1,
+		host,
+		Label{"os", metrics.Host.Os},
+		Label{"platform", metrics.Host.Platform},
+		Label{"platform_family", metrics.Host.PlatformFamily},
+		Label{"platform_version", metrics.Host.PlatformVersion},
+		Label{"kernel_version", metrics.Host.KernelVersion},
+		Label{"virtualization_system", metrics.Host.VirtualizationSystem},
+		Label{"virtualization_role", metrics.Host.VirtualizationRole},
+	)
+	e.Gauge("host_uptime_seconds", float64(metrics.Host.Uptime), host)
+	e.Gauge("host_procs", float64(metrics.Host.Procs), host)
+
+	return e.Lines()
+}
+
+func (s *HostStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	switch sample.Name {
+	case "host_info":
+		payload.Host.Os = sample.Labels["os"]
+		payload.Host.Platform = sample.Labels["platform"]
+		payload.Host.PlatformFamily = sample.Labels["platform_family"]
+		payload.Host.PlatformVersion = sample.Labels["platform_version"]
+		payload.Host.KernelVersion = sample.Labels["kernel_version"]
+		payload.Host.VirtualizationSystem = sample.Labels["virtualization_system"]
+		payload.Host.VirtualizationRole = sample.Labels["virtualization_role"]
+	case "host_uptime_seconds":
+		payload.Host.Uptime = uint64(sample.Value)
+	case "host_procs":
+		payload.Host.Procs = uint64(sample.Value)
+	}
+}
+
+func (s *HostStore) Store(data []string) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	payload := strings.Join(data, "")
+	endpoint := fmt.Sprintf("%s/api/v1/import/prometheus", s.vmEndpoint)
+
+	if err := sendWithRetry(endpoint, payload, "Host"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+type LoadStore struct {
+	vmEndpoint string
+}
+
+func NewLoadStore(vmEndpoint string) *LoadStore {
+	return &LoadStore{
+		vmEndpoint: vmEndpoint,
+	}
+}
+
+func (s *LoadStore) Type() string {
+	return TypeLoad
+}
+
+func (s *LoadStore) Selector() string {
+	return "load_.*"
+}
+
+func (s *LoadStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
+	e := NewEncoder(timestamp)
+	// Agents older than the load collector send no load
+	if metrics.Load == nil {
+		return e.Lines()
+	}
+	host := Label{"host", metrics.Host.Hostname}
+
+	e.Gauge("load_average_1m", metrics.Load.Load1, host)
+	e.Gauge("load_average_5m", metrics.Load.Load5, host)
+	e.Gauge("load_average_15m", metrics.Load.Load15, host)
+
+	for _, pressure := range metrics.Load.Pressure {
+		labels := []Label{
+			host,
+			{"resource", pressure.Resource},
+			{"kind", pressure.Kind},
+		}
+		e.Gauge("load_pressure_avg10", pressure.Avg10, labels...)
+		e.Gauge("load_pressure_avg60", pressure.Avg60, labels...)
+		e.Gauge("load_pressure_avg300", pressure.Avg300, labels...)
+		e.Counter("load_pressure_stalled_seconds_total", float64(pressure.TotalMicroseconds)/1e6, labels...)
+	}
+
+	return e.Lines()
+}
+
+func (s *LoadStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	if payload.Load == nil {
+		payload.Load = &pb.SystemLoadMetrics{}
+	}
+
+	switch sample.Name {
+	case "load_average_1m":
+		payload.Load.Load1 = sample.Value
+		return
+	case "load_average_5m":
+		payload.Load.Load5 = sample.Value
+		return
+	case "load_average_15m":
+		payload.Load.Load15 = sample.Value
+		return
+	}
+
+	resource, kind := sample.Labels["resource"], sample.Labels["kind"]
+	if resource == "" || kind == "" {
+		return
+	}
+	var pressure *pb.PressureMetrics
+	for _, p := range payload.Load.Pressure {
+		if p.Resource == resource && p.Kind == kind {
+			pressure = p
+			break
+		}
+	}
+	if pressure == nil {
+		pressure = &pb.PressureMetrics{Resource: resource, Kind: kind}
+		payload.Load.Pressure = append(payload.Load.Pressure, pressure)
+	}
+
+	switch sample.Name {
+	case "load_pressure_avg10":
+		pressure.Avg10 = sample.Value
+	case "load_pressure_avg60":
+		pressure.Avg60 = sample.Value
+	case "load_pressure_avg300":
+		pressure.Avg300 = sample.Value
+	case "load_pressure_stalled_seconds_total":
+		pressure.TotalMicroseconds = uint64(sample.Value * 1e6)
+	}
+}
+
+func (s *LoadStore) Store(data []string) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	payload := strings.Join(data, "")
+	endpoint := fmt.Sprintf("%s/api/v1/import/prometheus", s.vmEndpoint)
+
+	if err := sendWithRetry(endpoint, payload, "Load"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package metrics
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"github.com/theotruvelot/g0s/internal/server/telemetry"
 	"github.com/theotruvelot/g0s/pkg/logger"
+	"github.com/theotruvelot/g0s/pkg/wal"
 	"net"
 	"net/http"
 	"sync"
@@ -14,28 +17,51 @@ import (
 )
 
 type MetricStore interface {
+	// Type returns the metric type the store handles (see MetricsRequest.metric_type)
+	Type() string
+	// Selector returns a regular expression matching the names of the series written by the store
+	Selector() string
 	Format(metrics *pb.MetricsPayload, timestamp int64) []string
 	Store(data []string) error
+	// Load rebuilds the part of the payload owned by the store from a sample read back from VictoriaMetrics
+	Load(payload *pb.MetricsPayload, sample Sample)
 }
 
 type Manager struct {
-	stores []MetricStore
+	vmEndpoint string
+	stores     []MetricStore
+	// queue buffers the series on disk before they are written, nil to write them right away
+	queue   *wal.Queue
+	ctx     context.Context
+	cancel  context.CancelFunc
+	drained chan struct{}
 }
 
 func NewMetricsManager(vmEndpoint string) *Manager {
 	return &Manager{
+		vmEndpoint: vmEndpoint,
 		stores: []MetricStore{
+			NewHostStore(vmEndpoint),
 			NewCPUStore(vmEndpoint),
 			NewRAMStore(vmEndpoint),
 			NewDiskStore(vmEndpoint),
 			NewNetworkStore(vmEndpoint),
 			NewDockerStore(vmEndpoint),
+			NewProcessStore(vmEndpoint),
+			NewSystemdStore(vmEndpoint),
+			NewLoadStore(vmEndpoint),
 		},
 	}
 }
 
+// StoreAllMetrics writes the payload to VictoriaMetrics, or to the write-ahead queue of a
+// buffered manager. Only failing to buffer the payload is an error.
 func (m *Manager) StoreAllMetrics(metrics *pb.MetricsPayload) error {
 	timestamp := metrics.Timestamp.AsTime().UnixNano() / int64(time.Millisecond)
+	if m.queue != nil {
+		return m.appendAll(metrics, timestamp)
+	}
+
 	var wg sync.WaitGroup
 	errors := make(chan error, len(m.stores))
 
@@ -78,6 +104,11 @@ func sendWithRetry(endpoint, payload string, metricType string) error {
 		},
 	}
 
+	start := time.Now()
+	defer func() {
+		telemetry.VMWriteDuration.With(metricType).Observe(time.Since(start).Seconds())
+	}()
+
 	var lastErr error
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
@@ -88,6 +119,7 @@ func sendWithRetry(endpoint, payload string, metricType string) error {
 				zap.Int("attempt", attempt+1),
 				zap.Duration("delay", delay))
 			time.Sleep(delay)
+			telemetry.VMWriteRetries.With(metricType).Inc()
 		}
 
 		resp, err := client.Post(endpoint, "text/plain", bytes.NewBufferString(payload))
@@ -118,5 +150,6 @@ func sendWithRetry(endpoint, payload string, metricType string) error {
 			zap.Int("attempt", attempt+1))
 	}
 
+	telemetry.VMWriteFailures.With(metricType).Inc()
 	return fmt.Errorf("failed to send %s metrics after %d attempts: %w", metricType, maxRetries, lastErr)
 }
@@ -19,41 +19,99 @@ func NewNetworkStore(vmEndpoint string) *NetworkStore {
 	}
 }
 
+func (s *NetworkStore) Type() string {
+	return TypeNetwork
+}
+
+func (s *NetworkStore) Selector() string {
+	return "network_.*"
+}
+
 func (s *NetworkStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
-	var lines []string
+	e := NewEncoder(timestamp)
 
 	for _, net := range metrics.Network {
-		lines = append(lines, fmt.Sprintf(
-			"network_bytes_sent{host=\"%s\",interface=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			net.InterfaceName,
-			net.BytesSent,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"network_bytes_recv{host=\"%s\",interface=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			net.InterfaceName,
-			net.BytesRecv,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"network_packets_sent{host=\"%s\",interface=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			net.InterfaceName,
-			net.PacketsSent,
-			timestamp,
-		))
-		lines = append(lines, fmt.Sprintf(
-			"network_packets_recv{host=\"%s\",interface=\"%s\"} %d %d\n",
-			metrics.Host.Hostname,
-			net.InterfaceName,
-			net.PacketsRecv,
-			timestamp,
-		))
+		labels := []Label{{"host", metrics.Host.Hostname}, {"interface", net.InterfaceName}}
+		e.Counter("network_bytes_sent", float64(net.BytesSent), labels...)
+		e.Counter("network_bytes_recv", float64(net.BytesRecv), labels...)
+		e.Counter("network_packets_sent", float64(net.PacketsSent), labels...)
+		e.Counter("network_packets_recv", float64(net.PacketsRecv), labels...)
+		e.Counter("network_err_in", float64(net.ErrIn), labels...)
+		e.Counter("network_err_out", float64(net.ErrOut), labels...)
+		e.Counter("network_drop_in", float64(net.DropIn), labels...)
+		e.Counter("network_drop_out", float64(net.DropOut), labels...)
+		e.Gauge("network_bytes_sent_per_second", net.BytesSentPerSec, labels...)
+		e.Gauge("network_bytes_recv_per_second", net.BytesRecvPerSec, labels...)
+		e.Gauge("network_packets_sent_per_second", net.PacketsSentPerSec, labels...)
+		e.Gauge("network_packets_recv_per_second", net.PacketsRecvPerSec, labels...)
+		// The addresses are a label of a single series, they rarely change
+		e.Gauge("network_interface_info", 1, append(labels, Label{"addresses", strings.Join(net.Addresses, ",")})...)
+		e.Gauge("network_mtu", float64(net.Mtu), labels...)
+		up := 0.0
+		if net.Up {
+			up = 1
+		}
+		e.Gauge("network_up", up, labels...)
+		if net.SpeedMbps > 0 {
+			e.Gauge("network_speed_mbps", float64(net.SpeedMbps), labels...)
+		}
 	}
 
-	return lines
+	return e.Lines()
+}
+
+func (s *NetworkStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	name := sample.Labels["interface"]
+
+	var iface *pb.NetworkMetrics
+	for _, n := range payload.Network {
+		if n.InterfaceName == name {
+			iface = n
+			break
+		}
+	}
+	if iface == nil {
+		iface = &pb.NetworkMetrics{InterfaceName: name}
+		payload.Network = append(payload.Network, iface)
+	}
+
+	switch sample.Name {
+	case "network_bytes_sent":
+		iface.BytesSent = uint64(sample.Value)
+	case "network_bytes_recv":
+		iface.BytesRecv = uint64(sample.Value)
+	case "network_packets_sent":
+		iface.PacketsSent = uint64(sample.Value)
+	case "network_packets_recv":
+		iface.PacketsRecv = uint64(sample.Value)
+	case "network_err_in":
+		iface.ErrIn = uint64(sample.Value)
+	case "network_err_out":
+		iface.ErrOut = uint64(sample.Value)
+	case "network_drop_in":
+		iface.DropIn = uint64(sample.Value)
+	case "network_drop_out":
+		iface.DropOut = uint64(sample.Value)
+	case "network_bytes_sent_per_second":
+		iface.BytesSentPerSec = sample.Value
+	case "network_bytes_recv_per_second":
+		iface.BytesRecvPerSec = sample.Value
+	case "network_packets_sent_per_second":
+		iface.PacketsSentPerSec = sample.Value
+	case "network_packets_recv_per_second":
+		iface.PacketsRecvPerSec = sample.Value
+	case "network_interface_info":
+		iface.Addresses = nil
+		if addresses := sample.Labels["addresses"]; addresses != "" {
+			iface.Addresses = strings.Split(addresses, ",")
+		}
+	case "network_mtu":
+		iface.Mtu = uint32(sample.Value)
+	case "network_up":
+		iface.Up = sample.Value == 1
+	case "network_speed_mbps":
+		iface.SpeedMbps = uint64(sample.Value)
+	}
 }
 
 func (s *NetworkStore) Store(data []string) error {
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+type ProcessStore struct {
+	vmEndpoint string
+}
+
+func NewProcessStore(vmEndpoint string) *ProcessStore {
+	return &ProcessStore{
+		vmEndpoint: vmEndpoint,
+	}
+}
+
+func (s *ProcessStore) Type() string {
+	return TypeProcess
+}
+
+func (s *ProcessStore) Selector() string {
+	return "process_.*"
+}
+
+func (s *ProcessStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
+	e := NewEncoder(timestamp)
+
+	for _, proc := range metrics.Processes {
+		labels := []Label{
+			{"host", metrics.Host.Hostname},
+			{"pid", strconv.Itoa(int(proc.Pid))},
+			{"name", proc.Name},
+		}
+		// The details of the process are labels of a single series to keep the others small
+		e.Gauge("process_info", 1, append(labels,
+			Label{"cmdline", proc.Cmdline},
+			Label{"username", proc.Username},
+			Label{"state", proc.State},
+		)...)
+		e.Gauge("process_cpu_percent", proc.CpuPercent, labels...)
+		e.Gauge("process_rss_bytes", float64(proc.RssBytes), labels...)
+		e.Gauge("process_threads", float64(proc.Threads), labels...)
+		e.Gauge("process_open_fds", float64(proc.OpenFiles), labels...)
+	}
+
+	return e.Lines()
+}
+
+func (s *ProcessStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	pid, err := strconv.Atoi(sample.Labels["pid"])
+	if err != nil {
+		return
+	}
+
+	var proc *pb.ProcessMetrics
+	for _, p := range payload.Processes {
+		if p.Pid == int32(pid) {
+			proc = p
+			break
+		}
+	}
+	if proc == nil {
+		proc = &pb.ProcessMetrics{Pid: int32(pid), Name: sample.Labels["name"]}
+		payload.Processes = append(payload.Processes, proc)
+	}
+
+	switch sample.Name {
+	case "process_info":
+		proc.Cmdline = sample.Labels["cmdline"]
+		proc.Username = sample.Labels["username"]
+		proc.State = sample.Labels["state"]
+	case "process_cpu_percent":
+		proc.CpuPercent = sample.Value
+	case "process_rss_bytes":
+		proc.RssBytes = uint64(sample.Value)
+	case "process_threads":
+		proc.Threads = int32(sample.Value)
+	case "process_open_fds":
+		proc.OpenFiles = int32(sample.Value)
+	}
+}
+
+func (s *ProcessStore) Store(data []string) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	payload := strings.Join(data, "")
+	endpoint := fmt.Sprintf("%s/api/v1/import/prometheus", s.vmEndpoint)
+
+	if err := sendWithRetry(endpoint, payload, "Process"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Metric types accepted by MetricsRequest.metric_type
+const (
+	TypeHost    = "host"
+	TypeCPU     = "cpu"
+	TypeRAM     = "ram"
+	TypeDisk    = "disk"
+	TypeNetwork = "network"
+	TypeDocker  = "docker"
+	TypeProcess = "process"
+	TypeSystemd = "systemd"
+	TypeLoad    = "load"
+)
+
+// _queryLookback is the maximum age of the samples returned by a query. It has to be
+// larger than the agent collection interval, otherwise idle hosts disappear between two pushes.
+const _queryLookback = 10 * time.Minute
+
+var (
+	ErrUnknownMetricType = errors.New("unknown metric type")
+	ErrInvalidHostFilter = errors.New("invalid host filter")
+)
+
+// Sample is the latest value of a series read back from VictoriaMetrics
+type Sample struct {
+	Name      string
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
+
+type vmQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]interface{}    `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// CompileHostFilter compiles a host filter the same way VictoriaMetrics matches it,
+// i.e. anchored on both ends. An empty filter matches every host.
+func CompileHostFilter(hostFilter string) (*regexp.Regexp, error) {
+	if hostFilter == "" {
+		hostFilter = ".*"
+	}
+	re, err := regexp.Compile("^(?:" + hostFilter + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHostFilter, err)
+	}
+	return re, nil
+}
+
+// ValidateMetricType returns ErrUnknownMetricType if metricType is neither empty nor handled by a store
+func (m *Manager) ValidateMetricType(metricType string) error {
+	_, err := m.storesFor(metricType)
+	return err
+}
+
+// QueryMetrics reads the latest samples of every host matching hostFilter and rebuilds one payload per host,
+// sorted by hostname. An empty metricType returns every metric type.
+func (m *Manager) QueryMetrics(ctx context.Context, hostFilter, metricType string) ([]*pb.MetricsPayload, error) {
+	if _, err := CompileHostFilter(hostFilter); err != nil {
+		return nil, err
+	}
+	if hostFilter == "" {
+		hostFilter = ".+"
+	}
+
+	stores, err := m.storesFor(metricType)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := make(map[string]*pb.MetricsPayload)
+	for _, store := range stores {
+		selector := fmt.Sprintf("{__name__=~%s,host=~%s}", strconv.Quote(store.Selector()), strconv.Quote(hostFilter))
+		samples, err := m.query(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range samples {
+			hostname := sample.Labels["host"]
+			payload, ok := payloads[hostname]
+			if !ok {
+				payload = &pb.MetricsPayload{Host: &pb.HostMetrics{Hostname: hostname}}
+				payloads[hostname] = payload
+			}
+			store.Load(payload, sample)
+		}
+
+		// The instant query stamps the samples with its evaluation time, the payload is stamped
+		// with the time of the latest sample collected instead, so stale hosts don't look fresh
+		times, err := m.query(ctx, fmt.Sprintf("max by (host) (tlast_over_time(%s[%ds]))", selector, int(_queryLookback.Seconds())))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			payload, ok := payloads[t.Labels["host"]]
+			if !ok {
+				continue
+			}
+			sec, frac := math.Modf(t.Value)
+			collected := time.Unix(int64(sec), int64(frac*float64(time.Second)))
+			if payload.Timestamp == nil || collected.After(payload.Timestamp.AsTime()) {
+				payload.Timestamp = timestamppb.New(collected)
+			}
+		}
+	}
+
+	result := make([]*pb.MetricsPayload, 0, len(payloads))
+	for _, payload := range payloads {
+		result = append(result, payload)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Host.Hostname < result[j].Host.Hostname
+	})
+
+	return result, nil
+}
+
+// FilterPayload returns a copy of the payload holding only the host information and the given metric type.
+// An empty metricType returns the payload unchanged.
+func FilterPayload(payload *pb.MetricsPayload, metricType string) *pb.MetricsPayload {
+	if metricType == "" {
+		return payload
+	}
+
+	filtered := &pb.MetricsPayload{
+		Host:      payload.Host,
+		Timestamp: payload.Timestamp,
+	}
+	switch metricType {
+	case TypeCPU:
+		filtered.Cpu = payload.Cpu
+	case TypeRAM:
+		filtered.Ram = payload.Ram
+	case TypeDisk:
+		filtered.Disk = payload.Disk
+	case TypeNetwork:
+		filtered.Network = payload.Network
+	case TypeDocker:
+		filtered.Docker = payload.Docker
+	case TypeProcess:
+		filtered.Processes = payload.Processes
+	case TypeSystemd:
+		filtered.SystemdUnits = payload.SystemdUnits
+	case TypeLoad:
+		filtered.Load = payload.Load
+	}
+	return filtered
+}
+
+func (m *Manager) storesFor(metricType string) ([]MetricStore, error) {
+	if metricType == "" {
+		return m.stores, nil
+	}
+
+	// Host information is always returned so the payload can be identified
+	var stores []MetricStore
+	found := false
+	for _, store := range m.stores {
+		switch store.Type() {
+		case metricType:
+			found = true
+			stores = append(stores, store)
+		case TypeHost:
+			stores = append(stores, store)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMetricType, metricType)
+	}
+	return stores, nil
+}
+
+// query runs an instant query against VictoriaMetrics. The samples are stamped with the
+// evaluation time of the query, not the time they were collected.
+func (m *Manager) query(ctx context.Context, query string) ([]Sample, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("step", _queryLookback.String())
+	endpoint := fmt.Sprintf("%s/api/v1/query?%s", m.vmEndpoint, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query VictoriaMetrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body vmQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode VictoriaMetrics response (status %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return nil, fmt.Errorf("VictoriaMetrics query failed: %s: %s", body.ErrorType, body.Error)
+	}
+
+	samples := make([]Sample, 0, len(body.Data.Result))
+	for _, r := range body.Data.Result {
+		seconds, ok := r.Value[0].(float64)
+		if !ok {
+			continue
+		}
+		raw, ok := r.Value[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+
+		sec, frac := math.Modf(seconds)
+		samples = append(samples, Sample{
+			Name:      r.Metric["__name__"],
+			Labels:    r.Metric,
+			Value:     value,
+			Timestamp: time.Unix(int64(sec), int64(frac*float64(time.Second))),
+		})
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+type vmSeries struct {
+	labels map[string]string
+	value  string
+	// collected is the time of the sample in seconds, _fakeCollected when 0
+	collected float64
+}
+
+// The fake VictoriaMetrics evaluates every query minutes after the samples were collected
+const (
+	_fakeEvalTime  = 1700000540.0
+	_fakeCollected = 1700000000.5
+)
+
+// newFakeVM returns a VictoriaMetrics stand-in answering instant queries from the given series,
+// and the time of their latest sample per host to the tlast_over_time queries
+func newFakeVM(t *testing.T, series []vmSeries) (*httptest.Server, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/query", r.URL.Path)
+		query := r.URL.Query().Get("query")
+		queries = append(queries, query)
+
+		result := make([]map[string]interface{}, 0)
+		latest := make(map[string]float64)
+		for _, s := range series {
+			prefix := strings.SplitN(s.labels["__name__"], "_", 2)[0]
+			if !strings.Contains(query, "\""+prefix+"_.*\"") {
+				continue
+			}
+			collected := s.collected
+			if collected == 0 {
+				collected = _fakeCollected
+			}
+			latest[s.labels["host"]] = max(latest[s.labels["host"]], collected)
+			result = append(result, map[string]interface{}{
+				"metric": s.labels,
+				"value":  []interface{}{_fakeEvalTime, s.value},
+			})
+		}
+		if strings.HasPrefix(query, "max by (host) (tlast_over_time(") {
+			result = result[:0]
+			for host, collected := range latest {
+				result = append(result, map[string]interface{}{
+					"metric": map[string]string{"host": host},
+					"value":  []interface{}{_fakeEvalTime, strconv.FormatFloat(collected, 'f', -1, 64)},
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "vector",
+				"result":     result,
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func TestManager_QueryMetrics(t *testing.T) {
+	vm, queries := newFakeVM(t, []vmSeries{
+		{labels: map[string]string{"__name__": "host_info", "host": "web-1", "os": "linux", "kernel_version": "6.1"}, value: "1"},
+		{labels: map[string]string{"__name__": "host_procs", "host": "web-1"}, value: "321"},
+		{labels: map[string]string{"__name__": "cpu_usage_percent_avg", "host": "web-1"}, value: "42.5"},
+		{labels: map[string]string{"__name__": "cpu_usage_percent", "host": "web-1", "model": "CPU 1", "core_id": "1"}, value: "40"},
+		{labels: map[string]string{"__name__": "cpu_idle_time", "host": "web-1", "model": "CPU 1", "core_id": "1"}, value: "1000"},
+		{labels: map[string]string{"__name__": "ram_used_percent", "host": "web-1"}, value: "12.5"},
+		{labels: map[string]string{"__name__": "disk_used", "host": "web-1", "device": "/dev/sda1", "path": "/", "fstype": "ext4"}, value: "2048"},
+		{labels: map[string]string{"__name__": "network_bytes_recv", "host": "web-1", "interface": "eth0"}, value: "4096"},
+		{labels: map[string]string{"__name__": "docker_cpu_usage_percent", "host": "web-1", "container_id": "abc", "container_name": "nginx", "image": "nginx:1"}, value: "3"},
+		{labels: map[string]string{"__name__": "process_info", "host": "web-1", "pid": "812", "name": "java", "cmdline": "java -jar app.jar", "username": "app", "state": "running"}, value: "1"},
+		{labels: map[string]string{"__name__": "process_cpu_percent", "host": "web-1", "pid": "812", "name": "java"}, value: "187.5"},
+		{labels: map[string]string{"__name__": "process_rss_bytes", "host": "web-1", "pid": "812", "name": "java"}, value: "1073741824"},
+		{labels: map[string]string{"__name__": "systemd_unit_info", "host": "web-1", "unit": "backup.service", "description": "Nightly backup", "load_state": "loaded", "active_state": "failed", "sub_state": "failed", "result": "exit-code"}, value: "1"},
+		{labels: map[string]string{"__name__": "systemd_unit_last_exit_code", "host": "web-1", "unit": "backup.service"}, value: "3"},
+		{labels: map[string]string{"__name__": "systemd_unit_restarts_total", "host": "web-1", "unit": "backup.service"}, value: "2"},
+		{labels: map[string]string{"__name__": "systemd_failed_units", "host": "web-1"}, value: "1"},
+		{labels: map[string]string{"__name__": "load_average_1m", "host": "web-1"}, value: "2.5"},
+		{labels: map[string]string{"__name__": "load_pressure_avg10", "host": "web-1", "resource": "memory", "kind": "some"}, value: "12.5"},
+		{labels: map[string]string{"__name__": "load_pressure_stalled_seconds_total", "host": "web-1", "resource": "memory", "kind": "some"}, value: "51.234567"},
+		{labels: map[string]string{"__name__": "host_info", "host": "db-1", "os": "linux"}, value: "1", collected: 1699999990},
+	})
+
+	manager := NewMetricsManager(vm.URL)
+	payloads, err := manager.QueryMetrics(context.Background(), "", "")
+	require.NoError(t, err)
+	require.Len(t, payloads, 2)
+	assert.Len(t, *queries, 18, "a query for the values and one for the sample times per store")
+
+	// Payloads are sorted by hostname
+	assert.Equal(t, "db-1", payloads[0].Host.Hostname)
+	assert.Equal(t, time.Unix(1699999990, 0).UTC(), payloads[0].Timestamp.AsTime(), "a silent host keeps the time of its last sample")
+	web := payloads[1]
+	assert.Equal(t, "web-1", web.Host.Hostname)
+	assert.Equal(t, "linux", web.Host.Os)
+	assert.Equal(t, "6.1", web.Host.KernelVersion)
+	assert.Equal(t, uint64(321), web.Host.Procs)
+	assert.Equal(t, time.Unix(1700000000, int64(500*time.Millisecond)).UTC(), web.Timestamp.AsTime())
+
+	require.Len(t, web.Cpu, 2)
+	assert.True(t, web.Cpu[0].IsTotal)
+	assert.Equal(t, 42.5, web.Cpu[0].UsagePercent)
+	assert.Equal(t, int32(1), web.Cpu[1].CoreId)
+	assert.Equal(t, "CPU 1", web.Cpu[1].Model)
+	assert.Equal(t, 40.0, web.Cpu[1].UsagePercent)
+	assert.Equal(t, 1000.0, web.Cpu[1].IdleTime)
+
+	assert.Equal(t, 12.5, web.Ram.UsedPercent)
+
+	require.Len(t, web.Disk, 1)
+	assert.Equal(t, &pb.DiskMetrics{Device: "/dev/sda1", Path: "/", Fstype: "ext4", Used: 2048}, web.Disk[0])
+
+	require.Len(t, web.Network, 1)
+	assert.Equal(t, uint64(4096), web.Network[0].BytesRecv)
+
+	require.Len(t, web.Docker, 1)
+	assert.Equal(t, "nginx", web.Docker[0].ContainerName)
+	assert.Equal(t, 3.0, web.Docker[0].CpuMetrics.UsagePercent)
+
+	require.Len(t, web.Processes, 1)
+	assert.Equal(t, &pb.ProcessMetrics{
+		Pid: 812, Name: "java", Cmdline: "java -jar app.jar", Username: "app", State: "running",
+		CpuPercent: 187.5, RssBytes: 1 << 30,
+	}, web.Processes[0])
+
+	require.Len(t, web.SystemdUnits, 1)
+	assert.Equal(t, &pb.SystemdUnitMetrics{
+		Name: "backup.service", Description: "Nightly backup", LoadState: "loaded",
+		ActiveState: "failed", SubState: "failed", Result: "exit-code", Restarts: 2, LastExitCode: 3,
+	}, web.SystemdUnits[0])
+
+	assert.Equal(t, 2.5, web.Load.Load1)
+	require.Len(t, web.Load.Pressure, 1)
+	assert.Equal(t, &pb.PressureMetrics{
+		Resource: "memory", Kind: "some", Avg10: 12.5, TotalMicroseconds: 51234567,
+	}, web.Load.Pressure[0])
+}
+
+func TestManager_QueryMetrics_Filters(t *testing.T) {
+	vm, queries := newFakeVM(t, nil)
+	manager := NewMetricsManager(vm.URL)
+
+	_, err := manager.QueryMetrics(context.Background(), "web-.*", TypeCPU)
+	require.NoError(t, err)
+	require.Len(t, *queries, 4)
+	assert.Equal(t, `{__name__=~"host_.*",host=~"web-.*"}`, (*queries)[0])
+	assert.Equal(t, `max by (host) (tlast_over_time({__name__=~"host_.*",host=~"web-.*"}[600s]))`, (*queries)[1])
+	assert.Equal(t, `{__name__=~"cpu_.*",host=~"web-.*"}`, (*queries)[2])
+
+	_, err = manager.QueryMetrics(context.Background(), "", "gpu")
+	assert.ErrorIs(t, err, ErrUnknownMetricType)
+
+	_, err = manager.QueryMetrics(context.Background(), "web-(", "")
+	assert.ErrorIs(t, err, ErrInvalidHostFilter)
+}
+
+func TestManager_QueryMetrics_VMError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"status":"error","errorType":"422","error":"cannot parse query"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewMetricsManager(server.URL).QueryMetrics(context.Background(), "", TypeRAM)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse query")
+}
+
+func TestCompileHostFilter(t *testing.T) {
+	re, err := CompileHostFilter("web-.*")
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("web-1"))
+	assert.False(t, re.MatchString("old-web-1"))
+
+	re, err = CompileHostFilter("")
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("anything"))
+}
+
+func TestFilterPayload(t *testing.T) {
+	payload := &pb.MetricsPayload{
+		Host: &pb.HostMetrics{Hostname: "web-1"},
+		Cpu:  []*pb.CPUMetrics{{IsTotal: true}},
+		Ram:  &pb.RAMMetrics{UsedPercent: 10},
+	}
+
+	assert.Same(t, payload, FilterPayload(payload, ""))
+
+	filtered := FilterPayload(payload, TypeRAM)
+	assert.Equal(t, "web-1", filtered.Host.Hostname)
+	assert.Nil(t, filtered.Cpu)
+	assert.Equal(t, 10.0, filtered.Ram.UsedPercent)
+}
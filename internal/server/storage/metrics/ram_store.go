@@ -19,29 +19,38 @@ func NewRAMStore(vmEndpoint string) *RAMStore {
 	}
 }
 
+func (s *RAMStore) Type() string {
+	return TypeRAM
+}
+
+func (s *RAMStore) Selector() string {
+	return "ram_.*"
+}
+
 func (s *RAMStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
-	var lines []string
-
-	lines = append(lines, fmt.Sprintf(
-		"ram_total_octets{host=\"%s\"} %d %d\n",
-		metrics.Host.Hostname,
-		metrics.Ram.TotalOctets,
-		timestamp,
-	))
-	lines = append(lines, fmt.Sprintf(
-		"ram_used_octets{host=\"%s\"} %d %d\n",
-		metrics.Host.Hostname,
-		metrics.Ram.UsedOctets,
-		timestamp,
-	))
-	lines = append(lines, fmt.Sprintf(
-		"ram_used_percent{host=\"%s\"} %f %d\n",
-		metrics.Host.Hostname,
-		metrics.Ram.UsedPercent,
-		timestamp,
-	))
-
-	return lines
+	e := NewEncoder(timestamp)
+	host := Label{"host", metrics.Host.Hostname}
+
+	e.Gauge("ram_total_octets", float64(metrics.Ram.GetTotalOctets()), host)
+	e.Gauge("ram_used_octets", float64(metrics.Ram.GetUsedOctets()), host)
+	e.Gauge("ram_used_percent", metrics.Ram.GetUsedPercent(), host)
+
+	return e.Lines()
+}
+
+func (s *RAMStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	if payload.Ram == nil {
+		payload.Ram = &pb.RAMMetrics{}
+	}
+
+	switch sample.Name {
+	case "ram_total_octets":
+		payload.Ram.TotalOctets = uint64(sample.Value)
+	case "ram_used_octets":
+		payload.Ram.UsedOctets = uint64(sample.Value)
+	case "ram_used_percent":
+		payload.Ram.UsedPercent = sample.Value
+	}
 }
 
 func (s *RAMStore) Store(data []string) error {
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+// Samples returns the series the stores write for the payload, as they are stored in
+// VictoriaMetrics. Lines that can't be parsed back are skipped.
+func (m *Manager) Samples(payload *pb.MetricsPayload) []Sample {
+	timestamp := payload.Timestamp.AsTime()
+	var samples []Sample
+	for _, store := range m.stores {
+		for _, line := range store.Format(payload, timestamp.UnixMilli()) {
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+			sample, err := parseLine(line)
+			if err != nil {
+				continue
+			}
+			sample.Timestamp = timestamp
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// parseLine parses a line of the Prometheus text format written by the stores,
+// i.e. name{label="value",...} value [timestamp]
+func parseLine(line string) (Sample, error) {
+	line = strings.TrimSpace(line)
+	sample := Sample{Labels: map[string]string{}}
+
+	end := strings.IndexAny(line, "{ ")
+	if end <= 0 {
+		return sample, fmt.Errorf("invalid line %q", line)
+	}
+	sample.Name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		rest = rest[1:]
+		for !strings.HasPrefix(rest, "}") {
+			eq := strings.Index(rest, "=\"")
+			if eq <= 0 {
+				return sample, fmt.Errorf("invalid labels in line %q", line)
+			}
+			name := rest[:eq]
+			value, n, err := unquoteLabelValue(rest[eq+1:])
+			if err != nil {
+				return sample, fmt.Errorf("invalid label %s in line %q: %w", name, line, err)
+			}
+			sample.Labels[name] = value
+			rest = strings.TrimPrefix(rest[eq+1+n:], ",")
+		}
+		rest = rest[1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, fmt.Errorf("missing value in line %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value in line %q: %w", line, err)
+	}
+	sample.Value = value
+	if len(fields) > 1 {
+		if ms, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			sample.Timestamp = time.UnixMilli(ms)
+		}
+	}
+	return sample, nil
+}
+
+// unquoteLabelValue unquotes the label value s starts with and returns it with its quoted length
+func unquoteLabelValue(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(s) {
+				break
+			}
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated value")
+}
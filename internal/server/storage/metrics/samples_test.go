@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseLine(t *testing.T) {
+	sample, err := parseLine(`cpu_usage_percent{host="web-1",model="Intel \"Xeon\"",core_id="0"} 42.500000 1700000000000` + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, "cpu_usage_percent", sample.Name)
+	assert.Equal(t, map[string]string{"host": "web-1", "model": `Intel "Xeon"`, "core_id": "0"}, sample.Labels)
+	assert.Equal(t, 42.5, sample.Value)
+	assert.Equal(t, time.UnixMilli(1700000000000), sample.Timestamp)
+
+	sample, err = parseLine("host_procs 12")
+	require.NoError(t, err)
+	assert.Equal(t, "host_procs", sample.Name)
+	assert.Empty(t, sample.Labels)
+
+	for _, line := range []string{"", `cpu{host="web-1} 1`, `cpu{host} 1`, `cpu{host="web-1"}`, `cpu{host="web-1"} high`} {
+		_, err := parseLine(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestManager_Samples(t *testing.T) {
+	manager := NewMetricsManager("http://localhost:8428")
+	at := time.Unix(1700000000, 0)
+	samples := manager.Samples(&pb.MetricsPayload{
+		Timestamp: timestamppb.New(at),
+		Host:      &pb.HostMetrics{Hostname: "web-1"},
+		Cpu:       []*pb.CPUMetrics{{IsTotal: true, UsagePercent: 93}},
+		Ram:       &pb.RAMMetrics{},
+	})
+
+	var found bool
+	for _, sample := range samples {
+		assert.Equal(t, "web-1", sample.Labels["host"])
+		assert.True(t, at.Equal(sample.Timestamp))
+		if sample.Name == "cpu_usage_percent_avg" {
+			found = true
+			assert.Equal(t, 93.0, sample.Value)
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/theotruvelot/g0s/pkg/proto/metric"
+)
+
+type SystemdStore struct {
+	vmEndpoint string
+}
+
+func NewSystemdStore(vmEndpoint string) *SystemdStore {
+	return &SystemdStore{
+		vmEndpoint: vmEndpoint,
+	}
+}
+
+func (s *SystemdStore) Type() string {
+	return TypeSystemd
+}
+
+func (s *SystemdStore) Selector() string {
+	return "systemd_.*"
+}
+
+func (s *SystemdStore) Format(metrics *pb.MetricsPayload, timestamp int64) []string {
+	e := NewEncoder(timestamp)
+	if len(metrics.SystemdUnits) == 0 {
+		return e.Lines()
+	}
+
+	failed := 0
+	for _, unit := range metrics.SystemdUnits {
+		labels := []Label{
+			{"host", metrics.Host.Hostname},
+			{"unit", unit.Name},
+		}
+		// The states of the unit are labels of a single series to keep the others small
+		e.Gauge("systemd_unit_info", 1, append(labels,
+			Label{"description", unit.Description},
+			Label{"load_state", unit.LoadState},
+			Label{"active_state", unit.ActiveState},
+			Label{"sub_state", unit.SubState},
+			Label{"result", unit.Result},
+		)...)
+		// Written for every unit, so alerts on it resolve once the unit recovers
+		isFailed := 0.0
+		if unit.ActiveState == "failed" {
+			isFailed = 1
+			failed++
+		}
+		e.Gauge("systemd_unit_failed", isFailed, labels...)
+		e.Counter("systemd_unit_restarts_total", float64(unit.Restarts), labels...)
+		e.Gauge("systemd_unit_last_exit_code", float64(unit.LastExitCode), labels...)
+		if unit.MemoryBytes > 0 {
+			e.Gauge("systemd_unit_memory_bytes", float64(unit.MemoryBytes), labels...)
+		}
+		if unit.CpuSeconds > 0 {
+			e.Counter("systemd_unit_cpu_seconds_total", unit.CpuSeconds, labels...)
+		}
+	}
+	e.Gauge("systemd_failed_units", float64(failed), Label{"host", metrics.Host.Hostname})
+
+	return e.Lines()
+}
+
+func (s *SystemdStore) Load(payload *pb.MetricsPayload, sample Sample) {
+	name, ok := sample.Labels["unit"]
+	if !ok {
+		return
+	}
+
+	var unit *pb.SystemdUnitMetrics
+	for _, u := range payload.SystemdUnits {
+		if u.Name == name {
+			unit = u
+			break
+		}
+	}
+	if unit == nil {
+		unit = &pb.SystemdUnitMetrics{Name: name}
+		payload.SystemdUnits = append(payload.SystemdUnits, unit)
+	}
+
+	switch sample.Name {
+	case "systemd_unit_info":
+		unit.Description = sample.Labels["description"]
+		unit.LoadState = sample.Labels["load_state"]
+		unit.ActiveState = sample.Labels["active_state"]
+		unit.SubState = sample.Labels["sub_state"]
+		unit.Result = sample.Labels["result"]
+	case "systemd_unit_restarts_total":
+		unit.Restarts = uint32(sample.Value)
+	case "systemd_unit_last_exit_code":
+		unit.LastExitCode = int32(sample.Value)
+	case "systemd_unit_memory_bytes":
+		unit.MemoryBytes = uint64(sample.Value)
+	case "systemd_unit_cpu_seconds_total":
+		unit.CpuSeconds = sample.Value
+	}
+}
+
+func (s *SystemdStore) Store(data []string) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	payload := strings.Join(data, "")
+	endpoint := fmt.Sprintf("%s/api/v1/import/prometheus", s.vmEndpoint)
+
+	if err := sendWithRetry(endpoint, payload, "Systemd"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"database/sql"
+
+	"github.com/theotruvelot/g0s/pkg/wal"
+)
+
+var (
+	// IngestedPayloads counts the metrics payloads received from the agents, per host
+	IngestedPayloads = Default.NewCounterVec("g0s_ingest_payloads_total",
+		"Metrics payloads received from the agents.", "hostname")
+	// IngestedBytes counts the size of the metrics payloads received from the agents, per host
+	IngestedBytes = Default.NewCounterVec("g0s_ingest_bytes_total",
+		"Size in bytes of the metrics payloads received from the agents.", "hostname")
+
+	// VMWriteDuration is the latency of the writes to VictoriaMetrics, retries included, per metric type
+	VMWriteDuration = Default.NewHistogramVec("g0s_vm_write_duration_seconds",
+		"Latency of the writes to VictoriaMetrics, retries included.", DefaultBuckets, "type")
+	// VMWriteRetries counts the writes to VictoriaMetrics attempted again, per metric type
+	VMWriteRetries = Default.NewCounterVec("g0s_vm_write_retries_total",
+		"Writes to VictoriaMetrics attempted again after a failure.", "type")
+	// VMWriteFailures counts the writes to VictoriaMetrics that failed every attempt, per metric type
+	VMWriteFailures = Default.NewCounterVec("g0s_vm_write_failures_total",
+		"Writes to VictoriaMetrics dropped after failing every attempt.", "type")
+
+	// GRPCRequestDuration is the latency of the unary gRPC calls, per method and status code
+	GRPCRequestDuration = Default.NewHistogramVec("g0s_grpc_request_duration_seconds",
+		"Latency of the unary gRPC calls.", DefaultBuckets, "method", "code")
+	// GRPCActiveStreams is the number of gRPC streams open, per method
+	GRPCActiveStreams = Default.NewGaugeVec("g0s_grpc_active_streams",
+		"gRPC streams open, such as agent metrics streams and health watches.", "method")
+	// GRPCStreams counts the gRPC streams closed, per method and status code
+	GRPCStreams = Default.NewCounterVec("g0s_grpc_streams_total",
+		"gRPC streams closed.", "method", "code")
+)
+
+// RegisterDBStats registers the statistics of the connection pool of the database
+func RegisterDBStats(db *sql.DB) {
+	Default.NewGaugeFunc("g0s_db_max_open_connections", "Maximum number of open connections to the database.",
+		func() float64 { return float64(db.Stats().MaxOpenConnections) })
+	Default.NewGaugeFunc("g0s_db_open_connections", "Connections to the database, in use or idle.",
+		func() float64 { return float64(db.Stats().OpenConnections) })
+	Default.NewGaugeFunc("g0s_db_in_use_connections", "Connections to the database in use.",
+		func() float64 { return float64(db.Stats().InUse) })
+	Default.NewGaugeFunc("g0s_db_idle_connections", "Idle connections to the database.",
+		func() float64 { return float64(db.Stats().Idle) })
+	Default.NewCounterFunc("g0s_db_wait_count_total", "Queries that waited for a free connection to the database.",
+		func() float64 { return float64(db.Stats().WaitCount) })
+	Default.NewCounterFunc("g0s_db_wait_duration_seconds_total", "Time spent waiting for a free connection to the database.",
+		func() float64 { return db.Stats().WaitDuration.Seconds() })
+}
+
+// RegisterWALStats registers the size of the write-ahead queue of the metrics and what it dropped
+func RegisterWALStats(queue *wal.Queue) {
+	Default.NewGaugeFunc("g0s_wal_pending_bytes", "Size of the metrics buffered, not written to VictoriaMetrics yet.",
+		func() float64 { return float64(queue.Stats().PendingBytes) })
+	Default.NewGaugeFunc("g0s_wal_segments", "Segment files of the metrics buffer.",
+		func() float64 { return float64(queue.Stats().Segments) })
+	Default.NewCounterFunc("g0s_wal_dropped_bytes_total", "Size of the buffered metrics dropped as the buffer was full.",
+		func() float64 { return float64(queue.Stats().DroppedBytes) })
+	Default.NewCounterFunc("g0s_wal_expired_records_total", "Buffered metrics dropped as they were too old.",
+		func() float64 { return float64(queue.Stats().ExpiredRecords) })
+	Default.NewCounterFunc("g0s_wal_corrupted_segments_total", "Segments of the metrics buffer whose unreadable tail was skipped.",
+		func() float64 { return float64(queue.Stats().CorruptedSegments) })
+}
@@ -0,0 +1,345 @@
+// Package telemetry exposes the internals of the server in the Prometheus text format
+package telemetry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histograms
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+const (
+	_kindCounter   = "counter"
+	_kindGauge     = "gauge"
+	_kindHistogram = "histogram"
+)
+
+// metric is a series of a family
+type metric interface {
+	// write writes the samples of the series, labels being its formatted label pairs
+	write(w *bufio.Writer, name string, labels []string)
+}
+
+// family is a metric with its help and type, and one series per set of label values
+type family struct {
+	name      string
+	help      string
+	kind      string
+	labels    []string
+	newMetric func() metric
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	metric      metric
+}
+
+// with returns the series of the label values, created on first use
+func (f *family) with(values []string) metric {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("telemetry: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), values...), metric: f.newMetric()}
+		f.series[key] = s
+	}
+	return s.metric
+}
+
+// delete removes the series of the label values
+func (f *family) delete(values []string) {
+	f.mu.Lock()
+	delete(f.series, strings.Join(values, "\xff"))
+	f.mu.Unlock()
+}
+
+func (f *family) write(w *bufio.Writer) {
+	f.mu.Lock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	all := make([]*series, len(keys))
+	for i, key := range keys {
+		all[i] = f.series[key]
+	}
+	f.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+	for _, s := range all {
+		labels := make([]string, len(f.labels))
+		for i, label := range f.labels {
+			labels[i] = label + `="` + escapeLabelValue(s.labelValues[i]) + `"`
+		}
+		s.metric.write(w, f.name, labels)
+	}
+}
+
+// Registry is a set of metric families
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// Default is the registry the server instruments itself on
+var Default = NewRegistry()
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// register adds a family, panicking if its name is taken as it is a programming error
+func (r *Registry) register(name, help, kind string, labels []string, newMetric func() metric) *family {
+	f := &family{
+		name:      name,
+		help:      help,
+		kind:      kind,
+		labels:    labels,
+		newMetric: newMetric,
+		series:    make(map[string]*series),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.families[name]; ok {
+		panic("telemetry: metric " + name + " registered twice")
+	}
+	r.families[name] = f
+	return f
+}
+
+// NewCounterVec registers a counter with one series per set of label values
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.register(name, help, _kindCounter, labels, func() metric { return &Counter{} })}
+}
+
+// NewGaugeVec registers a gauge with one series per set of label values
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.register(name, help, _kindGauge, labels, func() metric { return &Gauge{} })}
+}
+
+// NewHistogramVec registers a histogram with one series per set of label values, counting the
+// observations at most each of the sorted bucket upper bounds
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{r.register(name, help, _kindHistogram, labels, func() metric { return newHistogram(buckets) })}
+}
+
+// NewCounterFunc registers a counter whose value is read from fn on every scrape
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) {
+	r.register(name, help, _kindCounter, nil, nil).series[""] = &series{metric: funcMetric(fn)}
+}
+
+// NewGaugeFunc registers a gauge whose value is read from fn on every scrape
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(name, help, _kindGauge, nil, nil).series[""] = &series{metric: funcMetric(fn)}
+}
+
+// Write writes every family in the Prometheus text format, sorted by name
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	buf := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(buf)
+	}
+	return buf.Flush()
+}
+
+// Handler returns the HTTP handler of the /metrics endpoint
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.Write(w)
+	})
+}
+
+// value is a float64 updated atomically
+type value struct {
+	bits atomic.Uint64
+}
+
+func (v *value) add(delta float64) {
+	for {
+		old := v.bits.Load()
+		if v.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (v *value) set(x float64) { v.bits.Store(math.Float64bits(x)) }
+func (v *value) get() float64  { return math.Float64frombits(v.bits.Load()) }
+
+// Counter is a value that only goes up
+type Counter struct {
+	v value
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() { c.v.add(1) }
+
+// Add adds delta to the counter, ignoring negative deltas
+func (c *Counter) Add(delta float64) {
+	if delta > 0 {
+		c.v.add(delta)
+	}
+}
+
+// Value returns the value of the counter
+func (c *Counter) Value() float64 { return c.v.get() }
+
+func (c *Counter) write(w *bufio.Writer, name string, labels []string) {
+	writeSample(w, name, labels, c.v.get())
+}
+
+// Gauge is a value that goes up and down
+type Gauge struct {
+	v value
+}
+
+// Inc adds one to the gauge
+func (g *Gauge) Inc() { g.v.add(1) }
+
+// Dec subtracts one from the gauge
+func (g *Gauge) Dec() { g.v.add(-1) }
+
+// Set sets the gauge to x
+func (g *Gauge) Set(x float64) { g.v.set(x) }
+
+// Value returns the value of the gauge
+func (g *Gauge) Value() float64 { return g.v.get() }
+
+func (g *Gauge) write(w *bufio.Writer, name string, labels []string) {
+	writeSample(w, name, labels, g.v.get())
+}
+
+// Histogram counts observations in buckets
+type Histogram struct {
+	buckets []float64
+	counts  []atomic.Uint64
+	count   atomic.Uint64
+	sum     value
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{buckets: buckets, counts: make([]atomic.Uint64, len(buckets))}
+}
+
+// Observe adds an observation
+func (h *Histogram) Observe(x float64) {
+	if i := sort.SearchFloat64s(h.buckets, x); i < len(h.buckets) {
+		h.counts[i].Add(1)
+	}
+	h.sum.add(x)
+	h.count.Add(1)
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() uint64 { return h.count.Load() }
+
+func (h *Histogram) write(w *bufio.Writer, name string, labels []string) {
+	var cumulative uint64
+	for i, bound := range h.buckets {
+		cumulative += h.counts[i].Load()
+		writeSample(w, name+"_bucket", append(labels, `le="`+formatFloat(bound)+`"`), float64(cumulative))
+	}
+	count := h.count.Load()
+	writeSample(w, name+"_bucket", append(labels, `le="+Inf"`), float64(count))
+	writeSample(w, name+"_sum", labels, h.sum.get())
+	writeSample(w, name+"_count", labels, float64(count))
+}
+
+// funcMetric is a series read from a function on every scrape
+type funcMetric func() float64
+
+func (f funcMetric) write(w *bufio.Writer, name string, labels []string) {
+	writeSample(w, name, labels, f())
+}
+
+// CounterVec is a counter with one series per set of label values
+type CounterVec struct{ f *family }
+
+// With returns the counter of the label values, in the order of the labels
+func (c *CounterVec) With(values ...string) *Counter { return c.f.with(values).(*Counter) }
+
+// Delete removes the counter of the label values
+func (c *CounterVec) Delete(values ...string) { c.f.delete(values) }
+
+// GaugeVec is a gauge with one series per set of label values
+type GaugeVec struct{ f *family }
+
+// With returns the gauge of the label values, in the order of the labels
+func (g *GaugeVec) With(values ...string) *Gauge { return g.f.with(values).(*Gauge) }
+
+// Delete removes the gauge of the label values
+func (g *GaugeVec) Delete(values ...string) { g.f.delete(values) }
+
+// HistogramVec is a histogram with one series per set of label values
+type HistogramVec struct{ f *family }
+
+// With returns the histogram of the label values, in the order of the labels
+func (h *HistogramVec) With(values ...string) *Histogram { return h.f.with(values).(*Histogram) }
+
+func writeSample(w *bufio.Writer, name string, labels []string, v float64) {
+	w.WriteString(name)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		w.WriteString(strings.Join(labels, ","))
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	_labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	_helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(s string) string { return _labelValueEscaper.Replace(s) }
+func escapeHelp(s string) string       { return _helpEscaper.Replace(s) }
@@ -0,0 +1,101 @@
+package telemetry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	return out.String()
+}
+
+func TestRegistry_Write(t *testing.T) {
+	r := NewRegistry()
+	payloads := r.NewCounterVec("test_payloads_total", "Payloads received.", "hostname")
+	streams := r.NewGaugeVec("test_active_streams", "Streams open.", "method")
+	r.NewGaugeFunc("test_open_connections", "Connections open.", func() float64 { return 3 })
+
+	payloads.With("web-2").Inc()
+	payloads.With("web-1").Add(2)
+	payloads.With("web-1").Add(-5)
+	streams.With("/metric.MetricService/StreamMetrics").Inc()
+	streams.With("/metric.MetricService/StreamMetrics").Inc()
+	streams.With("/metric.MetricService/StreamMetrics").Dec()
+
+	assert.Equal(t, `# HELP test_active_streams Streams open.
+# TYPE test_active_streams gauge
+test_active_streams{method="/metric.MetricService/StreamMetrics"} 1
+# HELP test_open_connections Connections open.
+# TYPE test_open_connections gauge
+test_open_connections 3
+# HELP test_payloads_total Payloads received.
+# TYPE test_payloads_total counter
+test_payloads_total{hostname="web-1"} 2
+test_payloads_total{hostname="web-2"} 1
+`, scrape(t, r))
+
+	payloads.Delete("web-2")
+	assert.NotContains(t, scrape(t, r), "web-2")
+}
+
+func TestRegistry_Histogram(t *testing.T) {
+	r := NewRegistry()
+	latency := r.NewHistogramVec("test_duration_seconds", "Latency.", []float64{0.1, 1}, "method", "code")
+
+	h := latency.With("/host.HostService/ListHosts", "OK")
+	h.Observe(0.05)
+	h.Observe(0.1)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	assert.Equal(t, uint64(4), h.Count())
+	assert.Equal(t, `# HELP test_duration_seconds Latency.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="/host.HostService/ListHosts",code="OK",le="0.1"} 2
+test_duration_seconds_bucket{method="/host.HostService/ListHosts",code="OK",le="1"} 3
+test_duration_seconds_bucket{method="/host.HostService/ListHosts",code="OK",le="+Inf"} 4
+test_duration_seconds_sum{method="/host.HostService/ListHosts",code="OK"} 3.65
+test_duration_seconds_count{method="/host.HostService/ListHosts",code="OK"} 4
+`, scrape(t, r))
+}
+
+func TestRegistry_EscapesLabelValues(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "Help with a \\ and a\nnewline.", "hostname").With("evil\"} 1\n\\").Inc()
+
+	assert.Equal(t, `# HELP test_total Help with a \\ and a\nnewline.
+# TYPE test_total counter
+test_total{hostname="evil\"} 1\n\\"} 1
+`, scrape(t, r))
+}
+
+func TestRegistry_Misuse(t *testing.T) {
+	r := NewRegistry()
+	counter := r.NewCounterVec("test_total", "Help.", "hostname")
+
+	assert.Panics(t, func() { r.NewGaugeVec("test_total", "Help.") }, "registered twice")
+	assert.Panics(t, func() { counter.With("web-1", "extra") }, "wrong number of label values")
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "Help.").With().Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "test_total 1\n")
+
+	rec = httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
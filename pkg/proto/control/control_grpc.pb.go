@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: pkg/proto/control/control.proto
+
+package control
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ControlService_AgentControl_FullMethodName  = "/control.ControlService/AgentControl"
+	ControlService_SignalProcess_FullMethodName = "/control.ControlService/SignalProcess"
+	ControlService_ControlUnit_FullMethodName   = "/control.ControlService/ControlUnit"
+	ControlService_ListActions_FullMethodName   = "/control.ControlService/ListActions"
+)
+
+// ControlServiceClient is the client API for ControlService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Actions on the hosts, carried out by their agents. Every action is written to an audit record.
+type ControlServiceClient interface {
+	// AgentControl is kept open by the agents alongside the health watch. The agent first sends
+	// its hostname, then the server sends it the commands of the operators and the agent answers
+	// each of them with a result.
+	AgentControl(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, Command], error)
+	// SignalProcess sends a signal to processes of a host the caller can see, and returns the
+	// result reported by its agent. It requires the hosts:operate permission.
+	SignalProcess(ctx context.Context, in *SignalProcessRequest, opts ...grpc.CallOption) (*ActionResult, error)
+	// ControlUnit starts, stops or restarts a systemd unit of a host the caller can see, and
+	// returns the result reported by its agent. It requires the hosts:operate permission.
+	ControlUnit(ctx context.Context, in *ControlUnitRequest, opts ...grpc.CallOption) (*ActionResult, error)
+	// ListActions returns the audit records of the actions on the hosts the caller can see,
+	// most recent first
+	ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (*ListActionsResponse, error)
+}
+
+type controlServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControlServiceClient(cc grpc.ClientConnInterface) ControlServiceClient {
+	return &controlServiceClient{cc}
+}
+
+func (c *controlServiceClient) AgentControl(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, Command], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ControlService_ServiceDesc.Streams[0], ControlService_AgentControl_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AgentMessage, Command]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlService_AgentControlClient = grpc.BidiStreamingClient[AgentMessage, Command]
+
+func (c *controlServiceClient) SignalProcess(ctx context.Context, in *SignalProcessRequest, opts ...grpc.CallOption) (*ActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResult)
+	err := c.cc.Invoke(ctx, ControlService_SignalProcess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ControlUnit(ctx context.Context, in *ControlUnitRequest, opts ...grpc.CallOption) (*ActionResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActionResult)
+	err := c.cc.Invoke(ctx, ControlService_ControlUnit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlServiceClient) ListActions(ctx context.Context, in *ListActionsRequest, opts ...grpc.CallOption) (*ListActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActionsResponse)
+	err := c.cc.Invoke(ctx, ControlService_ListActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlServiceServer is the server API for ControlService service.
+// All implementations must embed UnimplementedControlServiceServer
+// for forward compatibility.
+//
+// Actions on the hosts, carried out by their agents. Every action is written to an audit record.
+type ControlServiceServer interface {
+	// AgentControl is kept open by the agents alongside the health watch. The agent first sends
+	// its hostname, then the server sends it the commands of the operators and the agent answers
+	// each of them with a result.
+	AgentControl(grpc.BidiStreamingServer[AgentMessage, Command]) error
+	// SignalProcess sends a signal to processes of a host the caller can see, and returns the
+	// result reported by its agent. It requires the hosts:operate permission.
+	SignalProcess(context.Context, *SignalProcessRequest) (*ActionResult, error)
+	// ControlUnit starts, stops or restarts a systemd unit of a host the caller can see, and
+	// returns the result reported by its agent. It requires the hosts:operate permission.
+	ControlUnit(context.Context, *ControlUnitRequest) (*ActionResult, error)
+	// ListActions returns the audit records of the actions on the hosts the caller can see,
+	// most recent first
+	ListActions(context.Context, *ListActionsRequest) (*ListActionsResponse, error)
+	mustEmbedUnimplementedControlServiceServer()
+}
+
+// UnimplementedControlServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedControlServiceServer struct{}
+
+func (UnimplementedControlServiceServer) AgentControl(grpc.BidiStreamingServer[AgentMessage, Command]) error {
+	return status.Errorf(codes.Unimplemented, "method AgentControl not implemented")
+}
+func (UnimplementedControlServiceServer) SignalProcess(context.Context, *SignalProcessRequest) (*ActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignalProcess not implemented")
+}
+func (UnimplementedControlServiceServer) ControlUnit(context.Context, *ControlUnitRequest) (*ActionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlUnit not implemented")
+}
+func (UnimplementedControlServiceServer) ListActions(context.Context, *ListActionsRequest) (*ListActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
+}
+func (UnimplementedControlServiceServer) mustEmbedUnimplementedControlServiceServer() {}
+func (UnimplementedControlServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeControlServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControlServiceServer will
+// result in compilation errors.
+type UnsafeControlServiceServer interface {
+	mustEmbedUnimplementedControlServiceServer()
+}
+
+func RegisterControlServiceServer(s grpc.ServiceRegistrar, srv ControlServiceServer) {
+	// If the following call pancis, it indicates UnimplementedControlServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ControlService_ServiceDesc, srv)
+}
+
+func _ControlService_AgentControl_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControlServiceServer).AgentControl(&grpc.GenericServerStream[AgentMessage, Command]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ControlService_AgentControlServer = grpc.BidiStreamingServer[AgentMessage, Command]
+
+func _ControlService_SignalProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).SignalProcess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_SignalProcess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).SignalProcess(ctx, req.(*SignalProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ControlUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ControlUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ControlUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ControlUnit(ctx, req.(*ControlUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControlService_ListActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServiceServer).ListActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControlService_ListActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServiceServer).ListActions(ctx, req.(*ListActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ControlService_ServiceDesc is the grpc.ServiceDesc for ControlService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ControlService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "control.ControlService",
+	HandlerType: (*ControlServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignalProcess",
+			Handler:    _ControlService_SignalProcess_Handler,
+		},
+		{
+			MethodName: "ControlUnit",
+			Handler:    _ControlService_ControlUnit_Handler,
+		},
+		{
+			MethodName: "ListActions",
+			Handler:    _ControlService_ListActions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AgentControl",
+			Handler:       _ControlService_AgentControl_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/proto/control/control.proto",
+}
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	HealthService_Check_FullMethodName = "/health.HealthService/Check"
 	HealthService_Watch_FullMethodName = "/health.HealthService/Watch"
+	HealthService_Hello_FullMethodName = "/health.HealthService/Hello"
 )
 
 // HealthServiceClient is the client API for HealthService service.
@@ -31,6 +32,10 @@ const (
 type HealthServiceClient interface {
 	Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	Watch(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HealthCheckResponse], error)
+	// Hello is called by the agent before sending metrics to exchange the versions and features
+	// of both sides. The server answers FailedPrecondition to agents it can't work with, servers
+	// predating it answer Unimplemented.
+	Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error)
 }
 
 type healthServiceClient struct {
@@ -70,6 +75,16 @@ func (c *healthServiceClient) Watch(ctx context.Context, in *HealthCheckRequest,
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type HealthService_WatchClient = grpc.ServerStreamingClient[HealthCheckResponse]
 
+func (c *healthServiceClient) Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HelloResponse)
+	err := c.cc.Invoke(ctx, HealthService_Hello_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HealthServiceServer is the server API for HealthService service.
 // All implementations must embed UnimplementedHealthServiceServer
 // for forward compatibility.
@@ -78,6 +93,10 @@ type HealthService_WatchClient = grpc.ServerStreamingClient[HealthCheckResponse]
 type HealthServiceServer interface {
 	Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 	Watch(*HealthCheckRequest, grpc.ServerStreamingServer[HealthCheckResponse]) error
+	// Hello is called by the agent before sending metrics to exchange the versions and features
+	// of both sides. The server answers FailedPrecondition to agents it can't work with, servers
+	// predating it answer Unimplemented.
+	Hello(context.Context, *HelloRequest) (*HelloResponse, error)
 	mustEmbedUnimplementedHealthServiceServer()
 }
 
@@ -94,6 +113,9 @@ func (UnimplementedHealthServiceServer) Check(context.Context, *HealthCheckReque
 func (UnimplementedHealthServiceServer) Watch(*HealthCheckRequest, grpc.ServerStreamingServer[HealthCheckResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
 }
+func (UnimplementedHealthServiceServer) Hello(context.Context, *HelloRequest) (*HelloResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hello not implemented")
+}
 func (UnimplementedHealthServiceServer) mustEmbedUnimplementedHealthServiceServer() {}
 func (UnimplementedHealthServiceServer) testEmbeddedByValue()                       {}
 
@@ -144,6 +166,24 @@ func _HealthService_Watch_Handler(srv interface{}, stream grpc.ServerStream) err
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type HealthService_WatchServer = grpc.ServerStreamingServer[HealthCheckResponse]
 
+func _HealthService_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HelloRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HealthService_Hello_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).Hello(ctx, req.(*HelloRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HealthService_ServiceDesc is the grpc.ServiceDesc for HealthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -155,6 +195,10 @@ var HealthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _HealthService_Check_Handler,
 		},
+		{
+			MethodName: "Hello",
+			Handler:    _HealthService_Hello_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
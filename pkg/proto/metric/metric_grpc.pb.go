@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MetricService_StreamMetrics_FullMethodName    = "/metric.MetricService/StreamMetrics"
-	MetricService_GetMetrics_FullMethodName       = "/metric.MetricService/GetMetrics"
-	MetricService_GetMetricsStream_FullMethodName = "/metric.MetricService/GetMetricsStream"
+	MetricService_StreamMetrics_FullMethodName        = "/metric.MetricService/StreamMetrics"
+	MetricService_StreamMetricsBatches_FullMethodName = "/metric.MetricService/StreamMetricsBatches"
+	MetricService_GetMetrics_FullMethodName           = "/metric.MetricService/GetMetrics"
+	MetricService_GetMetricsStream_FullMethodName     = "/metric.MetricService/GetMetricsStream"
 )
 
 // MetricServiceClient is the client API for MetricService service.
@@ -32,8 +33,12 @@ const (
 type MetricServiceClient interface {
 	// Stream metrics from agent to server
 	StreamMetrics(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[MetricsPayload, MetricsResponse], error)
+	// Stream batches of metrics from agent to server, acknowledged asynchronously. The server
+	// sends the header g0s-metrics-batches when the stream opens, agents fall back to
+	// StreamMetrics when it answers Unimplemented instead.
+	StreamMetricsBatches(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[MetricsBatch, MetricsAck], error)
 	// Get metrics for CLI
-	GetMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsPayload, error)
+	GetMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsList, error)
 	GetMetricsStream(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsPayload], error)
 }
 
@@ -58,9 +63,22 @@ func (c *metricServiceClient) StreamMetrics(ctx context.Context, opts ...grpc.Ca
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MetricService_StreamMetricsClient = grpc.BidiStreamingClient[MetricsPayload, MetricsResponse]
 
-func (c *metricServiceClient) GetMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsPayload, error) {
+func (c *metricServiceClient) StreamMetricsBatches(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[MetricsBatch, MetricsAck], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(MetricsPayload)
+	stream, err := c.cc.NewStream(ctx, &MetricService_ServiceDesc.Streams[1], MetricService_StreamMetricsBatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MetricsBatch, MetricsAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MetricService_StreamMetricsBatchesClient = grpc.BidiStreamingClient[MetricsBatch, MetricsAck]
+
+func (c *metricServiceClient) GetMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetricsList)
 	err := c.cc.Invoke(ctx, MetricService_GetMetrics_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -70,7 +88,7 @@ func (c *metricServiceClient) GetMetrics(ctx context.Context, in *MetricsRequest
 
 func (c *metricServiceClient) GetMetricsStream(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsPayload], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &MetricService_ServiceDesc.Streams[1], MetricService_GetMetricsStream_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &MetricService_ServiceDesc.Streams[2], MetricService_GetMetricsStream_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +113,12 @@ type MetricService_GetMetricsStreamClient = grpc.ServerStreamingClient[MetricsPa
 type MetricServiceServer interface {
 	// Stream metrics from agent to server
 	StreamMetrics(grpc.BidiStreamingServer[MetricsPayload, MetricsResponse]) error
+	// Stream batches of metrics from agent to server, acknowledged asynchronously. The server
+	// sends the header g0s-metrics-batches when the stream opens, agents fall back to
+	// StreamMetrics when it answers Unimplemented instead.
+	StreamMetricsBatches(grpc.BidiStreamingServer[MetricsBatch, MetricsAck]) error
 	// Get metrics for CLI
-	GetMetrics(context.Context, *MetricsRequest) (*MetricsPayload, error)
+	GetMetrics(context.Context, *MetricsRequest) (*MetricsList, error)
 	GetMetricsStream(*MetricsRequest, grpc.ServerStreamingServer[MetricsPayload]) error
 	mustEmbedUnimplementedMetricServiceServer()
 }
@@ -111,7 +133,10 @@ type UnimplementedMetricServiceServer struct{}
 func (UnimplementedMetricServiceServer) StreamMetrics(grpc.BidiStreamingServer[MetricsPayload, MetricsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamMetrics not implemented")
 }
-func (UnimplementedMetricServiceServer) GetMetrics(context.Context, *MetricsRequest) (*MetricsPayload, error) {
+func (UnimplementedMetricServiceServer) StreamMetricsBatches(grpc.BidiStreamingServer[MetricsBatch, MetricsAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMetricsBatches not implemented")
+}
+func (UnimplementedMetricServiceServer) GetMetrics(context.Context, *MetricsRequest) (*MetricsList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
 func (UnimplementedMetricServiceServer) GetMetricsStream(*MetricsRequest, grpc.ServerStreamingServer[MetricsPayload]) error {
@@ -145,6 +170,13 @@ func _MetricService_StreamMetrics_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MetricService_StreamMetricsServer = grpc.BidiStreamingServer[MetricsPayload, MetricsResponse]
 
+func _MetricService_StreamMetricsBatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MetricServiceServer).StreamMetricsBatches(&grpc.GenericServerStream[MetricsBatch, MetricsAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MetricService_StreamMetricsBatchesServer = grpc.BidiStreamingServer[MetricsBatch, MetricsAck]
+
 func _MetricService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MetricsRequest)
 	if err := dec(in); err != nil {
@@ -193,6 +225,12 @@ var MetricService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamMetricsBatches",
+			Handler:       _MetricService_StreamMetricsBatches_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "GetMetricsStream",
 			Handler:       _MetricService_GetMetricsStream_Handler,
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// CheckWritableDir creates dir if it doesn't exist and checks that files can be created in it,
+// e.g. that a default directory under /var/lib is usable by a user other than root
+func CheckWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %w", err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
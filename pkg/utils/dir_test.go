@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckWritableDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state", "wal")
+	if err := CheckWritableDir(dir); err != nil {
+		t.Fatalf("CheckWritableDir() error = %v, missing directories should be created", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("CheckWritableDir() left %d files behind", len(entries))
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckWritableDir(filepath.Join(file, "wal")); err == nil {
+		t.Error("CheckWritableDir() should fail when a file is in the way")
+	}
+}
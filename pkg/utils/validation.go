@@ -3,9 +3,18 @@ package utils
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
+// _unitTypes are the suffixes of the systemd unit names, see systemd.unit(5)
+const _unitTypes = `\.(service|socket|device|mount|automount|swap|target|path|timer|slice|scope)$`
+
+var (
+	_unitNamePattern = regexp.MustCompile(`^[a-zA-Z0-9:_.\\@-]+` + _unitTypes)
+	_unitTypePattern = regexp.MustCompile(_unitTypes)
+)
+
 // ValidateServerURL validates that the provided string is a valid HTTP/HTTPS URL
 func ValidateServerURL(serverURL string) error {
 	if serverURL == "" {
@@ -36,3 +45,21 @@ func NormalizeServerURL(serverURL string) string {
 	// Remove trailing slash
 	return strings.TrimSuffix(serverURL, "/")
 }
+
+// HasUnitType reports whether a unit name, or a glob of unit names, ends with a unit type,
+// e.g. ".service" or ".timer"
+func HasUnitType(name string) bool {
+	return _unitTypePattern.MatchString(name)
+}
+
+// NormalizeUnitName validates a systemd unit name, a name without unit type being a service,
+// e.g. "nginx" is "nginx.service"
+func NormalizeUnitName(name string) (string, error) {
+	if !HasUnitType(name) {
+		name += ".service"
+	}
+	if len(name) > 255 || !_unitNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid unit name %q", name)
+	}
+	return name, nil
+}
@@ -149,3 +149,36 @@ func TestNormalizeServerURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeUnitName(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      string
+		wantError bool
+	}{
+		{name: "nginx", want: "nginx.service"},
+		{name: "nginx.service", want: "nginx.service"},
+		{name: "getty@tty1.service", want: "getty@tty1.service"},
+		{name: "backup.timer", want: "backup.timer"},
+		{name: "php8.2-fpm", want: "php8.2-fpm.service"},
+		{name: "", wantError: true},
+		{name: "../nginx", wantError: true},
+		{name: "nginx restart", wantError: true},
+		{name: strings.Repeat("a", 256), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeUnitName(tt.name)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("NormalizeUnitName(%q) = %q, want an error", tt.name, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("NormalizeUnitName(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
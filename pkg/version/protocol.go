@@ -0,0 +1,30 @@
+package version
+
+// Protocol is the version of the protocol between the agents and the server. It is increased
+// on changes the other side can't ignore, optional ones are negotiated as features instead.
+const Protocol uint32 = 1
+
+// MinProtocol is the oldest protocol version of the other side this binary still works with
+const MinProtocol uint32 = 1
+
+// Features negotiated between the agents and the server
+const (
+	// FeatureMetricsBatches is sending metrics on StreamMetricsBatches
+	FeatureMetricsBatches = "metrics-batches"
+	// FeatureGzip is compressing the metrics batches with gzip
+	FeatureGzip = "gzip"
+)
+
+// CommonFeatures returns the features of ours also in theirs, in the order of ours
+func CommonFeatures(ours, theirs []string) []string {
+	common := make([]string, 0, len(ours))
+	for _, feature := range ours {
+		for _, other := range theirs {
+			if feature == other {
+				common = append(common, feature)
+				break
+			}
+		}
+	}
+	return common
+}
@@ -0,0 +1,6 @@
+// Package version holds the version of the g0s binaries, set at build time with
+// -ldflags "-X github.com/theotruvelot/g0s/pkg/version.Version=v1.2.3"
+package version
+
+// Version is the version of the running binary, "dev" for local builds
+var Version = "dev"
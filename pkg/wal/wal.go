@@ -0,0 +1,559 @@
+// Package wal is a durable FIFO queue of records, stored on disk as a sequence of segment files.
+// Records are appended to the newest segment and read from the oldest one, and a segment is
+// deleted once every record of it is acknowledged. The read position survives restarts.
+package wal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSegmentBytes is the size segments are rotated at by default
+	DefaultSegmentBytes = 16 << 20
+	// MaxRecordBytes is the size of the largest record
+	MaxRecordBytes = 64 << 20
+
+	// _headerSize is the size of the header of a record: its length, the CRC-32 of the rest of
+	// the record and the time it was appended, in nanoseconds
+	_headerSize    = 16
+	_segmentSuffix = ".seg"
+	_cursorFile    = "cursor"
+)
+
+var (
+	ErrClosed         = errors.New("wal: queue closed")
+	ErrRecordTooLarge = errors.New("wal: record too large")
+
+	// errCorrupted is returned when a record is truncated or fails its checksum
+	errCorrupted = errors.New("wal: corrupted record")
+)
+
+// Options bounds a queue. Zero values mean no bound.
+type Options struct {
+	// MaxBytes bounds the size of the queue on disk, the oldest segments are dropped beyond it
+	MaxBytes int64
+	// MaxAge bounds the age of the records, older records are dropped instead of read
+	MaxAge time.Duration
+	// SegmentBytes is the size segments are rotated at, DefaultSegmentBytes if zero. It is
+	// lowered to a quarter of MaxBytes so the queue never drops more than a quarter at once.
+	SegmentBytes int64
+}
+
+// Stats describes the content of a queue and what it dropped since it was opened
+type Stats struct {
+	// PendingBytes is the size of the records not acknowledged yet, headers included
+	PendingBytes int64
+	Segments     int
+	// DroppedBytes is the size of the records dropped because of MaxBytes
+	DroppedBytes int64
+	// ExpiredRecords is the number of records dropped because of MaxAge
+	ExpiredRecords int64
+	// CorruptedSegments is the number of segments whose tail was unreadable and skipped
+	CorruptedSegments int64
+}
+
+type segment struct {
+	id   uint64
+	size int64
+}
+
+// position is a position in a segment
+type position struct {
+	id     uint64
+	offset int64
+}
+
+// inflight is a record returned by Next and not acknowledged yet
+type inflight struct {
+	// end is the position following the record
+	end position
+	// skipped records are expired or corrupted ones, acknowledged with the records before them
+	skipped bool
+}
+
+// Queue is a durable FIFO queue with a single reader, which may read ahead of the records it
+// acknowledged. It is safe for concurrent use.
+type Queue struct {
+	dir    string
+	opts   Options
+	now    func() time.Time
+	notify chan struct{}
+
+	mu sync.Mutex
+	// segments are sorted from the oldest, being read, to the newest, being written
+	segments []*segment
+	head     *os.File
+	reader   *os.File
+	readerID uint64
+	// offset is the position of the oldest record not acknowledged, in the oldest segment
+	offset int64
+	// read is the position of the record returned by the next call to Next
+	read     position
+	inflight []inflight
+	stats    Stats
+	closed   bool
+}
+
+// Open opens the queue stored in dir, creating it if needed. Records are appended to a new
+// segment, so a record torn by a crash is never followed by new ones.
+func Open(dir string, opts Options) (*Queue, error) {
+	if opts.SegmentBytes <= 0 {
+		opts.SegmentBytes = DefaultSegmentBytes
+	}
+	if opts.MaxBytes > 0 && opts.SegmentBytes > opts.MaxBytes/4 {
+		opts.SegmentBytes = max(opts.MaxBytes/4, 1)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("wal: create directory: %w", err)
+	}
+
+	q := &Queue{
+		dir:    dir,
+		opts:   opts,
+		now:    time.Now,
+		notify: make(chan struct{}, 1),
+	}
+	cursorID, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// The new segment follows the cursor too, as the segment it points to may be gone
+	id := cursorID + 1
+	if len(q.segments) > 0 {
+		id = max(id, q.segments[len(q.segments)-1].id+1)
+	}
+	if err := q.createSegment(id); err != nil {
+		return nil, err
+	}
+	q.read = position{id: q.segments[0].id, offset: q.offset}
+	return q, nil
+}
+
+// load lists the segments of the directory and restores the read position, returning the
+// segment it is in
+func (q *Queue) load() (uint64, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return 0, fmt.Errorf("wal: list segments: %w", err)
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), _segmentSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		id, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return 0, fmt.Errorf("wal: stat segment: %w", err)
+		}
+		q.segments = append(q.segments, &segment{id: id, size: info.Size()})
+	}
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i].id < q.segments[j].id })
+
+	cursorID, cursorOffset, err := q.readCursor()
+	if err != nil {
+		return 0, err
+	}
+	// Segments before the cursor were read entirely before a crash prevented their deletion
+	for len(q.segments) > 0 && q.segments[0].id < cursorID {
+		if err := os.Remove(q.segmentPath(q.segments[0].id)); err != nil {
+			return 0, fmt.Errorf("wal: remove segment: %w", err)
+		}
+		q.segments = q.segments[1:]
+	}
+	if len(q.segments) > 0 && q.segments[0].id == cursorID {
+		q.offset = min(cursorOffset, q.segments[0].size)
+	}
+	return cursorID, nil
+}
+
+// Append adds a record at the end of the queue, written to disk before it returns
+func (q *Queue) Append(data []byte) error {
+	if len(data) > MaxRecordBytes {
+		return ErrRecordTooLarge
+	}
+
+	record := make([]byte, _headerSize+len(data))
+	binary.LittleEndian.PutUint32(record[0:], uint32(len(data)))
+	binary.LittleEndian.PutUint64(record[8:], uint64(q.now().UnixNano()))
+	copy(record[_headerSize:], data)
+	binary.LittleEndian.PutUint32(record[4:], crc32.ChecksumIEEE(record[8:]))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+
+	head := q.segments[len(q.segments)-1]
+	if _, err := q.head.Write(record); err != nil {
+		// Remove what was written of the record so the segment stays readable
+		_ = q.head.Truncate(head.size)
+		return fmt.Errorf("wal: write record: %w", err)
+	}
+	if err := q.head.Sync(); err != nil {
+		_ = q.head.Truncate(head.size)
+		return fmt.Errorf("wal: sync segment: %w", err)
+	}
+	head.size += int64(len(record))
+
+	if head.size >= q.opts.SegmentBytes {
+		if err := q.head.Close(); err != nil {
+			return fmt.Errorf("wal: close segment: %w", err)
+		}
+		if err := q.createSegment(head.id + 1); err != nil {
+			return err
+		}
+	}
+	if err := q.enforceMaxBytes(); err != nil {
+		return err
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Notify returns a channel receiving a value after records are appended
+func (q *Queue) Notify() <-chan struct{} {
+	return q.notify
+}
+
+// Peek returns the oldest record not acknowledged yet, ok being false if the queue is empty.
+// The record stays in the queue until Ack is called, so Peek returns it again meanwhile.
+func (q *Queue) Peek() (data []byte, ok bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rewind()
+	return q.next()
+}
+
+// Next returns the record following the last one returned, ok being false if there is none.
+// Records returned stay in the queue until acknowledged, in order, by Ack.
+func (q *Queue) Next() (data []byte, ok bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.next()
+}
+
+// Rewind makes Next return the oldest record not acknowledged again, e.g. after the records
+// read since were lost on the way
+func (q *Queue) Rewind() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rewind()
+}
+
+// Ack removes the oldest record returned by Next or Peek and not acknowledged yet from the
+// queue. It does nothing if that record was dropped meanwhile to respect MaxBytes.
+func (q *Queue) Ack() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if len(q.inflight) == 0 {
+		return nil
+	}
+
+	end := q.inflight[0].end
+	q.inflight = q.inflight[1:]
+	for len(q.inflight) > 0 && q.inflight[0].skipped {
+		end = q.inflight[0].end
+		q.inflight = q.inflight[1:]
+	}
+	if err := q.advance(end); err != nil {
+		return err
+	}
+	return q.writeCursor()
+}
+
+func (q *Queue) rewind() {
+	q.inflight = nil
+	q.read = position{id: q.segments[0].id, offset: q.offset}
+}
+
+func (q *Queue) next() ([]byte, bool, error) {
+	for {
+		if q.closed {
+			return nil, false, ErrClosed
+		}
+
+		i := q.segmentIndex(q.read.id)
+		if i < 0 {
+			// The segment was dropped to respect MaxBytes, so were the records read from it
+			q.rewind()
+			continue
+		}
+		seg := q.segments[i]
+		if q.read.offset >= seg.size {
+			if i == len(q.segments)-1 {
+				return nil, false, nil
+			}
+			if err := q.skip(position{id: q.segments[i+1].id}); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+
+		data, appendedAt, err := q.readRecord(seg, q.read.offset)
+		if errors.Is(err, errCorrupted) {
+			// Nothing after a torn or corrupted record can be trusted, skip the rest of the segment
+			q.stats.CorruptedSegments++
+			if err := q.skip(position{id: seg.id, offset: seg.size}); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		end := position{id: seg.id, offset: q.read.offset + _headerSize + int64(len(data))}
+		if q.opts.MaxAge > 0 && q.now().Sub(appendedAt) > q.opts.MaxAge {
+			q.stats.ExpiredRecords++
+			if err := q.skip(end); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		q.read = end
+		q.inflight = append(q.inflight, inflight{end: end})
+		return data, true, nil
+	}
+}
+
+// skip moves the read position to p without returning what is before, acknowledging it at once
+// if nothing is in flight
+func (q *Queue) skip(p position) error {
+	q.read = p
+	if len(q.inflight) > 0 {
+		q.inflight = append(q.inflight, inflight{end: p, skipped: true})
+		return nil
+	}
+	return q.advance(p)
+}
+
+// advance moves the position of the oldest record not acknowledged to p, deleting the segments
+// read entirely
+func (q *Queue) advance(p position) error {
+	if p.id < q.segments[0].id {
+		// Dropped meanwhile to respect MaxBytes
+		return nil
+	}
+	for q.segments[0].id < p.id {
+		if err := q.removeOldest(); err != nil {
+			return err
+		}
+	}
+	q.offset = p.offset
+	if q.offset >= q.segments[0].size && len(q.segments) > 1 {
+		return q.removeOldest()
+	}
+	return nil
+}
+
+// Stats returns the size of the queue and what it dropped
+func (q *Queue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := q.stats
+	stats.Segments = len(q.segments)
+	for _, seg := range q.segments {
+		stats.PendingBytes += seg.size
+	}
+	stats.PendingBytes -= q.offset
+	return stats
+}
+
+// Close saves the read position and closes the segments
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+
+	if q.reader != nil {
+		q.reader.Close()
+	}
+	if err := q.head.Close(); err != nil {
+		return fmt.Errorf("wal: close segment: %w", err)
+	}
+	return q.writeCursor()
+}
+
+// readRecord reads the record at offset in the segment
+func (q *Queue) readRecord(seg *segment, offset int64) ([]byte, time.Time, error) {
+	if q.reader == nil || q.readerID != seg.id {
+		if q.reader != nil {
+			q.reader.Close()
+		}
+		reader, err := os.Open(q.segmentPath(seg.id))
+		if err != nil {
+			q.reader = nil
+			return nil, time.Time{}, fmt.Errorf("wal: open segment: %w", err)
+		}
+		q.reader, q.readerID = reader, seg.id
+	}
+
+	header := make([]byte, _headerSize)
+	if err := q.readAt(header, offset, seg); err != nil {
+		return nil, time.Time{}, err
+	}
+	length := int64(binary.LittleEndian.Uint32(header[0:]))
+	if length > MaxRecordBytes {
+		return nil, time.Time{}, errCorrupted
+	}
+
+	record := make([]byte, _headerSize+length)
+	copy(record, header)
+	if err := q.readAt(record[_headerSize:], offset+_headerSize, seg); err != nil {
+		return nil, time.Time{}, err
+	}
+	if crc32.ChecksumIEEE(record[8:]) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, time.Time{}, errCorrupted
+	}
+
+	appendedAt := time.Unix(0, int64(binary.LittleEndian.Uint64(header[8:])))
+	return record[_headerSize:], appendedAt, nil
+}
+
+// readAt fills buf from the reader at offset, reading past the end of the segment being corruption
+func (q *Queue) readAt(buf []byte, offset int64, seg *segment) error {
+	if offset+int64(len(buf)) > seg.size {
+		return errCorrupted
+	}
+	if _, err := q.reader.ReadAt(buf, offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errCorrupted
+		}
+		return fmt.Errorf("wal: read segment: %w", err)
+	}
+	return nil
+}
+
+// enforceMaxBytes drops the oldest segments until the queue fits in MaxBytes
+func (q *Queue) enforceMaxBytes() error {
+	if q.opts.MaxBytes <= 0 {
+		return nil
+	}
+
+	var size int64
+	for _, seg := range q.segments {
+		size += seg.size
+	}
+	for size > q.opts.MaxBytes && len(q.segments) > 1 {
+		oldest := q.segments[0]
+		q.stats.DroppedBytes += oldest.size - q.offset
+		size -= oldest.size
+		if err := q.removeOldest(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeOldest deletes the oldest segment, forgetting the records in flight from it, and moves
+// the position of the oldest record not acknowledged to the next one
+func (q *Queue) removeOldest() error {
+	oldest := q.segments[0]
+	if q.reader != nil && q.readerID == oldest.id {
+		q.reader.Close()
+		q.reader = nil
+	}
+	if err := os.Remove(q.segmentPath(oldest.id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("wal: remove segment: %w", err)
+	}
+	q.segments = q.segments[1:]
+	q.offset = 0
+
+	kept := q.inflight[:0]
+	for _, record := range q.inflight {
+		if record.end.id != oldest.id {
+			kept = append(kept, record)
+		}
+	}
+	q.inflight = kept
+	if q.read.id == oldest.id {
+		q.read = position{id: q.segments[0].id}
+	}
+	return nil
+}
+
+func (q *Queue) segmentIndex(id uint64) int {
+	for i, seg := range q.segments {
+		if seg.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (q *Queue) createSegment(id uint64) error {
+	head, err := os.OpenFile(q.segmentPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("wal: create segment: %w", err)
+	}
+	q.head = head
+	q.segments = append(q.segments, &segment{id: id})
+	return nil
+}
+
+func (q *Queue) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, _segmentSuffix))
+}
+
+// readCursor returns the read position saved by writeCursor, zero if there is none
+func (q *Queue) readCursor() (uint64, int64, error) {
+	data, err := os.ReadFile(filepath.Join(q.dir, _cursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("wal: read cursor: %w", err)
+	}
+
+	var id uint64
+	var offset int64
+	if _, err := fmt.Sscanf(string(data), "%d %d", &id, &offset); err != nil {
+		// Start over from the oldest segment, records are sent twice rather than lost
+		return 0, 0, nil
+	}
+	return id, offset, nil
+}
+
+// writeCursor saves the read position, replacing the file atomically
+func (q *Queue) writeCursor() error {
+	path := filepath.Join(q.dir, _cursorFile)
+	data := fmt.Sprintf("%d %d\n", q.segments[0].id, q.offset)
+	if err := os.WriteFile(path+".tmp", []byte(data), 0o600); err != nil {
+		return fmt.Errorf("wal: write cursor: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("wal: write cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain reads and acknowledges every record of the queue
+func drain(t *testing.T, q *Queue) []string {
+	t.Helper()
+	var records []string
+	for {
+		data, ok, err := q.Peek()
+		require.NoError(t, err)
+		if !ok {
+			return records
+		}
+		records = append(records, string(data))
+		require.NoError(t, q.Ack())
+	}
+}
+
+func appendRecords(t *testing.T, q *Queue, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		require.NoError(t, q.Append([]byte(fmt.Sprintf("record-%02d", i))))
+	}
+}
+
+func records(from, to int) []string {
+	var records []string
+	for i := from; i < to; i++ {
+		records = append(records, fmt.Sprintf("record-%02d", i))
+	}
+	return records
+}
+
+func TestQueue_FIFOAcrossSegments(t *testing.T) {
+	q, err := Open(t.TempDir(), Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	defer q.Close()
+
+	appendRecords(t, q, 0, 10)
+	assert.Greater(t, q.Stats().Segments, 1)
+	select {
+	case <-q.Notify():
+	default:
+		t.Fatal("appending did not notify")
+	}
+
+	data, ok, err := q.Peek()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "record-00", string(data))
+	data, _, _ = q.Peek()
+	assert.Equal(t, "record-00", string(data), "a record stays in the queue until acknowledged")
+
+	assert.Equal(t, records(0, 10), drain(t, q))
+	stats := q.Stats()
+	assert.Equal(t, int64(0), stats.PendingBytes)
+	assert.Equal(t, 1, stats.Segments, "read segments are deleted")
+}
+
+func TestQueue_ResumesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	q, err := Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	appendRecords(t, q, 0, 6)
+	for i := 0; i < 4; i++ {
+		_, _, err := q.Peek()
+		require.NoError(t, err)
+		require.NoError(t, q.Ack())
+	}
+	_, _, err = q.Peek()
+	require.NoError(t, err)
+	require.NoError(t, q.Close())
+
+	q, err = Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	defer q.Close()
+	appendRecords(t, q, 6, 8)
+
+	assert.Equal(t, records(4, 8), drain(t, q), "the peeked record was not acknowledged")
+}
+
+func TestQueue_MaxBytesDropsOldest(t *testing.T) {
+	q, err := Open(t.TempDir(), Options{MaxBytes: 200})
+	require.NoError(t, err)
+	defer q.Close()
+
+	// Records take 25 bytes and segments are rotated at 50 bytes
+	appendRecords(t, q, 0, 20)
+
+	stats := q.Stats()
+	assert.LessOrEqual(t, stats.PendingBytes, int64(200))
+	assert.Equal(t, int64(20*25)-stats.PendingBytes, stats.DroppedBytes)
+	got := drain(t, q)
+	assert.Equal(t, records(20-len(got), 20), got, "the newest records are kept")
+}
+
+func TestQueue_MaxAgeExpires(t *testing.T) {
+	q, err := Open(t.TempDir(), Options{MaxAge: time.Hour})
+	require.NoError(t, err)
+	defer q.Close()
+
+	now := time.Now()
+	q.now = func() time.Time { return now.Add(-2 * time.Hour) }
+	appendRecords(t, q, 0, 3)
+	q.now = func() time.Time { return now }
+	appendRecords(t, q, 3, 5)
+
+	assert.Equal(t, records(3, 5), drain(t, q))
+	assert.Equal(t, int64(3), q.Stats().ExpiredRecords)
+}
+
+func TestQueue_SkipsTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	q, err := Open(dir, Options{})
+	require.NoError(t, err)
+	appendRecords(t, q, 0, 3)
+	require.NoError(t, q.Close())
+
+	// A crash in the middle of the last record
+	path := filepath.Join(dir, fmt.Sprintf("%020d%s", 1, _segmentSuffix))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-4))
+
+	q, err = Open(dir, Options{})
+	require.NoError(t, err)
+	defer q.Close()
+	appendRecords(t, q, 3, 4)
+
+	assert.Equal(t, []string{"record-00", "record-01", "record-03"}, drain(t, q))
+	assert.Equal(t, int64(1), q.Stats().CorruptedSegments)
+}
+
+func TestQueue_Closed(t *testing.T) {
+	q, err := Open(t.TempDir(), Options{})
+	require.NoError(t, err)
+	require.NoError(t, q.Close())
+
+	assert.ErrorIs(t, q.Append([]byte("record")), ErrClosed)
+	_, _, err = q.Peek()
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestQueue_ReadsAheadOfAcknowledgments(t *testing.T) {
+	dir := t.TempDir()
+	q, err := Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	appendRecords(t, q, 0, 6)
+
+	var read []string
+	for i := 0; i < 4; i++ {
+		data, ok, err := q.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+		read = append(read, string(data))
+	}
+	assert.Equal(t, records(0, 4), read)
+
+	require.NoError(t, q.Ack())
+	require.NoError(t, q.Ack())
+
+	// The records read but not acknowledged are read again after a rewind, or a restart
+	q.Rewind()
+	data, _, err := q.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "record-02", string(data))
+	require.NoError(t, q.Close())
+
+	q, err = Open(dir, Options{SegmentBytes: 64})
+	require.NoError(t, err)
+	defer q.Close()
+	assert.Equal(t, records(2, 6), drain(t, q))
+}